@@ -17,6 +17,20 @@ var (
 	ErrHTTPStatus     = errors.New("bad HTTP(S) status")
 	ErrTooLarge       = errors.New("too laege decompressed data")
 	ErrClipboard      = errors.New("cannot set --clipborad and --file options at onece")
+	ErrOutputFormat   = errors.New("unknown output format")
+	ErrDOTConfig      = errors.New("invalid DOT config")
+	ErrTemplate       = errors.New("invalid template")
+	ErrQuery          = errors.New("invalid query")
+	ErrNoTerminal     = errors.New("cannot open terminal")
+	ErrSessionKey     = errors.New("invalid session key")
+	ErrUnsupportedAlg = errors.New("unsupported algorithm")
+	ErrIntegrity      = errors.New("integrity check failed")
+	ErrNoSecretKey    = errors.New("no matching secret key")
+	ErrPassphrase     = errors.New("invalid passphrase")
+	ErrNeedPassphrase = errors.New("passphrase is required for protected secret key")
+	ErrDivertToCard   = errors.New("decryption is impossible: secret key is on smartcard (GNU divert-to-card stub)")
+	ErrDummyKey       = errors.New("decryption is impossible: secret key is not included (GNU dummy stub)")
+	ErrKeyMismatch    = errors.New("secret key does not match public key")
 )
 
 /* Copyright 2019-2021 Spiegel
@@ -0,0 +1,89 @@
+package facade
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gocli/rwi"
+	"github.com/spiegel-im-spiegel/gpgpdump/facade/browse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+)
+
+//newBrowseCmd returns cobra.Command instance for browse sub-command
+func newBrowseCmd(ui *rwi.RWI) *cobra.Command {
+	browseCmd := &cobra.Command{
+		Use:     "browse [flags]",
+		Aliases: []string{"b"},
+		Short:   "Browses OpenPGP packets in interactive tree view",
+		Long:    "Browses OpenPGP packets in interactive tree view (full-screen terminal UI; press q to quit).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cxt := parseContext(cmd)
+
+			//options
+			filePath, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --file option", errs.WithCause(err)))
+			}
+			cbFlag, err := cmd.Flags().GetBool("clipboard")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --clipboard option", errs.WithCause(err)))
+			}
+
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
+			//open PGP file
+			in, err := openInput(ui, cxt, filePath, cbFlag)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer in.Close()
+
+			//parse OpenPGP packets
+			p, err := parse.NewWithContext(ctx, cxt, in)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			res, err := p.Parse()
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			res, err = queryPacketInfo(cmd, res)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			res, err = filterPacketInfo(cmd, res)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			if redacted, err := redactPacketInfo(cmd, res); err != nil {
+				return debugPrint(ui, cxt, err)
+			} else if redacted != nil {
+				res = redacted
+			}
+			return debugPrint(ui, cxt, browse.Run(res))
+		},
+	}
+	browseCmd.Flags().StringP("file", "f", "", "path of OpenPGP file")
+	_ = browseCmd.MarkFlagFilename("file")
+	browseCmd.Flags().BoolP("clipboard", "", false, "input from clipboard (ASCII armor text only)")
+
+	return browseCmd
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,61 @@
+package browse
+
+import (
+	"bufio"
+	"bytes"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l" //alternate screen buffer and hidden cursor
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+//Run shows interactive tree view of packets on terminal until quit
+func Run(info *result.Info) error {
+	t, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer t.close()
+	if _, err := t.Write([]byte(enterScreen)); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() { _, _ = t.Write([]byte(leaveScreen)) }()
+
+	b := newBrowser(info)
+	r := bufio.NewReader(t)
+	buf := &bytes.Buffer{}
+	for {
+		b.resize(t.size())
+		buf.Reset()
+		b.render(buf)
+		if _, err := t.Write(buf.Bytes()); err != nil {
+			return errs.Wrap(err)
+		}
+		k, err := readKey(r)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		if !b.key(k) {
+			return nil
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
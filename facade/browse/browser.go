@@ -0,0 +1,490 @@
+package browse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/render"
+)
+
+//helpText is text of status line
+const helpText = "j/k:move h/l:fold Enter:toggle [/]:packet /:search n/N:match e/c:expand/collapse all q:quit"
+
+//browser class is state of tree view
+type browser struct {
+	roots   []*node
+	visible []*node //nodes in expanded tree
+	cursor  int     //index of selected node in visible
+	top     int     //index of first line in visible
+	width   int
+	height  int
+
+	query   string
+	input   bool //typing query for search
+	message string
+}
+
+//newBrowser returns browser instance (packets are collapsed)
+func newBrowser(info *result.Info) *browser {
+	b := &browser{width: 80, height: 24}
+	if info != nil {
+		b.roots = newNodes(info.Packets, nil, 0)
+	}
+	b.refresh()
+	return b
+}
+
+//refresh rebuilds visible nodes with keeping selected node
+func (b *browser) refresh() {
+	sel := b.selected()
+	b.visible = b.visible[:0]
+	var walk func(nodes []*node)
+	walk = func(nodes []*node) {
+		for _, n := range nodes {
+			b.visible = append(b.visible, n)
+			if n.expanded {
+				walk(n.children)
+			}
+		}
+	}
+	walk(b.roots)
+	b.cursor = 0
+	for i, n := range b.visible {
+		if n == sel {
+			b.cursor = i
+			break
+		}
+	}
+}
+
+//selected returns selected node (nil if tree is empty)
+func (b *browser) selected() *node {
+	if b.cursor < 0 || b.cursor >= len(b.visible) {
+		return nil
+	}
+	return b.visible[b.cursor]
+}
+
+//resize sets size of terminal
+func (b *browser) resize(width, height int) {
+	if width > 0 {
+		b.width = width
+	}
+	if height > 0 {
+		b.height = height
+	}
+}
+
+//pageSize returns number of lines of tree view
+func (b *browser) pageSize() int {
+	if h := b.height - 2; h > 0 { //title and status lines
+		return h
+	}
+	return 1
+}
+
+//move moves cursor by delta
+func (b *browser) move(delta int) {
+	b.cursor += delta
+	if b.cursor >= len(b.visible) {
+		b.cursor = len(b.visible) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+//expand expands selected node, or moves to its first child if expanded
+func (b *browser) expand() {
+	n := b.selected()
+	if n == nil || len(n.children) == 0 {
+		return
+	}
+	if n.expanded {
+		b.move(1)
+		return
+	}
+	n.expanded = true
+	b.refresh()
+}
+
+//collapse collapses selected node, or moves to its parent if collapsed
+func (b *browser) collapse() {
+	n := b.selected()
+	if n == nil {
+		return
+	}
+	if n.expanded {
+		n.expanded = false
+		b.refresh()
+		return
+	}
+	if n.parent != nil {
+		b.selectNode(n.parent)
+	}
+}
+
+//toggle expands or collapses selected node
+func (b *browser) toggle() {
+	if n := b.selected(); n != nil && len(n.children) > 0 {
+		n.expanded = !n.expanded
+		b.refresh()
+	}
+}
+
+//expandAll expands or collapses all nodes
+func (b *browser) expandAll(flag bool) {
+	sel := b.selected()
+	walkNodes(b.roots, func(n *node) {
+		n.expanded = flag && len(n.children) > 0
+	})
+	if !flag {
+		for sel != nil && sel.parent != nil {
+			sel = sel.parent
+		}
+	}
+	b.selectNode(sel)
+}
+
+//selectNode selects node with expanding its ancestors
+func (b *browser) selectNode(n *node) {
+	if n == nil {
+		return
+	}
+	for p := n.parent; p != nil; p = p.parent {
+		p.expanded = true
+	}
+	b.refresh()
+	for i, v := range b.visible {
+		if v == n {
+			b.cursor = i
+			return
+		}
+	}
+}
+
+//next selects next (or previous) node matched by fn in depth-first order (with wrap around)
+func (b *browser) next(forward bool, fn func(*node) bool) bool {
+	all := []*node{}
+	walkNodes(b.roots, func(n *node) {
+		all = append(all, n)
+	})
+	if len(all) == 0 {
+		return false
+	}
+	start := 0
+	sel := b.selected()
+	for i, n := range all {
+		if n == sel {
+			start = i
+			break
+		}
+	}
+	for i := 1; i <= len(all); i++ {
+		j := start + i
+		if !forward {
+			j = start - i
+		}
+		n := all[((j%len(all))+len(all))%len(all)]
+		if fn(n) {
+			b.selectNode(n)
+			return true
+		}
+	}
+	return false
+}
+
+//search selects next (or previous) node matched by query
+func (b *browser) search(forward bool) {
+	if len(b.query) == 0 {
+		return
+	}
+	if !b.next(forward, func(n *node) bool { return n.match(b.query) }) {
+		b.message = fmt.Sprintf("not found: %s", b.query)
+	}
+}
+
+//nextPacket selects next (or previous) packet
+func (b *browser) nextPacket(forward bool) {
+	b.next(forward, (*node).isPacket)
+}
+
+//key handles key input, and returns false if quit
+func (b *browser) key(k key) bool {
+	b.message = ""
+	if b.input {
+		return b.inputKey(k)
+	}
+	switch k.code {
+	case keyInterrupt:
+		return false
+	case keyUp:
+		b.move(-1)
+	case keyDown:
+		b.move(1)
+	case keyPageUp:
+		b.move(-b.pageSize())
+	case keyPageDown:
+		b.move(b.pageSize())
+	case keyHome:
+		b.cursor = 0
+	case keyEnd:
+		b.move(len(b.visible))
+	case keyLeft:
+		b.collapse()
+	case keyRight:
+		b.expand()
+	case keyEnter:
+		b.toggle()
+	case keyTab:
+		b.nextPacket(true)
+	case keyBacktab:
+		b.nextPacket(false)
+	case keyRune:
+		return b.runeKey(k.r)
+	}
+	return true
+}
+
+//runeKey handles character key, and returns false if quit
+func (b *browser) runeKey(r rune) bool {
+	switch r {
+	case 'q', 'Q':
+		return false
+	case 'k':
+		b.move(-1)
+	case 'j':
+		b.move(1)
+	case 'g':
+		b.cursor = 0
+	case 'G':
+		b.move(len(b.visible))
+	case 'h':
+		b.collapse()
+	case 'l':
+		b.expand()
+	case ' ':
+		b.toggle()
+	case ']':
+		b.nextPacket(true)
+	case '[':
+		b.nextPacket(false)
+	case 'e':
+		b.expandAll(true)
+	case 'c':
+		b.expandAll(false)
+	case '/':
+		b.input = true
+		b.query = ""
+	case 'n':
+		b.search(true)
+	case 'N':
+		b.search(false)
+	}
+	return true
+}
+
+//inputKey handles key input in search mode
+func (b *browser) inputKey(k key) bool {
+	switch k.code {
+	case keyInterrupt:
+		return false
+	case keyEscape:
+		b.input = false
+		b.query = ""
+	case keyEnter:
+		b.input = false
+		b.search(true)
+	case keyBackspace:
+		if _, size := utf8.DecodeLastRuneInString(b.query); size > 0 {
+			b.query = b.query[:len(b.query)-size]
+		}
+	case keyRune:
+		b.query += string(k.r)
+	}
+	return true
+}
+
+//render outputs screen (lines are terminated by CRLF for raw mode)
+func (b *browser) render(w io.Writer) {
+	page := b.pageSize()
+	if b.cursor < b.top {
+		b.top = b.cursor
+	}
+	if b.cursor >= b.top+page {
+		b.top = b.cursor - page + 1
+	}
+	treeWidth, detailWidth := b.width, 0
+	if b.width >= 60 { //side pane
+		treeWidth = b.width * 3 / 5
+		detailWidth = b.width - treeWidth - 1
+	}
+	details := []string{}
+	sel := b.selected()
+	if sel != nil && detailWidth > 0 {
+		details = detailLines(sel, detailWidth)
+	}
+
+	fmt.Fprint(w, "\x1b[H\x1b[2J")
+	title := "gpgpdump"
+	if sel != nil {
+		title += ": " + strings.Join(sel.path(), " > ")
+	}
+	fmt.Fprintf(w, "\x1b[1m%s\x1b[0m\r\n", fit(title, b.width))
+	for i := 0; i < page; i++ {
+		line := ""
+		if n := b.top + i; n < len(b.visible) {
+			line = treeLine(b.visible[n], treeWidth)
+			if n == b.cursor {
+				line = "\x1b[7m" + line + "\x1b[0m"
+			}
+		} else {
+			line = fit("", treeWidth)
+		}
+		if detailWidth > 0 {
+			d := ""
+			if i < len(details) {
+				d = details[i]
+			}
+			line += "│" + fit(d, detailWidth)
+		}
+		fmt.Fprintf(w, "%s\r\n", line)
+	}
+	fmt.Fprint(w, b.status())
+}
+
+//status returns text of status line
+func (b *browser) status() string {
+	pos := fmt.Sprintf(" %d/%d", b.cursor+1, len(b.visible))
+	text := helpText
+	switch {
+	case b.input:
+		text = "/" + b.query + "_"
+	case len(b.message) > 0:
+		text = b.message
+	}
+	width := b.width - utf8.RuneCountInString(pos)
+	if width < 0 {
+		width = 0
+	}
+	return "\x1b[7m" + fit(text, width) + pos + "\x1b[0m"
+}
+
+//treeLine returns line of node in tree view
+func treeLine(n *node, width int) string {
+	mark := "  "
+	if len(n.children) > 0 {
+		mark = "▸ "
+		if n.expanded {
+			mark = "▾ "
+		}
+	}
+	return fit(strings.Repeat("  ", n.depth)+mark+n.label(), width)
+}
+
+//detailLines returns lines in side pane for selected node
+func detailLines(n *node, width int) []string {
+	lines := []string{}
+	add := func(s string) {
+		lines = append(lines, wrap(s, width)...)
+	}
+	itm := n.item
+	add(itm.Name)
+	if len(itm.Value) > 0 {
+		add("Value: " + itm.Value)
+	}
+	if len(itm.Note) > 0 {
+		add("Note: " + itm.Note)
+	}
+	if len(itm.Dump) > 0 {
+		add("Dump: " + itm.Dump)
+	}
+	if h := itm.Header; h != nil {
+		add(fmt.Sprintf("Offset: %d (header %d bytes, body %d bytes)", h.Offset, h.HeaderLen, len(itm.Raw)))
+	}
+	var parent *result.Item
+	if n.parent != nil {
+		parent = n.parent.item
+	}
+	if exp, ok := render.Explanation(itm, parent); ok {
+		add("")
+		add(exp.Description)
+		if len(exp.Reference) > 0 {
+			add(exp.Reference)
+		}
+	}
+	if raw := n.raw(); len(raw) > 0 {
+		add("")
+		add(fmt.Sprintf("Raw data (%d bytes):", len(raw)))
+		lines = append(lines, hexLines(raw, width)...)
+	}
+	return lines
+}
+
+//hexLines returns hex dump of data fitted to width
+func hexLines(data []byte, width int) []string {
+	perLine := 16
+	for perLine > 4 && 6+perLine*3 > width {
+		perLine /= 2
+	}
+	lines := []string{}
+	for off := 0; off < len(data); off += perLine {
+		end := off + perLine
+		if end > len(data) {
+			end = len(data)
+		}
+		hex := make([]string, 0, perLine)
+		for _, c := range data[off:end] {
+			hex = append(hex, fmt.Sprintf("%02x", c))
+		}
+		lines = append(lines, fmt.Sprintf("%04x  %s", off, strings.Join(hex, " ")))
+	}
+	return lines
+}
+
+//fit returns string truncated or padded to width (in runes)
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	rs := []rune(s)
+	if len(rs) > width {
+		if width == 1 {
+			return "…"
+		}
+		return string(rs[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(rs))
+}
+
+//wrap returns lines of string wrapped at width (in runes)
+func wrap(s string, width int) []string {
+	rs := []rune(s)
+	if width <= 0 || len(rs) <= width {
+		return []string{s}
+	}
+	lines := []string{}
+	for len(rs) > width {
+		lines = append(lines, string(rs[:width]))
+		rs = rs[width:]
+	}
+	return append(lines, string(rs))
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,156 @@
+package browse
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func parseFile(t *testing.T, name string) *result.Info {
+	t.Helper()
+	file, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("os.Open(%v) = \"%+v\", want nil error.", name, err)
+	}
+	defer file.Close()
+	p, err := parse.New(context.New(context.Set(context.ARMOR, true), context.Set(context.UTC, true)), file)
+	if err != nil {
+		t.Fatalf("parse.New() = \"%+v\", want nil error.", err)
+	}
+	info, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() = \"%+v\", want nil error.", err)
+	}
+	return info
+}
+
+func runes(s string) []key {
+	keys := []key{}
+	for _, r := range s {
+		keys = append(keys, key{code: keyRune, r: r})
+	}
+	return keys
+}
+
+func TestBrowserKey(t *testing.T) {
+	testCases := []struct {
+		keys    []key
+		visible int
+		want    string
+		quit    bool
+	}{
+		{keys: nil, visible: 5, want: "Public-Key Packet (tag 6)"},
+		{keys: runes("jj"), visible: 5, want: "Signature Packet (tag 2)"},
+		{keys: []key{{code: keyEnd}, {code: keyDown}}, visible: 5, want: "Signature Packet (tag 2)"},
+		{keys: runes("jl"), visible: 6, want: "User ID Packet (tag 13)"},
+		{keys: runes("jll"), visible: 6, want: "User ID"},
+		{keys: runes("jllh"), visible: 6, want: "User ID Packet (tag 13)"},
+		{keys: runes("jllhh"), visible: 5, want: "User ID Packet (tag 13)"},
+		{keys: append(runes("j"), key{code: keyEnter}, key{code: keyEnter}), visible: 5, want: "User ID Packet (tag 13)"},
+		{keys: runes("]]]"), visible: 5, want: "Public-Subkey Packet (tag 14)"},
+		{keys: []key{{code: keyBacktab}}, visible: 5, want: "Signature Packet (tag 2)"},
+		{keys: append(runes("/issuer"), key{code: keyEnter}), visible: 15, want: "Issuer (sub 16)"},
+		{keys: append(runes("/issuer"), key{code: keyEnter}, key{code: keyRune, r: 'n'}), visible: 25, want: "Issuer (sub 16)"},
+		{keys: append(runes("/ISSUER"), key{code: keyEnter}, key{code: keyRune, r: 'c'}), visible: 5, want: "Signature Packet (tag 2)"},
+		{keys: append(runes("/foo"), key{code: keyBackspace}, key{code: keyEscape}), visible: 5, want: "Public-Key Packet (tag 6)"},
+		{keys: runes("e"), visible: 68, want: "Public-Key Packet (tag 6)"},
+		{keys: runes("q"), visible: 5, want: "Public-Key Packet (tag 6)", quit: true},
+		{keys: []key{{code: keyInterrupt}}, visible: 5, want: "Public-Key Packet (tag 6)", quit: true},
+	}
+	info := parseFile(t, "../../testdata/eccpub.asc")
+	for _, tc := range testCases {
+		b := newBrowser(info)
+		quit := false
+		for _, k := range tc.keys {
+			if !b.key(k) {
+				quit = true
+				break
+			}
+		}
+		if quit != tc.quit {
+			t.Errorf("browser.key(%v) quit = %v, want %v.", tc.keys, quit, tc.quit)
+		}
+		if len(b.visible) != tc.visible {
+			t.Errorf("browser.key(%v) visible = %v, want %v.", tc.keys, len(b.visible), tc.visible)
+		}
+		if sel := b.selected(); sel == nil || !strings.HasPrefix(sel.item.Name, tc.want) {
+			t.Errorf("browser.key(%v) selected = %v, want %v.", tc.keys, sel, tc.want)
+		}
+	}
+}
+
+func TestBrowserSearchNotFound(t *testing.T) {
+	b := newBrowser(parseFile(t, "../../testdata/eccpub.asc"))
+	for _, k := range append(runes("/no such item"), key{code: keyEnter}) {
+		b.key(k)
+	}
+	if want := "not found: no such item"; b.message != want {
+		t.Errorf("browser.message = \"%v\", want \"%v\".", b.message, want)
+	}
+}
+
+func TestBrowserRender(t *testing.T) {
+	b := newBrowser(parseFile(t, "../../testdata/eccpub.asc"))
+	b.resize(100, 20)
+	for _, k := range append(runes("/issuer"), key{code: keyEnter}) {
+		b.key(k)
+	}
+	buf := &bytes.Buffer{}
+	b.render(buf)
+	str := buf.String()
+	for _, want := range []string{
+		"gpgpdump: Signature Packet (tag 2) > Unhashed Subpacket > Issuer (sub 16)",
+		"\x1b[7m      Issuer (sub 16): 0x31fbfda95fbbfa18",
+		"│RFC 9580 Section 5.2.3.12",
+		"│0000  31 fb fd a9 5f bb fa 18",
+		"10/15\x1b[0m",
+	} {
+		if !strings.Contains(str, want) {
+			t.Errorf("browser.render() = \"%v\", want \"%v\".", str, want)
+		}
+	}
+	if n := strings.Count(str, "\r\n"); n != 19 {
+		t.Errorf("browser.render() = %v lines, want %v.", n+1, 20)
+	}
+}
+
+func TestNodeRaw(t *testing.T) {
+	b := newBrowser(parseFile(t, "../../testdata/eccpub.asc"))
+	b.expandAll(true)
+	testCases := []struct {
+		name string
+		want []byte
+	}{
+		{name: "Public key creation time", want: []byte{0x54, 0xc3, 0x01, 0xbf}},
+		{name: "ECC Curve OID", want: []byte{0x08, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}},
+		{name: "Key Expiration Time", want: []byte{0x00, 0x09, 0x3a, 0x80}},
+	}
+	for _, tc := range testCases {
+		b.query = tc.name
+		b.cursor = 0
+		b.search(true)
+		if raw := b.selected().raw(); !bytes.Equal(raw, tc.want) {
+			t.Errorf("node.raw(%v) = % x, want % x.", tc.name, raw, tc.want)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
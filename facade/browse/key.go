@@ -0,0 +1,122 @@
+package browse
+
+import (
+	"bufio"
+)
+
+//keyCode is code of key input
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyTab
+	keyBacktab
+	keyInterrupt
+	keyUnknown
+)
+
+//key class is key input from terminal
+type key struct {
+	code keyCode
+	r    rune
+}
+
+//escapeKeys is table of ANSI escape sequences (CSI or SS3) for keys
+var escapeKeys = map[string]keyCode{
+	"A":  keyUp,
+	"B":  keyDown,
+	"C":  keyRight,
+	"D":  keyLeft,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"Z":  keyBacktab,
+	"1~": keyHome,
+	"4~": keyEnd,
+	"5~": keyPageUp,
+	"6~": keyPageDown,
+	"7~": keyHome,
+	"8~": keyEnd,
+}
+
+//readKey returns key input from terminal in raw mode
+func readKey(r *bufio.Reader) (key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return key{}, err
+	}
+	switch b {
+	case 0x1b:
+		if r.Buffered() == 0 {
+			return key{code: keyEscape}, nil
+		}
+		return readEscape(r)
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case 0x7f, 0x08:
+		return key{code: keyBackspace}, nil
+	case '\t':
+		return key{code: keyTab}, nil
+	case 0x03: //Ctrl-C
+		return key{code: keyInterrupt}, nil
+	}
+	if err := r.UnreadByte(); err != nil {
+		return key{}, err
+	}
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	return key{code: keyRune, r: c}, nil
+}
+
+//readEscape returns key of escape sequence (ESC is already read)
+func readEscape(r *bufio.Reader) (key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return key{}, err
+	}
+	if b != '[' && b != 'O' {
+		return key{code: keyUnknown}, nil
+	}
+	seq := []byte{}
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return key{}, err
+		}
+		seq = append(seq, c)
+		if 0x40 <= c && c <= 0x7e { //final byte
+			break
+		}
+	}
+	if code, ok := escapeKeys[string(seq)]; ok {
+		return key{code: code}, nil
+	}
+	return key{code: keyUnknown}, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
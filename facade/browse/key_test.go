@@ -0,0 +1,53 @@
+package browse
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want []key
+	}{
+		{in: "jk/q", want: []key{{code: keyRune, r: 'j'}, {code: keyRune, r: 'k'}, {code: keyRune, r: '/'}, {code: keyRune, r: 'q'}}},
+		{in: "\x1b[A\x1b[B\x1b[C\x1b[D", want: []key{{code: keyUp}, {code: keyDown}, {code: keyRight}, {code: keyLeft}}},
+		{in: "\x1bOH\x1bOF\x1b[5~\x1b[6~\x1b[Z", want: []key{{code: keyHome}, {code: keyEnd}, {code: keyPageUp}, {code: keyPageDown}, {code: keyBacktab}}},
+		{in: "\x1b[1;5A\x1bx", want: []key{{code: keyUnknown}, {code: keyUnknown}}},
+		{in: "\r\n\t\x7f\x03", want: []key{{code: keyEnter}, {code: keyEnter}, {code: keyTab}, {code: keyBackspace}, {code: keyInterrupt}}},
+		{in: "鍵", want: []key{{code: keyRune, r: '鍵'}}},
+	}
+	for _, tc := range testCases {
+		r := bufio.NewReader(strings.NewReader(tc.in))
+		for _, want := range tc.want {
+			k, err := readKey(r)
+			if err != nil {
+				t.Errorf("readKey(%q) = \"%+v\", want nil error.", tc.in, err)
+				break
+			}
+			if k != want {
+				t.Errorf("readKey(%q) = %v, want %v.", tc.in, k, want)
+			}
+		}
+	}
+	r := bufio.NewReader(strings.NewReader("\x1b"))
+	if k, err := readKey(r); err != nil || k.code != keyEscape {
+		t.Errorf("readKey(ESC) = %v, \"%+v\", want %v.", k, err, keyEscape)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
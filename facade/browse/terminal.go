@@ -0,0 +1,89 @@
+package browse
+
+import (
+	"os"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"golang.org/x/term"
+)
+
+//terminal class is controlling terminal in raw mode
+type terminal struct {
+	in      *os.File
+	out     *os.File
+	state   *term.State //saved by term.MakeRaw
+	restore func()      //restores OS specific mode of output
+}
+
+//openTerminal returns controlling terminal in raw mode (standard input may be OpenPGP data)
+func openTerminal() (*terminal, error) {
+	in, out, restore, err := openTTY()
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrNoTerminal, errs.WithCause(err))
+	}
+	t := &terminal{in: in, out: out, restore: restore}
+	t.state, err = term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		t.closeFiles()
+		return nil, errs.Wrap(ecode.ErrNoTerminal, errs.WithCause(err))
+	}
+	return t, nil
+}
+
+//Read reads key input from terminal
+func (t *terminal) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+//Write writes to terminal
+func (t *terminal) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+//size returns width and height of terminal (zero if unknown)
+func (t *terminal) size() (int, int) {
+	cols, rows, err := term.GetSize(int(t.out.Fd()))
+	if err != nil {
+		return 0, 0
+	}
+	return cols, rows
+}
+
+//close restores state of terminal and closes it
+func (t *terminal) close() error {
+	err := term.Restore(int(t.in.Fd()), t.state)
+	if e := t.closeFiles(); err == nil {
+		err = e
+	}
+	return errs.Wrap(err)
+}
+
+//closeFiles restores output mode and closes files of terminal
+func (t *terminal) closeFiles() error {
+	if t.restore != nil {
+		t.restore()
+	}
+	err := t.in.Close()
+	if t.out != t.in {
+		if e := t.out.Close(); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,51 @@
+//go:build windows
+// +build windows
+
+package browse
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+//openTTY returns console for input and output (escape sequences are enabled on output)
+func openTTY() (*os.File, *os.File, func(), error) {
+	in, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	out, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		in.Close()
+		return nil, nil, nil, err
+	}
+	h := windows.Handle(out.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		in.Close()
+		out.Close()
+		return nil, nil, nil, err
+	}
+	if err := windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		in.Close()
+		out.Close()
+		return nil, nil, nil, err
+	}
+	return in, out, func() { _ = windows.SetConsoleMode(h, mode) }, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
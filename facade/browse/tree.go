@@ -0,0 +1,111 @@
+package browse
+
+import (
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//node class is item in tree view
+type node struct {
+	item     *result.Item
+	parent   *node
+	children []*node
+	depth    int
+	expanded bool
+}
+
+//newNodes returns nodes of items (collapsed)
+func newNodes(items []*result.Item, parent *node, depth int) []*node {
+	nodes := []*node{}
+	for _, itm := range items {
+		if itm == nil {
+			continue
+		}
+		n := &node{item: itm, parent: parent, depth: depth}
+		n.children = newNodes(itm.Items, n, depth+1)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+//label returns text of node in tree view
+func (n *node) label() string {
+	s := n.item.Name
+	if len(n.item.Value) > 0 {
+		s += ": " + n.item.Value
+	}
+	if len(n.item.Note) > 0 {
+		s += " (" + n.item.Note + ")"
+	}
+	return s
+}
+
+//isPacket returns true if node is OpenPGP packet
+func (n *node) isPacket() bool {
+	return n.item.Kind == result.KindPacket
+}
+
+//match returns true if name, value or note of item includes query (case insensitive)
+func (n *node) match(query string) bool {
+	q := strings.ToLower(query)
+	for _, s := range []string{n.item.Name, n.item.Value, n.item.Note} {
+		if strings.Contains(strings.ToLower(s), q) {
+			return true
+		}
+	}
+	return false
+}
+
+//path returns names of node and its ancestors
+func (n *node) path() []string {
+	names := []string{}
+	for p := n; p != nil; p = p.parent {
+		names = append([]string{p.item.Name}, names...)
+	}
+	return names
+}
+
+//raw returns raw data of item (offsets in span are relative to data of packet or sub-packet including it)
+func (n *node) raw() []byte {
+	if len(n.item.Raw) > 0 {
+		return n.item.Raw
+	}
+	s := n.item.Span
+	if s == nil {
+		return nil
+	}
+	for p := n.parent; p != nil; p = p.parent {
+		if p.item.Kind != result.KindPacket && p.item.Kind != result.KindSubpacket {
+			continue
+		}
+		if 0 <= s.Start && s.Start < s.End && s.End <= int64(len(p.item.Raw)) {
+			return p.item.Raw[s.Start:s.End]
+		}
+		break
+	}
+	return nil
+}
+
+//walkNodes calls fn for each node in depth-first order
+func walkNodes(nodes []*node, fn func(*node)) {
+	for _, n := range nodes {
+		fn(n)
+		walkNodes(n.children, fn)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,44 @@
+package facade
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gocli/signal"
+)
+
+//signalContext returns context.Context canceled by SIGINT (Ctrl-C) or --timeout option.
+func signalContext(cmd *cobra.Command) (context.Context, context.CancelFunc, error) {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, nil, errs.New("error in --timeout option", errs.WithCause(err))
+	}
+	if timeout < 0 {
+		return nil, nil, errs.New("error in --timeout option", errs.WithCause(os.ErrInvalid), errs.WithContext("timeout", timeout))
+	}
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	return signal.Context(ctx, os.Interrupt), cancel, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
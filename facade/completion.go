@@ -57,21 +57,22 @@ func newCompletionCmd(ui *rwi.RWI, rootCmd *cobra.Command) *cobra.Command {
 		Short:   "Generate completion script",
 		Long:    fmt.Sprintf(longDescription, Name),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cxt := parseContext(cmd)
 			if len(args) == 0 {
-				return debugPrint(ui, rootCmd.Root().GenBashCompletion(ui.Writer()))
+				return debugPrint(ui, cxt, rootCmd.Root().GenBashCompletion(ui.Writer()))
 			} else if len(args) == 1 {
 				switch {
 				case strings.EqualFold(args[0], "bash"):
-					return debugPrint(ui, rootCmd.Root().GenBashCompletion(ui.Writer()))
+					return debugPrint(ui, cxt, rootCmd.Root().GenBashCompletion(ui.Writer()))
 				case strings.EqualFold(args[0], "zsh"):
-					return debugPrint(ui, rootCmd.Root().GenZshCompletion(ui.Writer()))
+					return debugPrint(ui, cxt, rootCmd.Root().GenZshCompletion(ui.Writer()))
 				case strings.EqualFold(args[0], "fish"):
-					return debugPrint(ui, rootCmd.Root().GenFishCompletion(ui.Writer(), true))
+					return debugPrint(ui, cxt, rootCmd.Root().GenFishCompletion(ui.Writer(), true))
 				case strings.EqualFold(args[0], "powershell"):
-					return debugPrint(ui, rootCmd.Root().GenPowerShellCompletion(ui.Writer()))
+					return debugPrint(ui, cxt, rootCmd.Root().GenPowerShellCompletion(ui.Writer()))
 				}
 			}
-			return debugPrint(ui, os.ErrInvalid)
+			return debugPrint(ui, cxt, os.ErrInvalid)
 		},
 	}
 
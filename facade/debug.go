@@ -4,10 +4,11 @@ import (
 	"fmt"
 
 	"github.com/spiegel-im-spiegel/gocli/rwi"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
 )
 
-func debugPrint(ui *rwi.RWI, err error) error {
-	if debugFlag && err != nil {
+func debugPrint(ui *rwi.RWI, cxt *context.Context, err error) error {
+	if cxt.Debug() && err != nil {
 		fmt.Fprintf(ui.Writer(), "%+v\n", err)
 		return nil
 	}
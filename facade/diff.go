@@ -0,0 +1,121 @@
+package facade
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gocli/rwi"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/render"
+)
+
+//newDiffCmd returns cobra.Command instance for diff sub-command
+func newDiffCmd(ui *rwi.RWI) *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:     "diff [flags] FILE1 FILE2",
+		Aliases: []string{"d"},
+		Short:   "Reports structural difference between two OpenPGP inputs",
+		Long:    "Reports structural difference between two OpenPGP inputs (packets are aligned by fingerprint, user ID and signature issuer/time; \"-\" is standard input).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cxt := parseContext(cmd)
+			if len(args) != 2 {
+				return debugPrint(ui, cxt, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
+			}
+
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
+			//parse OpenPGP packets
+			infos := make([]*result.Info, 0, len(args))
+			for _, path := range args {
+				res, err := parseFile(ctx, cmd, ui, path)
+				if err != nil {
+					return debugPrint(ui, cxt, err)
+				}
+				infos = append(infos, res)
+			}
+			r, err := marshalDiff(cmd, render.Compare(infos[0], infos[1]))
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			return debugPrint(ui, cxt, errs.Wrap(ui.WriteFrom(r)))
+		},
+	}
+
+	return diffCmd
+}
+
+//parseFile returns result.Info parsed from file ("-" is standard input)
+func parseFile(ctx context.Context, cmd *cobra.Command, ui *rwi.RWI, path string) (*result.Info, error) {
+	var r io.Reader = ui.Reader()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, errs.Wrap(err, errs.WithContext("file", path))
+		}
+		defer file.Close()
+		r = file
+	}
+	p, err := parse.NewWithContext(ctx, parseContext(cmd), r)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("file", path))
+	}
+	res, err := p.Parse()
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("file", path))
+	}
+	return res, nil
+}
+
+//marshalDiff returns output of render.Diff formatted by options (text or json)
+func marshalDiff(cmd *cobra.Command, d *render.Diff) (io.Reader, error) {
+	if err := checkFormatOptions(cmd); err != nil {
+		return nil, err
+	}
+	format, err := cmd.Flags().GetString("output-format")
+	if err != nil {
+		return nil, errs.New("error in --output-format option", errs.WithCause(err))
+	}
+	jsonFlag, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return nil, errs.New("error in --json option", errs.WithCause(err))
+	}
+	indentSize, err := cmd.Flags().GetInt("indent")
+	if err != nil {
+		return nil, errs.New("error in --indent option", errs.WithCause(err))
+	}
+	if jsonFlag {
+		format = "json"
+	}
+	switch strings.ToLower(format) {
+	case "text":
+		return d.Text(), nil
+	case "json":
+		return d.JSON(indentSize)
+	}
+	return nil, errs.New("error in --output-format option (text or json only)", errs.WithCause(ecode.ErrOutputFormat), errs.WithContext("format", format))
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -12,9 +12,11 @@ import (
 	"github.com/spiegel-im-spiegel/gocli/rwi"
 	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
 	"github.com/spiegel-im-spiegel/gpgpdump/facade/clipboard"
+	"github.com/spiegel-im-spiegel/gpgpdump/locale"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/render"
 )
 
 var (
@@ -24,15 +26,6 @@ var (
 	Version = "dev-version"
 )
 
-var (
-	versionFlag bool //version flag
-	jsonFlag    bool //output with JSON format
-	cbFlag      bool //input from clipboard
-	debugFlag   bool //debug flag
-	indentSize  int
-	filePath    string
-)
-
 //newRootCmd returns cobra.Command instance for root command
 func newRootCmd(ui *rwi.RWI, args []string) *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -40,67 +33,97 @@ func newRootCmd(ui *rwi.RWI, args []string) *cobra.Command {
 		Short: "OpenPGP packet visualizer",
 		Long:  "OpenPGP (RFC 4880) packet visualizer by golang.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			//options options
+			cxt := parseContext(cmd)
+
+			//options
+			versionFlag, err := cmd.Flags().GetBool("version")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --version option", errs.WithCause(err)))
+			}
 			if versionFlag {
-				return debugPrint(ui, errs.Wrap(ui.OutputErrln(getVersion())))
+				return debugPrint(ui, cxt, errs.Wrap(ui.OutputErrln(getVersion())))
+			}
+			filePath, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --file option", errs.WithCause(err)))
+			}
+			cbFlag, err := cmd.Flags().GetBool("clipboard")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --clipboard option", errs.WithCause(err)))
 			}
-			cxt := parseContext(cmd)
 
-			//open PGP file
-			if cbFlag && len(filePath) > 0 {
-				return debugPrint(ui, errs.Wrap(ecode.ErrClipboard))
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
 			}
-			var r io.Reader
-			switch {
-			case cbFlag:
-				cb, err := clipboard.NewReader()
-				if err != nil {
-					return debugPrint(ui, errs.Wrap(err))
-				}
-				r = cb
-				cxt.Set(context.ARMOR, true) //ASCII armor text only
-			case len(filePath) > 0:
-				file, err := os.Open(filePath)
-				if err != nil {
-					return debugPrint(ui, errs.Wrap(err))
-				}
-				defer file.Close()
-				r = file
-			default:
-				r = ui.Reader()
+			defer cancel()
+
+			//open PGP file
+			in, err := openInput(ui, cxt, filePath, cbFlag)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
 			}
+			defer in.Close()
 
 			//options OpenPGP packets
-			p, err := parse.New(cxt, r)
+			p, err := parse.NewWithContext(ctx, cxt, in)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			res, err := p.Parse()
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			r, err = marshalPacketInfo(res)
+			r, err := marshalPacketInfo(cmd, cxt, res, ui.Writer())
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			return debugPrint(ui, errs.Wrap(ui.WriteFrom(r)))
+			return debugPrint(ui, cxt, errs.Wrap(ui.WriteFrom(r)))
 		},
 	}
-	rootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "output version of "+Name)
-	rootCmd.Flags().StringVarP(&filePath, "file", "f", "", "path of OpenPGP file")
+	rootCmd.Flags().BoolP("version", "v", false, "output version of "+Name)
+	rootCmd.Flags().StringP("file", "f", "", "path of OpenPGP file")
 	_ = rootCmd.MarkFlagFilename("file")
-	rootCmd.Flags().BoolVarP(&cbFlag, "clipboard", "", false, "input from clipboard (ASCII armor text only)")
-	rootCmd.PersistentFlags().BoolVarP(&jsonFlag, "json", "j", false, "output with JSON format")
-	rootCmd.PersistentFlags().IntVarP(&indentSize, "indent", "", 0, "indent size for output text")
+	rootCmd.Flags().BoolP("clipboard", "", false, "input from clipboard (ASCII armor text only)")
+	rootCmd.PersistentFlags().StringP("output-format", "o", "text", "output format ("+strings.Join(formatNames(), "/")+")")
+	rootCmd.PersistentFlags().BoolP("json", "j", false, "output with JSON format (alias of --output-format json)")
+	rootCmd.PersistentFlags().BoolP("html", "", false, "output with self-contained HTML report (alias of --output-format html)")
+	rootCmd.PersistentFlags().BoolP("with-colons", "", false, "output keys with GnuPG colon-delimited records (alias of --output-format colons)")
+	rootCmd.PersistentFlags().BoolP("with-sig-list", "", false, "adds sig and rev records in colons output")
+	rootCmd.PersistentFlags().StringP("dot-config", "", "", "path of config file for DOT format (TOML)")
+	rootCmd.PersistentFlags().StringP("template", "", "", "output with Go text/template")
+	rootCmd.PersistentFlags().StringP("template-file", "", "", "path of template file for output")
+	rootCmd.PersistentFlags().IntP("indent", "", 0, "indent size for output text")
+	rootCmd.PersistentFlags().BoolP("subpacket-rows", "", false, "adds rows of sub-packets in CSV/TSV output")
+	rootCmd.PersistentFlags().StringP("color", "", "auto", "colorize output text (auto/always/never)")
+	rootCmd.PersistentFlags().StringP("theme", "", "default", "color theme of output text ("+strings.Join(render.ThemeNames(), "/")+")")
+	rootCmd.PersistentFlags().BoolP("tree", "", false, "draw tree-branch glyphs instead of indent in output text")
+	rootCmd.PersistentFlags().IntSliceP("tags", "", nil, "shows only packets with given tags (e.g. 2,13)")
+	rootCmd.PersistentFlags().IntSliceP("subpackets", "", nil, "shows only sub-packets with given types in signature packets (e.g. 33,16)")
+	rootCmd.PersistentFlags().IntP("max-depth", "", -1, "maximum depth of items (packets are depth 0, negative is unlimited)")
+	rootCmd.PersistentFlags().BoolP("exclude-unhashed", "", false, "removes unhashed sub-packets in signature packets")
+	rootCmd.PersistentFlags().StringArrayP("path", "", nil, "shows only items selected by path (e.g. \"/3/Hashed Subpacket/*\")")
+	rootCmd.PersistentFlags().StringP("query", "", "", "shows only items selected by query expression (see query sub-command)")
+	rootCmd.PersistentFlags().StringSliceP("redact", "", nil, "replaces values with stable pseudonyms ("+strings.Join(render.RedactCategories(), "/")+"; all if no value)")
+	rootCmd.PersistentFlags().Lookup("redact").NoOptDefVal = render.RedactAll
+	rootCmd.PersistentFlags().BoolP("explain", "", false, "adds descriptions and references to specifications (text/html/json/toml/yaml/xml)")
+	rootCmd.PersistentFlags().StringP("lang", "", "", "language of text and HTML output ("+strings.Join(locale.Languages(), "/")+"; default is $LANG)")
+	rootCmd.PersistentFlags().VarP(&sessionKeyValue{}, "session-key", "", "decrypts encrypted data packets with session key (e.g. output of gpg --show-session-key)")
+	rootCmd.PersistentFlags().VarP(&passphraseFileValue{}, "passphrase-file", "", "decrypts symmetric-key encrypted session key packets and protected secret keys with passphrase in the first line of file")
+	_ = rootCmd.MarkPersistentFlagFilename("passphrase-file")
+	rootCmd.PersistentFlags().VarP(&secretKeyFileValue{}, "secret-key-file", "", "decrypts public-key encrypted session key packets with secret keys in file (repeatable)")
+	_ = rootCmd.MarkPersistentFlagFilename("secret-key-file")
+	rootCmd.PersistentFlags().DurationP("timeout", "", 0, "timeout for fetching and parsing (e.g. 30s, 0 is no timeout)")
 	rootCmd.PersistentFlags().BoolP(context.ARMOR.String(), "a", false, "accepts ASCII armor text only")
 	rootCmd.PersistentFlags().BoolP(context.CERT.String(), "c", false, "dumps attested certification in signature packets (tag 2)")
 	rootCmd.PersistentFlags().BoolP(context.DEBUG.String(), "", false, "for debug") //not use
-	//rootCmd.PersistentFlags().BoolP(context.GDUMP.String(), "g", false, "selects alternate (GnuPG type) dump format") //not use
+	rootCmd.PersistentFlags().BoolP(context.GDUMP.String(), "g", false, "selects alternate (GnuPG type) dump format (alias of --output-format gdump)")
 	rootCmd.PersistentFlags().BoolP(context.INTEGER.String(), "i", false, "dumps multi-precision integers")
 	rootCmd.PersistentFlags().BoolP(context.LITERAL.String(), "l", false, "dumps literal packets (tag 11)")
 	rootCmd.PersistentFlags().BoolP(context.MARKER.String(), "m", false, "dumps marker packets (tag 10)")
 	rootCmd.PersistentFlags().BoolP(context.PRIVATE.String(), "p", false, "dumps private packets (tag 60-63)")
 	rootCmd.PersistentFlags().BoolP(context.UTC.String(), "u", false, "output with UTC time")
+	rootCmd.PersistentFlags().BoolP(context.SECRETS.String(), "", false, "DANGER: reveals unprotected secret key material in all outputs (masked by default)")
 
 	rootCmd.SilenceUsage = true
 	rootCmd.SetArgs(args)
@@ -112,20 +135,292 @@ func newRootCmd(ui *rwi.RWI, args []string) *cobra.Command {
 		newHkpCmd(ui),
 		newGitHubCmd(ui),
 		newFetchCmd(ui),
+		newQueryCmd(ui),
+		newDiffCmd(ui),
+		newBrowseCmd(ui),
 		newCompletionCmd(ui, rootCmd),
 	)
 
 	return rootCmd
 }
 
-func marshalPacketInfo(i *result.Info) (io.Reader, error) {
-	if jsonFlag {
-		return i.JSON(indentSize)
+//openInput returns OpenPGP data from clipboard, file or standard input
+func openInput(ui *rwi.RWI, cxt *context.Context, filePath string, cbFlag bool) (io.ReadCloser, error) {
+	switch {
+	case cbFlag && len(filePath) > 0:
+		return nil, errs.Wrap(ecode.ErrClipboard)
+	case cbFlag:
+		cb, err := clipboard.NewReader()
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		cxt.Set(context.ARMOR, true) //ASCII armor text only
+		return io.NopCloser(cb), nil
+	case len(filePath) > 0:
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		return file, nil
+	}
+	return io.NopCloser(ui.Reader()), nil
+}
+
+//marshalPacketInfo returns output of result.Info formatted by options (out is destination of output for detecting terminal)
+func marshalPacketInfo(cmd *cobra.Command, cxt *context.Context, i *result.Info, out io.Writer) (io.Reader, error) {
+	if err := checkFormatOptions(cmd); err != nil {
+		return nil, err
+	}
+	format, err := cmd.Flags().GetString("output-format")
+	if err != nil {
+		return nil, errs.New("error in --output-format option", errs.WithCause(err))
+	}
+	jsonFlag, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return nil, errs.New("error in --json option", errs.WithCause(err))
+	}
+	indentSize, err := cmd.Flags().GetInt("indent")
+	if err != nil {
+		return nil, errs.New("error in --indent option", errs.WithCause(err))
+	}
+	gdumpFlag, err := cmd.Flags().GetBool(context.GDUMP.String())
+	if err != nil {
+		return nil, errs.New("error in --gdump option", errs.WithCause(err))
+	}
+	htmlFlag, err := cmd.Flags().GetBool("html")
+	if err != nil {
+		return nil, errs.New("error in --html option", errs.WithCause(err))
+	}
+	i, err = queryPacketInfo(cmd, i)
+	if err != nil {
+		return nil, err
+	}
+	i, err = filterPacketInfo(cmd, i)
+	if err != nil {
+		return nil, err
+	}
+	plain := i
+	redacted, err := redactPacketInfo(cmd, i)
+	if err != nil {
+		return nil, err
+	}
+	if redacted != nil {
+		i = redacted
+	}
+	explainFlag, err := cmd.Flags().GetBool("explain")
+	if err != nil {
+		return nil, errs.New("error in --explain option", errs.WithCause(err))
+	}
+	if explainFlag {
+		i = render.Explain(i)
+	}
+	tmpl, err := getTemplate(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl != nil {
+		return render.Template(i, tmpl)
+	}
+	colonsFlag, err := cmd.Flags().GetBool("with-colons")
+	if err != nil {
+		return nil, errs.New("error in --with-colons option", errs.WithCause(err))
+	}
+	switch {
+	case jsonFlag:
+		format = "json"
+	case gdumpFlag:
+		format = "gdump"
+	case htmlFlag:
+		format = "html"
+	case colonsFlag:
+		format = "colons"
+	}
+	f, ok := getFormatter(format)
+	if !ok {
+		return nil, errs.New("error in --output-format option", errs.WithCause(ecode.ErrOutputFormat), errs.WithContext("format", format))
+	}
+	if redacted != nil {
+		switch {
+		case itemFormats[format], format == "html":
+		case tableFormats[format]:
+			i = plain //columns are redacted in rendering, since lengths and key IDs are taken from raw octets
+		default:
+			return nil, errs.New("--redact option is not available in this output format", errs.WithCause(ecode.ErrInvalidOption), errs.WithContext("format", format))
+		}
+	}
+	if packetFormats[format] {
+		for _, name := range itemFilters {
+			if cmd.Flags().Changed(name) {
+				return nil, errs.New("--"+name+" option is not available in this output format", errs.WithCause(ecode.ErrInvalidOption), errs.WithContext("format", format))
+			}
+		}
+	}
+	catalog, err := getCatalog(cmd)
+	if err != nil {
+		return nil, err
 	}
-	if indentSize > 0 {
-		return i.ToString(strings.Repeat(" ", indentSize)), nil
+	if !localizedFormats[format] {
+		catalog = nil
+	}
+	if format == "text" {
+		i = catalog.Localize(i) //HTML is translated in rendering for explanations by original names
+	}
+	opts := &outputOptions{indent: indentSize, explain: explainFlag, catalog: catalog}
+	if redacted != nil {
+		if opts.redact, err = cmd.Flags().GetStringSlice("redact"); err != nil {
+			return nil, errs.New("error in --redact option", errs.WithCause(err))
+		}
+	}
+	dotConfig, err := cmd.Flags().GetString("dot-config")
+	if err != nil {
+		return nil, errs.New("error in --dot-config option", errs.WithCause(err))
+	}
+	if len(dotConfig) > 0 {
+		cfg, err := loadDOTConfig(dotConfig)
+		if err != nil {
+			return nil, errs.New("error in --dot-config option", errs.WithCause(err), errs.WithContext("file", dotConfig))
+		}
+		opts.dotConfig = cfg
+	}
+	color, err := cmd.Flags().GetString("color")
+	if err != nil {
+		return nil, errs.New("error in --color option", errs.WithCause(err))
+	}
+	colorFlag, err := useColor(color, out)
+	if err != nil {
+		return nil, errs.New("error in --color option", errs.WithCause(err), errs.WithContext("color", color))
+	}
+	if colorFlag {
+		themeName, err := cmd.Flags().GetString("theme")
+		if err != nil {
+			return nil, errs.New("error in --theme option", errs.WithCause(err))
+		}
+		theme, ok := render.GetTheme(themeName)
+		if !ok {
+			return nil, errs.New("error in --theme option", errs.WithCause(ecode.ErrInvalidOption), errs.WithContext("theme", themeName))
+		}
+		opts.theme = theme
+	}
+	if opts.tree, err = cmd.Flags().GetBool("tree"); err != nil {
+		return nil, errs.New("error in --tree option", errs.WithCause(err))
+	}
+	if opts.subpacketRows, err = cmd.Flags().GetBool("subpacket-rows"); err != nil {
+		return nil, errs.New("error in --subpacket-rows option", errs.WithCause(err))
+	}
+	if opts.sigList, err = cmd.Flags().GetBool("with-sig-list"); err != nil {
+		return nil, errs.New("error in --with-sig-list option", errs.WithCause(err))
+	}
+	return f(cxt, i, opts)
+}
+
+//filterPacketInfo returns result.Info filtered by options
+func filterPacketInfo(cmd *cobra.Command, i *result.Info) (*result.Info, error) {
+	opts := []result.FilterOpt{}
+	tags, err := cmd.Flags().GetIntSlice("tags")
+	if err != nil {
+		return nil, errs.New("error in --tags option", errs.WithCause(err))
+	}
+	if len(tags) > 0 {
+		opts = append(opts, result.FilterTags(tags...))
+	}
+	subpackets, err := cmd.Flags().GetIntSlice("subpackets")
+	if err != nil {
+		return nil, errs.New("error in --subpackets option", errs.WithCause(err))
+	}
+	if len(subpackets) > 0 {
+		opts = append(opts, result.FilterSubpackets(subpackets...))
+	}
+	depth, err := cmd.Flags().GetInt("max-depth")
+	if err != nil {
+		return nil, errs.New("error in --max-depth option", errs.WithCause(err))
+	}
+	if depth >= 0 {
+		opts = append(opts, result.MaxDepth(depth))
+	}
+	unhashed, err := cmd.Flags().GetBool("exclude-unhashed")
+	if err != nil {
+		return nil, errs.New("error in --exclude-unhashed option", errs.WithCause(err))
+	}
+	if unhashed {
+		opts = append(opts, result.ExcludeUnhashed(true))
+	}
+	paths, err := cmd.Flags().GetStringArray("path")
+	if err != nil {
+		return nil, errs.New("error in --path option", errs.WithCause(err))
+	}
+	for _, path := range paths {
+		opts = append(opts, result.FilterPath(path))
+	}
+	if len(opts) == 0 {
+		return i, nil
+	}
+	return i.Filter(opts...), nil
+}
+
+//itemFormats is table of output formats which output items only (not raw octets)
+var itemFormats = map[string]bool{
+	"text": true,
+	"json": true,
+	"toml": true,
+	"yaml": true,
+	"xml":  true,
+}
+
+//tableFormats is table of output formats with one row per packet
+var tableFormats = map[string]bool{
+	"csv": true,
+	"tsv": true,
+}
+
+//packetFormats is table of output formats which decode whole packets (items in packets must not be filtered)
+var packetFormats = map[string]bool{
+	"gdump":   true,
+	"pgpdump": true,
+	"sq":      true,
+	"colons":  true,
+	"dot":     true,
+}
+
+//itemFilters is list of options which select items in packets (not available in packetFormats)
+var itemFilters = []string{"subpackets", "max-depth", "exclude-unhashed", "path", "query"}
+
+//localizedFormats is table of output formats translated by --lang option (structured formats are always in English for programs)
+var localizedFormats = map[string]bool{
+	"text": true,
+	"html": true,
+}
+
+//getCatalog returns message catalog by --lang option or environment variables (nil is English)
+func getCatalog(cmd *cobra.Command) (*locale.Catalog, error) {
+	lang, err := cmd.Flags().GetString("lang")
+	if err != nil {
+		return nil, errs.New("error in --lang option", errs.WithCause(err))
+	}
+	if len(lang) > 0 {
+		c, ok := locale.Get(lang)
+		if !ok {
+			return nil, errs.New("error in --lang option", errs.WithCause(ecode.ErrInvalidOption), errs.WithContext("lang", lang))
+		}
+		return c, nil
+	}
+	c, _ := locale.Get(locale.FromEnv())
+	return c, nil
+}
+
+//redactPacketInfo returns result.Info redacted by --redact option (nil if not set)
+func redactPacketInfo(cmd *cobra.Command, i *result.Info) (*result.Info, error) {
+	categories, err := cmd.Flags().GetStringSlice("redact")
+	if err != nil {
+		return nil, errs.New("error in --redact option", errs.WithCause(err))
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	res, err := render.Redact(i, categories...)
+	if err != nil {
+		return nil, errs.New("error in --redact option", errs.WithCause(err))
 	}
-	return i.ToString("\t"), nil
+	return res, nil
 }
 
 func getBool(cmd *cobra.Command, code context.OptCode) (context.OptCode, bool) {
@@ -138,19 +433,21 @@ func getBool(cmd *cobra.Command, code context.OptCode) (context.OptCode, bool) {
 }
 
 func parseContext(cmd *cobra.Command) *context.Context {
-	cxt := context.New(
+	return context.New(
 		context.Set(getBool(cmd, context.ARMOR)),
 		context.Set(getBool(cmd, context.CERT)),
 		context.Set(getBool(cmd, context.DEBUG)), //for debug
-		//context.Set(getBool(cmd, context.GDUMP)), //not use
+		context.Set(getBool(cmd, context.GDUMP)),
 		context.Set(getBool(cmd, context.INTEGER)),
 		context.Set(getBool(cmd, context.LITERAL)),
 		context.Set(getBool(cmd, context.MARKER)),
 		context.Set(getBool(cmd, context.PRIVATE)),
 		context.Set(getBool(cmd, context.UTC)),
+		context.Set(getBool(cmd, context.SECRETS)),
+		context.WithSessionKey(getSessionKey(cmd)),
+		context.WithPassphrase(getPassphrase(cmd)),
+		context.WithSecretKeys(getSecretKeys(cmd)),
 	)
-	debugFlag = cxt.Debug()
-	return cxt
 }
 
 //Execute is called from main function
@@ -3,6 +3,9 @@ package facade
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/spiegel-im-spiegel/gocli/exitcode"
@@ -49,6 +52,14 @@ Symmetrically Encrypted Data Packet (tag 9) (56 bytes)
 `
 )
 
+func TestMain(m *testing.M) {
+	//output text in English regardless of locale of test environment
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		os.Unsetenv(env)
+	}
+	os.Exit(m.Run())
+}
+
 func TestLoadByStdin(t *testing.T) {
 	inData := bytes.NewReader(bindata1)
 	outBuf := new(bytes.Buffer)
@@ -70,6 +81,169 @@ func TestLoadByStdin(t *testing.T) {
 	}
 }
 
+func TestLoadWithTimeout(t *testing.T) {
+	inData := bytes.NewReader(bindata1)
+	outBuf := new(bytes.Buffer)
+	outErrBuf := new(bytes.Buffer)
+	ui := rwi.New(rwi.WithReader(inData), rwi.WithWriter(outBuf), rwi.WithErrorWriter(outErrBuf))
+	args := []string{"--timeout", "1m"}
+
+	exit := Execute(ui, args)
+	if exit != exitcode.Normal {
+		t.Errorf("Execute(timeout) = \"%v\", want \"%v\".", exit, exitcode.Normal)
+	}
+	str := outBuf.String()
+	if str != resdataFromBindata1 {
+		t.Errorf("Execute(timeout) = \"%v\", want \"%v\".", str, resdataFromBindata1)
+	}
+
+	ui = rwi.New(rwi.WithReader(bytes.NewReader(bindata1)), rwi.WithWriter(new(bytes.Buffer)), rwi.WithErrorWriter(new(bytes.Buffer)))
+	args = []string{"--timeout", "-1s"}
+	exit = Execute(ui, args)
+	if exit != exitcode.Abnormal {
+		t.Errorf("Execute(timeout) = \"%v\", want \"%v\".", exit, exitcode.Abnormal)
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	testCases := []struct {
+		args []string
+		exit exitcode.ExitCode
+		want string
+	}{
+		{args: []string{"-o", "yaml"}, exit: exitcode.Normal, want: "Packet:\n  - name: \"Marker Packet (Obsolete Literal Packet) (tag 10)\"\n"},
+		{args: []string{"-o", "toml"}, exit: exitcode.Normal, want: "[[Packet]]\nname = \"Marker Packet (Obsolete Literal Packet) (tag 10)\"\n"},
+		{args: []string{"-o", "xml"}, exit: exitcode.Normal, want: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Info><Packet><name>Marker Packet (Obsolete Literal Packet) (tag 10)</name>"},
+		{args: []string{"-o", "json"}, exit: exitcode.Normal, want: `{"Packet":[{"name":"Marker Packet (Obsolete Literal Packet) (tag 10)"`},
+		{args: []string{"-o", "yaml", "-j"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-o", "text"}, exit: exitcode.Normal, want: resdataFromBindata1},
+		{args: []string{"-o", "gdump"}, exit: exitcode.Normal, want: "# off=0 ctb=a8 tag=10 hlen=2 plen=3\n:marker packet: PGP\n"},
+		{args: []string{"-g"}, exit: exitcode.Normal, want: "# off=0 ctb=a8 tag=10 hlen=2 plen=3\n:marker packet: PGP\n"},
+		{args: []string{"-o", "pgpdump"}, exit: exitcode.Normal, want: "Old: Marker Packet(tag 10)(3 bytes)\n\tString - PGP\n"},
+		{args: []string{"-o", "sq"}, exit: exitcode.Normal, want: "Marker Packet, old CTB, 3 bytes\n\nSymmetric-Key Encrypted Session Key Packet, new CTB, 4 bytes\n"},
+		{args: []string{"-o", "html"}, exit: exitcode.Normal, want: "<!DOCTYPE html>\n"},
+		{args: []string{"--html"}, exit: exitcode.Normal, want: "<!DOCTYPE html>\n"},
+		{args: []string{"-o", "dot"}, exit: exitcode.Normal, want: "digraph gpgpdump {\n\tp0 [label=\"Marker Packet (Obsolete Literal Packet) (tag 10)\", shape=box, style=rounded];\n"},
+		{args: []string{"-o", "dot", "--dot-config", "../dot-config.toml"}, exit: exitcode.Normal, want: "digraph gpgpdump {\n\tnode [fontname=\"Inconsolata\"];\n\tedge [color=\"red\"];\n"},
+		{args: []string{"-o", "dot", "--dot-config", "noexist.toml"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-o", "hexdump"}, exit: exitcode.Normal, want: "0000   a8 03                                            Marker Packet (Obsolete Literal Packet) (tag 10) (3 bytes)\n0002   50 47 50                                           Literal data (3 bytes)\n"},
+		{args: []string{"--tree"}, exit: exitcode.Normal, want: "Marker Packet (Obsolete Literal Packet) (tag 10) (3 bytes)\n└── Literal data (3 bytes)\n"},
+		{args: []string{"--color", "always", "--theme", "mono"}, exit: exitcode.Normal, want: "\x1b[1mMarker Packet (Obsolete Literal Packet) (tag 10)\x1b[0m \x1b[2m(3 bytes)\x1b[0m\n"},
+		{args: []string{"--color", "never", "--theme", "foo"}, exit: exitcode.Normal, want: resdataFromBindata1},
+		{args: []string{"--color", "always", "--theme", "foo"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--color", "foo"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--template", `{{range tag 3 .}}{{value "Symmetric Algorithm" .}}{{end}}`}, exit: exitcode.Normal, want: "CAST5 (128 bit key, as per) (sym 3)"},
+		{args: []string{"--template", `{{len .Packets}}`}, exit: exitcode.Normal, want: "3"},
+		{args: []string{"-j", "--template", `{{len .Packets}}`}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--template", "{{range}}"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--template-file", "testdata/packets.tmpl"}, exit: exitcode.Normal, want: "Marker Packet (Obsolete Literal Packet) (tag 10)\nSymmetric-Key Encrypted Session Key Packet (tag 3)\nSymmetrically Encrypted Data Packet (tag 9)\n"},
+		{args: []string{"--template-file", "noexist.tmpl"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--template", "{{.}}", "--template-file", "noexist.tmpl"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-o", "text", "--html"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-g", "--with-colons"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-o", "json", "--template", "{{.}}"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-o", "json", "-j=false"}, exit: exitcode.Normal, want: `{"Packet":[`},
+		{args: []string{"-o", "csv"}, exit: exitcode.Normal, want: "path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created\n0,0,10,Marker Packet (Obsolete Literal Packet),3,,,,,\n1,5,3,Symmetric-Key Encrypted Session Key Packet,4,4,\"CAST5 (128 bit key, as per) (sym 3)\",,,\n"},
+		{args: []string{"-o", "tsv", "--subpacket-rows"}, exit: exitcode.Normal, want: "path\toffset\ttag\tname\tlength\tversion\talgorithm\tkeyid\tfingerprint\tcreated\n0\t0\t10\t"},
+		{args: []string{"-o", "colons"}, exit: exitcode.Normal, want: ""},
+		{args: []string{"--with-colons", "-f", "../testdata/eccpub.asc"}, exit: exitcode.Normal, want: "pub:e:256:19:31FBFDA95FBBFA18:1422066111:1422670911:::::sc:::::nistp256:::0:\nfpr:::::::::8B2019D433A7729E5BFE577931FBFDA95FBBFA18:\nuid:e:"},
+		{args: []string{"--with-colons", "--with-sig-list", "-f", "../testdata/eccpub.asc"}, exit: exitcode.Normal, want: "pub:e:256:19:31FBFDA95FBBFA18:1422066111:1422670911:::::sc:::::nistp256:::0:\nfpr:::::::::8B2019D433A7729E5BFE577931FBFDA95FBBFA18:\nuid:e::::1422066111::::John Doe (forECC) <john@examle.com>::::::::::0:\nsig:::19:"},
+		{args: []string{"--tags", "3", "--max-depth", "1"}, exit: exitcode.Normal, want: "Symmetric-Key Encrypted Session Key Packet (tag 3) (4 bytes)\n\tVersion: 4 (current)\n\tSymmetric Algorithm: CAST5 (128 bit key, as per) (sym 3)\n\tString-to-Key (S2K) Algorithm: Simple S2K (s2k 0)\n"},
+		{args: []string{"--path", "/1/Version", "--path", "/0"}, exit: exitcode.Normal, want: "Marker Packet (Obsolete Literal Packet) (tag 10) (3 bytes)\n\tLiteral data (3 bytes)\nSymmetric-Key Encrypted Session Key Packet (tag 3) (4 bytes)\n\tVersion: 4 (current)\n"},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--subpackets", "16", "--exclude-unhashed", "-o", "csv", "--subpacket-rows"}, exit: exitcode.Normal, want: "path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created\n0,0,2,Signature Packet,94,4,"},
+		{args: []string{"--tags", "x"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--tags", "2", "-o", "gdump"}, exit: exitcode.Normal, want: "# off=0 ctb=88 tag=2 hlen=2 plen=94\n:signature packet: algo 19, keyid 31FBFDA95FBBFA18\n"},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--tags", "2", "--subpackets", "2", "-o", "gdump"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--subpackets", "2", "-o", "pgpdump"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--max-depth", "1", "-o", "sq"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--path", "/0", "--with-colons"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/eccsig.asc", "--query", "//Version", "-o", "dot"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--query", "//Version"}, exit: exitcode.Normal, want: "Version: 4 (current)\n"},
+		{args: []string{"query", "//*[@tag = 3]/String-to-Key (S2K) Algorithm/*", "-o", "json"}, exit: exitcode.Normal, want: `{"Packet":[{"name":"Hash Algorithm","value":"MD5 (hash 1)"}]}`},
+		{args: []string{"query", "-f", "../testdata/eccsig.asc", "//Signature Packet[Hash Algorithm ~ \"SHA2\"]//Issuer"}, exit: exitcode.Normal, want: "Issuer (sub 16): 0x31fbfda95fbbfa18\n"},
+		{args: []string{"query"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--query", "//Version["}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"diff", "-", "../testdata/diff/alice-1.asc"}, exit: exitcode.Normal, want: "- Marker Packet (Obsolete Literal Packet) (tag 10)\n- Symmetric-Key Encrypted Session Key Packet (tag 3)\n- Symmetrically Encrypted Data Packet (tag 9)\n+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c (EdDSA (pub 22))\n"},
+		{args: []string{"diff", "-j", "../testdata/diff/alice-1.asc", "../testdata/diff/alice-1.asc"}, exit: exitcode.Normal, want: "{}"},
+		{args: []string{"diff", "-o", "csv", "../testdata/diff/alice-1.asc", "../testdata/diff/alice-2.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"diff", "../testdata/diff/alice-1.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"diff", "../testdata/diff/alice-1.asc", "noexist.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"browse", "-f", "noexist.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"browse", "--clipboard", "-f", "../testdata/eccpub.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--redact", "-f", "../testdata/eccsig.asc"}, exit: exitcode.Normal, want: strings.Replace(resdataFromAscdata1, "0x31fbfda95fbbfa18", "0x0101010101010101", 1)},
+		{args: []string{"--redact=uid,email", "-f", "../testdata/eccpub.asc", "--tags", "13", "-j"}, exit: exitcode.Normal, want: `{"Packet":[{"name":"User ID Packet (tag 13)","note":"35 bytes","Item":[{"name":"User ID","value":"uid-1 \u003cemail-1@example.invalid\u003e"}]}]}`},
+		{args: []string{"--redact=foo"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--redact", "-o", "hexdump"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"--explain", "--tags", "3", "--max-depth", "1"}, exit: exitcode.Normal, want: "Symmetric-Key Encrypted Session Key Packet (tag 3) (4 bytes) [1]\n\tVersion: 4 (current) [2]\n"},
+		{args: []string{"--explain", "--tags", "10", "-j"}, exit: exitcode.Normal, want: `{"Packet":[{"name":"Marker Packet (Obsolete Literal Packet) (tag 10)","note":"3 bytes","description":"Marker with the fixed content \"PGP\"; ignored on receipt.","reference":"RFC 9580 Section 5.8"`},
+		{args: []string{"--lang", "ja", "--max-depth", "1"}, exit: exitcode.Normal, want: "マーカーパケット (旧リテラルパケット) (tag 10) (3 バイト)\n\tリテラルデータ (3 バイト)\n共通鍵暗号化セッション鍵パケット (tag 3) (4 バイト)\n\tバージョン: 4 (現行)\n"},
+		{args: []string{"--lang", "ja_JP.UTF-8", "-j", "--tags", "10"}, exit: exitcode.Normal, want: `{"Packet":[{"name":"Marker Packet (Obsolete Literal Packet) (tag 10)","note":"3 bytes"`},
+		{args: []string{"--lang", "ja", "--html"}, exit: exitcode.Normal, want: "<!DOCTYPE html>\n<html lang=\"ja\">\n"},
+		{args: []string{"--lang", "ja", "-o", "gdump"}, exit: exitcode.Normal, want: "# off=0 ctb=a8 tag=10 hlen=2 plen=3\n:marker packet: PGP\n"},
+		{args: []string{"--lang", "en"}, exit: exitcode.Normal, want: resdataFromBindata1},
+		{args: []string{"--lang", "xx"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/v5/v5-private-key.txt", "-i", "--query", "//*[@tag = 5]/Secret-Key/*"}, exit: exitcode.Normal, want: "EdDSA secret key (256 bits)\n\t(masked secret-key material)\n2-octet checksum\n\t0e 5c\n"},
+		{args: []string{"-f", "../testdata/v5/v5-private-key.txt", "-i", "--show-secrets", "--query", "//*[@tag = 5]/Secret-Key/*"}, exit: exitcode.Normal, want: "EdDSA secret key (256 bits)\n\t87 67 54 a7 49 49 96 ab 11 2c a0 8e 9f 69 c2 15 65 0b ba 9a 98 77 70 11 73 cd 3b dc 9b 99 40 36\n"},
+		{args: []string{"-f", "../testdata/decrypt/sed.asc", "--session-key", "3:79DEBF2265EB299851D31485F7E87DB5", "--query", "//*[@tag = 9]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: quick check: ok (31 bytes)\nLiteral data format: b (binary)\nFile name: hello.txt\n"},
+		{args: []string{"-f", "../testdata/decrypt/seipd1.asc", "--session-key", "9:5715297AA4E3DD36254C63C84E8101CBD8C250EA75EF696709829D8730786C02", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: MDC: ok (33 bytes)\nCompression Algorithm: ZIP <RFC1951> (comp 1)\nCompressed data (31 bytes)\nLiteral Data Packet (tag 11) (29 bytes)\n\tLiteral data format: b (binary)\n\tFile name: hello.txt\n"},
+		{args: []string{"-f", "../testdata/decrypt/seipd1.asc", "--session-key", "9:0015297AA4E3DD36254C63C84E8101CBD8C250EA75EF696709829D8730786C02", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: invalid session key\n"},
+		{args: []string{"-f", "../testdata/decrypt/seipd1.asc", "--session-key", "9:XYZ"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/decrypt/pk-skesk.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 3]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: AES with 256-bit key (sym 9)\n\t(masked session key)\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-skesk.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--show-secrets", "--query", "//*[@tag = 3]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: AES with 256-bit key (sym 9)\n\tb1 de de 31 be ce 2b 24 2b 3e 89 65 28 dc 22 da fb 30 a8 73 22 fe 6d ea c6 72 d8 59 c6 cf 7f 0b\n"},
+		{args: []string{"-f", "../testdata/decrypt/seipd1.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: MDC: ok (33 bytes)\n"},
+		{args: []string{"-f", "../testdata/decrypt/seipd1.asc", "--passphrase-file", "../testdata/decrypt/noexist.txt"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/decrypt/pk-skesk.asc", "--secret-key-file", "../testdata/decrypt/alice-sec.asc", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: MDC: ok (31 bytes)\nLiteral data format: b (binary)\nFile name: hello.txt\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-cv25519.asc", "--secret-key-file", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: AES with 256-bit key (sym 9)\n\t(masked session key)\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-nistp256.asc", "--secret-key-file", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--show-secrets", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: AES with 256-bit key (sym 9)\n\t14 8b cd cd 36 da 13 3b 8b 10 36 d7 ed 2b 65 ee 68 68 a9 47 e8 d4 c0 97 cc 66 fe bc d7 57 fe a7\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-elg.asc", "--secret-key-file", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: MDC: ok"},
+		{args: []string{"-f", "../testdata/decrypt/pk-x448.asc", "--secret-key-file", "../testdata/decrypt/x448-sec.asc", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key (sym alg is specified in sym. encrypted integrity protected data)\n\t(masked session key)\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-x448.asc", "--secret-key-file", "../testdata/decrypt/x448-sec.asc", "--query", "//*[@tag = 18]/*/*"}, exit: exitcode.Normal, want: "Decrypted data: authentication tags: ok (29 bytes)\nLiteral data format: b (binary)\nFile name: hello.txt\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-x448.asc", "--secret-key-file", "../testdata/decrypt/bob-sec.asc", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: no matching secret key\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-elg.asc", "--secret-key-file", "../testdata/decrypt/bob-sec.asc", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: passphrase is required for protected secret key\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-elg.asc", "--secret-key-file", "../testdata/decrypt/alice-sec.asc", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: no matching secret key\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-skesk.asc", "--secret-key-file", "../testdata/decrypt/alice-card.asc", "--query", "//*[@tag = 1]/*[@name = \"Decrypted session key\"]"}, exit: exitcode.Normal, want: "Decrypted session key: decryption is impossible: secret key is on smartcard (GNU divert-to-card stub)\n"},
+		{args: []string{"-f", "../testdata/decrypt/pk-skesk.asc", "--secret-key-file", "../testdata/decrypt/noexist.asc"}, exit: exitcode.Abnormal, want: ""},
+		{args: []string{"-f", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "-i", "--query", "//*[@tag = 5]/Secret-Key/*[@name = \"Decrypted secret-key material\"]"}, exit: exitcode.Normal, want: "Decrypted secret-key material: SHA-1: ok (34 bytes)\n\tEdDSA secret key (256 bits)\n\t\t(masked secret-key material)\n\tKey pair check: ok\n"},
+		{args: []string{"-f", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 7]/Secret-Key/*[@name = \"Decrypted secret-key material\"]/*"}, exit: exitcode.Normal, want: "ECDH secret key (255 bits)\nKey pair check: ok\nECDH secret key (256 bits)\nKey pair check: ok\nElGamal secret exponent x (340 bits)\nKey pair check: ok\n"},
+		{args: []string{"-f", "../testdata/decrypt/bob-sec.asc", "--passphrase-file", "../testdata/decrypt/bob-sec.asc", "--query", "//*[@tag = 5]/Secret-Key/*[@name = \"Decrypted secret-key material\"]"}, exit: exitcode.Normal, want: "Decrypted secret-key material: invalid passphrase\n"},
+		{args: []string{"-f", "../testdata/decrypt/alice-card.asc", "--passphrase-file", "../testdata/decrypt/passphrase.txt", "--query", "//*[@tag = 7]/Secret-Key/*[@name = \"Decrypted secret-key material\"]"}, exit: exitcode.Normal, want: "Decrypted secret-key material: decryption is impossible: secret key is on smartcard (GNU divert-to-card stub)\n"},
+		{args: []string{"-o", "foo"}, exit: exitcode.Abnormal, want: ""},
+	}
+	for _, tc := range testCases {
+		outBuf := new(bytes.Buffer)
+		ui := rwi.New(rwi.WithReader(bytes.NewReader(bindata1)), rwi.WithWriter(outBuf), rwi.WithErrorWriter(new(bytes.Buffer)))
+		exit := Execute(ui, tc.args)
+		if exit != tc.exit {
+			t.Errorf("Execute(%v) = \"%v\", want \"%v\".", tc.args, exit, tc.exit)
+		}
+		if str := outBuf.String(); !strings.HasPrefix(str, tc.want) {
+			t.Errorf("Execute(%v) = \"%v\", want prefix \"%v\".", tc.args, str, tc.want)
+		}
+	}
+}
+
+func TestRedactDebug(t *testing.T) {
+	for _, args := range [][]string{
+		{"-f", "../testdata/diff/alice-1.asc", "--redact", "--debug"},
+		{"-f", "../testdata/diff/alice-1.asc", "--redact", "--debug", "-j"},
+		{"-f", "../testdata/diff/alice-1.asc", "--redact", "--html"},
+		{"-f", "../testdata/diff/alice-1.asc", "--redact", "-o", "csv", "--subpacket-rows"},
+		{"-f", "../testdata/diff/alice-1.asc", "--redact", "-o", "tsv", "--subpacket-rows"},
+	} {
+		outBuf := new(bytes.Buffer)
+		ui := rwi.New(rwi.WithWriter(outBuf), rwi.WithErrorWriter(new(bytes.Buffer)))
+		if exit := Execute(ui, args); exit != exitcode.Normal {
+			t.Errorf("Execute(%v) = \"%v\", want \"%v\".", args, exit, exitcode.Normal)
+		}
+		str := outBuf.String()
+		for _, s := range []string{"Alice", "alice", "41 6c 69 63 65", "f9 05 49 bb", "72 d8 16 a3", "72d816a3"} {
+			if strings.Contains(str, s) {
+				t.Errorf("Execute(%v) = \"%v\", want not to contain \"%v\".", args, str, s)
+			}
+		}
+	}
+}
+
 func TestLoadByNosata(t *testing.T) {
 	inData := bytes.NewReader([]byte{})
 	outBuf := new(bytes.Buffer)
@@ -125,6 +299,35 @@ func TestLoadByNofile(t *testing.T) {
 	}
 }
 
+func TestExecuteConcurrent(t *testing.T) {
+	testCases := []struct {
+		args []string
+		res  string
+	}{
+		{args: []string{}, res: resdataFromBindata1},
+		{args: []string{"-j", "--indent", "2"}, res: resJSON},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, tc := range testCases {
+			wg.Add(1)
+			go func(args []string, res string) {
+				defer wg.Done()
+				outBuf := new(bytes.Buffer)
+				outErrBuf := new(bytes.Buffer)
+				ui := rwi.New(rwi.WithReader(bytes.NewReader(bindata1)), rwi.WithWriter(outBuf), rwi.WithErrorWriter(outErrBuf))
+				if exit := Execute(ui, args); exit != exitcode.Normal {
+					t.Errorf("Execute(%v) = \"%v\", want \"%v\".", args, exit, exitcode.Normal)
+				}
+				if str := outBuf.String(); str != res {
+					t.Errorf("Execute(%v) = \"%v\", want \"%v\".", args, str, res)
+				}
+			}(tc.args, tc.res)
+		}
+	}
+	wg.Wait()
+}
+
 /* Copyright 2017-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
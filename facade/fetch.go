@@ -1,14 +1,12 @@
 package facade
 
 import (
-	"context"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/fetch"
 	"github.com/spiegel-im-spiegel/gocli/rwi"
-	"github.com/spiegel-im-spiegel/gocli/signal"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse"
 )
 
@@ -23,46 +21,52 @@ func newFetchCmd(ui *rwi.RWI) *cobra.Command {
 			cxt := parseContext(cmd)
 			//user id
 			if len(args) != 1 {
-				return debugPrint(ui, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
+				return debugPrint(ui, cxt, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
 			}
 			u, err := fetch.URL(args[0])
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 
 			//options
 			rawFlag, err := cmd.Flags().GetBool("raw")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --raw option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --raw option", errs.WithCause(err)))
 			}
 
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
 			//Fetch OpenPGP packets
 			resp, err := fetch.New().Get(
 				u,
-				fetch.WithContext(signal.Context(context.Background(), os.Interrupt)),
+				fetch.WithContext(ctx),
 			)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			defer resp.Close()
 			if rawFlag {
-				return debugPrint(ui, ui.WriteFrom(resp.Body()))
+				return debugPrint(ui, cxt, ui.WriteFrom(resp.Body()))
 			}
 
 			//parse OpenPGP packets
-			p, err := parse.New(cxt, resp.Body())
+			p, err := parse.NewWithContext(ctx, cxt, resp.Body())
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			res, err := p.Parse()
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			r, err := marshalPacketInfo(res)
+			r, err := marshalPacketInfo(cmd, cxt, res, ui.Writer())
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			return debugPrint(ui, ui.WriteFrom(r))
+			return debugPrint(ui, cxt, ui.WriteFrom(r))
 		},
 	}
 	fetchCmd.Flags().BoolP("raw", "", false, "output raw data")
@@ -0,0 +1,213 @@
+package facade
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/locale"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/render"
+)
+
+//outputOptions class is options for output formatters
+type outputOptions struct {
+	indent    int
+	dotConfig *render.DOTConfig
+	theme     *render.Theme //nil is monochrome
+	tree      bool
+
+	subpacketRows bool
+	sigList       bool
+	explain       bool            //footnotes in text output
+	catalog       *locale.Catalog //nil is English
+	redact        []string        //categories of redaction (nil is not redacted)
+}
+
+//formatter is function type for marshaling result.Info
+type formatter func(cxt *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error)
+
+//formatters is table of output formatters (key is name of format)
+var formatters = map[string]formatter{
+	"text": marshalText,
+	"json": indented((*result.Info).JSON),
+	"toml": indented((*result.Info).TOML),
+	"yaml": indented((*result.Info).YAML),
+	"xml":  indented((*result.Info).XML),
+	"gdump": func(_ *context.Context, i *result.Info, _ *outputOptions) (io.Reader, error) {
+		return render.GDump(i)
+	},
+	"pgpdump": func(cxt *context.Context, i *result.Info, _ *outputOptions) (io.Reader, error) {
+		return render.PGPDump(cxt, i)
+	},
+	"html": func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return render.HTML(i, render.WithLocalizer(opts.catalog), render.WithHexView(opts.redact == nil))
+	},
+	"dot": func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return render.DOT(i, opts.dotConfig)
+	},
+	"hexdump": func(_ *context.Context, i *result.Info, _ *outputOptions) (io.Reader, error) {
+		return render.HexDump(i)
+	},
+	"colons": func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return render.Colons(i, render.WithSigList(opts.sigList))
+	},
+	"csv": func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return render.Table(i, render.WithSubpackets(opts.subpacketRows), render.WithRedaction(opts.redact...))
+	},
+	"tsv": func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return render.Table(i, render.WithComma('\t'), render.WithSubpackets(opts.subpacketRows), render.WithRedaction(opts.redact...))
+	},
+	"sq": func(cxt *context.Context, i *result.Info, _ *outputOptions) (io.Reader, error) {
+		return render.SQDump(cxt, i)
+	},
+}
+
+//indented returns formatter with indent size only
+func indented(f func(i *result.Info, indent int) (io.Reader, error)) formatter {
+	return func(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+		return f(i, opts.indent)
+	}
+}
+
+//getFormatter returns formatter by name of format
+func getFormatter(name string) (formatter, bool) {
+	f, ok := formatters[strings.ToLower(name)]
+	return f, ok
+}
+
+//formatNames returns sorted names of output formats
+func formatNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//loadDOTConfig returns DOTConfig instance from config file
+func loadDOTConfig(path string) (*render.DOTConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer file.Close()
+	return render.NewDOTConfig(file)
+}
+
+//formatOptions is list of options selecting output format (exclusive each other)
+var formatOptions = []string{"output-format", "json", context.GDUMP.String(), "html", "with-colons", "template", "template-file"}
+
+//checkFormatOptions returns error if output format is selected by two or more options
+func checkFormatOptions(cmd *cobra.Command) error {
+	set := []string{}
+	for _, name := range formatOptions {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+		if f.Value.Type() == "bool" && f.Value.String() != "true" {
+			continue
+		}
+		set = append(set, "--"+name)
+	}
+	if len(set) > 1 {
+		return errs.New("cannot set "+strings.Join(set, " and ")+" options at once", errs.WithCause(ecode.ErrInvalidOption))
+	}
+	return nil
+}
+
+//getTemplate returns template by --template or --template-file option (nil if not set)
+func getTemplate(cmd *cobra.Command) (*template.Template, error) {
+	text, err := cmd.Flags().GetString("template")
+	if err != nil {
+		return nil, errs.New("error in --template option", errs.WithCause(err))
+	}
+	path, err := cmd.Flags().GetString("template-file")
+	if err != nil {
+		return nil, errs.New("error in --template-file option", errs.WithCause(err))
+	}
+	switch {
+	case len(text) > 0 && len(path) > 0:
+		return nil, errs.New("cannot set --template and --template-file options at once", errs.WithCause(ecode.ErrInvalidOption))
+	case len(path) > 0:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errs.New("error in --template-file option", errs.WithCause(err), errs.WithContext("file", path))
+		}
+		tmpl, err := render.NewTemplate(string(b))
+		if err != nil {
+			return nil, errs.New("error in --template-file option", errs.WithCause(err), errs.WithContext("file", path))
+		}
+		return tmpl, nil
+	case len(text) > 0:
+		tmpl, err := render.NewTemplate(text)
+		if err != nil {
+			return nil, errs.New("error in --template option", errs.WithCause(err))
+		}
+		return tmpl, nil
+	}
+	return nil, nil
+}
+
+//useColor returns true if output text is colorized (color is value of --color option)
+func useColor(color string, out io.Writer) (bool, error) {
+	switch strings.ToLower(color) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		if len(os.Getenv("NO_COLOR")) > 0 {
+			return false, nil
+		}
+		return isTerminal(out), nil
+	}
+	return false, errs.Wrap(ecode.ErrInvalidOption)
+}
+
+//isTerminal returns true if out is terminal
+func isTerminal(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func marshalText(_ *context.Context, i *result.Info, opts *outputOptions) (io.Reader, error) {
+	indent := "\t"
+	if opts.indent > 0 {
+		indent = strings.Repeat(" ", opts.indent)
+	}
+	if opts.theme != nil || opts.tree || opts.explain {
+		return render.Text(i, render.WithTheme(opts.theme), render.WithTree(opts.tree), render.WithIndent(indent))
+	}
+	return i.ToString(indent), nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -2,14 +2,12 @@ package facade
 
 import (
 	"bytes"
-	"context"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/fetch"
 	"github.com/spiegel-im-spiegel/gocli/rwi"
-	"github.com/spiegel-im-spiegel/gocli/signal"
 	"github.com/spiegel-im-spiegel/gpgpdump/github"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse"
 	contxt "github.com/spiegel-im-spiegel/gpgpdump/parse/context"
@@ -27,48 +25,54 @@ func newGitHubCmd(ui *rwi.RWI) *cobra.Command {
 			cxt.Set(contxt.ARMOR, true)
 			//user id
 			if len(args) != 1 {
-				return debugPrint(ui, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
+				return debugPrint(ui, cxt, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
 			}
 			userID := args[0]
 
 			//options
 			keyid, err := cmd.Flags().GetString("keyid")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --keyid option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --keyid option", errs.WithCause(err)))
 			}
 			rawFlag, err := cmd.Flags().GetBool("raw")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --raw option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --raw option", errs.WithCause(err)))
 			}
 
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
 			//Fetch OpenPGP packets
 			resp, err := github.GetKey(
-				signal.Context(context.Background(), os.Interrupt),
+				ctx,
 				fetch.New(),
 				userID,
 				keyid,
 			)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			if rawFlag {
-				return debugPrint(ui, ui.WriteFrom(bytes.NewReader(resp)))
+				return debugPrint(ui, cxt, ui.WriteFrom(bytes.NewReader(resp)))
 			}
 
 			//parse OpenPGP packets
-			p, err := parse.NewBytes(cxt, resp)
+			p, err := parse.NewBytesWithContext(ctx, cxt, resp)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			res, err := p.Parse()
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			r, err := marshalPacketInfo(res)
+			r, err := marshalPacketInfo(cmd, cxt, res, ui.Writer())
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			return debugPrint(ui, ui.WriteFrom(r))
+			return debugPrint(ui, cxt, ui.WriteFrom(r))
 		},
 	}
 	githubCmd.Flags().StringP("keyid", "", "", "OpenPGP key ID")
@@ -1,14 +1,12 @@
 package facade
 
 import (
-	"context"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/fetch"
 	"github.com/spiegel-im-spiegel/gocli/rwi"
-	"github.com/spiegel-im-spiegel/gocli/signal"
 	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
 	"github.com/spiegel-im-spiegel/gpgpdump/hkp"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse"
@@ -27,31 +25,37 @@ func newHkpCmd(ui *rwi.RWI) *cobra.Command {
 			cxt.Set(contxt.ARMOR, true)
 			//user id
 			if len(args) != 1 {
-				return debugPrint(ui, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
+				return debugPrint(ui, cxt, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
 			}
 			userID := args[0]
 
 			//options
 			sks, err := cmd.Flags().GetString("keyserver")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --keyserver option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --keyserver option", errs.WithCause(err)))
 			}
 			if len(sks) == 0 {
-				return debugPrint(ui, errs.New("error in --keyserver option", errs.WithCause(ecode.ErrEmptyKeyServer)))
+				return debugPrint(ui, cxt, errs.New("error in --keyserver option", errs.WithCause(ecode.ErrEmptyKeyServer)))
 			}
 			port, err := cmd.Flags().GetInt("port")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --port option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --port option", errs.WithCause(err)))
 			}
 			rawFlag, err := cmd.Flags().GetBool("raw")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --raw option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --raw option", errs.WithCause(err)))
 			}
 			secFlag, err := cmd.Flags().GetBool("secure")
 			if err != nil {
-				return debugPrint(ui, errs.New("error in --secure option", errs.WithCause(err)))
+				return debugPrint(ui, cxt, errs.New("error in --secure option", errs.WithCause(err)))
 			}
 
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
 			//Fetch OpenPGP packets
 			prt := hkp.HKP
 			if secFlag {
@@ -62,32 +66,32 @@ func newHkpCmd(ui *rwi.RWI) *cobra.Command {
 				hkp.WithProtocol(prt),
 				hkp.WithPort(port),
 			).Fetch(
-				signal.Context(context.Background(), os.Interrupt),
+				ctx,
 				fetch.New(),
 				userID,
 			)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			defer resp.Close()
 			if rawFlag {
-				return debugPrint(ui, ui.WriteFrom(resp))
+				return debugPrint(ui, cxt, ui.WriteFrom(resp))
 			}
 
 			//parse OpenPGP packets
-			p, err := parse.New(cxt, resp)
+			p, err := parse.NewWithContext(ctx, cxt, resp)
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
 			res, err := p.Parse()
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			r, err := marshalPacketInfo(res)
+			r, err := marshalPacketInfo(cmd, cxt, res, ui.Writer())
 			if err != nil {
-				return debugPrint(ui, err)
+				return debugPrint(ui, cxt, err)
 			}
-			return debugPrint(ui, ui.WriteFrom(r))
+			return debugPrint(ui, cxt, ui.WriteFrom(r))
 		},
 	}
 	hkpCmd.Flags().StringP("keyserver", "", "keys.gnupg.net", "OpenPGP key server")
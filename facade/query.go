@@ -0,0 +1,104 @@
+package facade
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gocli/rwi"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//newQueryCmd returns cobra.Command instance for query sub-command
+func newQueryCmd(ui *rwi.RWI) *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:     "query [flags] EXPR",
+		Aliases: []string{"q"},
+		Short:   "Dumps items selected by query expression",
+		Long:    "Dumps items selected by query expression (e.g. '//Signature Packet[Hash Algorithm ~ \"SHA-1\"]').",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cxt := parseContext(cmd)
+			if len(args) != 1 {
+				return debugPrint(ui, cxt, errs.Wrap(os.ErrInvalid, errs.WithContext("args", args)))
+			}
+			if err := cmd.Flags().Set("query", args[0]); err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --query option", errs.WithCause(err)))
+			}
+
+			//options
+			filePath, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return debugPrint(ui, cxt, errs.New("error in --file option", errs.WithCause(err)))
+			}
+
+			ctx, cancel, err := signalContext(cmd)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			defer cancel()
+
+			//open PGP file
+			var r io.Reader = ui.Reader()
+			if len(filePath) > 0 {
+				file, err := os.Open(filePath)
+				if err != nil {
+					return debugPrint(ui, cxt, errs.Wrap(err))
+				}
+				defer file.Close()
+				r = file
+			}
+
+			//parse OpenPGP packets
+			p, err := parse.NewWithContext(ctx, cxt, r)
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			res, err := p.Parse()
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			r, err = marshalPacketInfo(cmd, cxt, res, ui.Writer())
+			if err != nil {
+				return debugPrint(ui, cxt, err)
+			}
+			return debugPrint(ui, cxt, errs.Wrap(ui.WriteFrom(r)))
+		},
+	}
+	queryCmd.Flags().StringP("file", "f", "", "path of OpenPGP file")
+	_ = queryCmd.MarkFlagFilename("file")
+
+	return queryCmd
+}
+
+//queryPacketInfo returns items selected by --query option
+func queryPacketInfo(cmd *cobra.Command, i *result.Info) (*result.Info, error) {
+	expr, err := cmd.Flags().GetString("query")
+	if err != nil {
+		return nil, errs.New("error in --query option", errs.WithCause(err))
+	}
+	if len(expr) == 0 {
+		return i, nil
+	}
+	res, err := i.Query(expr)
+	if err != nil {
+		return nil, errs.New("error in --query option", errs.WithCause(err))
+	}
+	return res, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,159 @@
+package facade
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/keyring"
+)
+
+//sessionKeyValue class is value of --session-key option (implements pflag.Value)
+type sessionKeyValue struct {
+	sk *context.SessionKey
+}
+
+//String returns "ALGO:HEX" string (Stringer interface)
+func (v *sessionKeyValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.sk.String()
+}
+
+//Set parses "ALGO:HEX" string (pflag.Value interface)
+func (v *sessionKeyValue) Set(s string) error {
+	sk, err := context.ParseSessionKey(s)
+	if err != nil {
+		return err
+	}
+	v.sk = sk
+	return nil
+}
+
+//Type returns type name of value (pflag.Value interface)
+func (v *sessionKeyValue) Type() string {
+	return "ALGO:HEX"
+}
+
+//getSessionKey returns session key from --session-key option (nil if not set)
+func getSessionKey(cmd *cobra.Command) *context.SessionKey {
+	f := cmd.Flags().Lookup("session-key")
+	if f == nil {
+		return nil
+	}
+	if v, ok := f.Value.(*sessionKeyValue); ok {
+		return v.sk
+	}
+	return nil
+}
+
+//passphraseFileValue class is value of --passphrase-file option (implements pflag.Value)
+type passphraseFileValue struct {
+	path       string
+	passphrase []byte
+}
+
+//String returns path of passphrase file (Stringer interface)
+func (v *passphraseFileValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.path
+}
+
+//Set reads passphrase from the first line of file (pflag.Value interface)
+func (v *passphraseFileValue) Set(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return errs.Wrap(err, errs.WithContext("path", path))
+	}
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		b = b[:i]
+	}
+	v.path = path
+	v.passphrase = bytes.TrimSuffix(b, []byte("\r"))
+	return nil
+}
+
+//Type returns type name of value (pflag.Value interface)
+func (v *passphraseFileValue) Type() string {
+	return "PATH"
+}
+
+//getPassphrase returns passphrase from --passphrase-file option (nil if not set)
+func getPassphrase(cmd *cobra.Command) []byte {
+	f := cmd.Flags().Lookup("passphrase-file")
+	if f == nil {
+		return nil
+	}
+	if v, ok := f.Value.(*passphraseFileValue); ok {
+		return v.passphrase
+	}
+	return nil
+}
+
+//secretKeyFileValue class is value of --secret-key-file option (implements pflag.Value)
+type secretKeyFileValue struct {
+	paths []string
+	keys  []*keyring.SecretKey
+}
+
+//String returns paths of secret key files (Stringer interface)
+func (v *secretKeyFileValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return strings.Join(v.paths, ",")
+}
+
+//Set reads secret keys from file (pflag.Value interface)
+func (v *secretKeyFileValue) Set(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errs.Wrap(err, errs.WithContext("path", path))
+	}
+	defer file.Close()
+	keys, err := keyring.ReadSecretKeys(file)
+	if err != nil {
+		return errs.Wrap(err, errs.WithContext("path", path))
+	}
+	v.paths = append(v.paths, path)
+	v.keys = append(v.keys, keys...)
+	return nil
+}
+
+//Type returns type name of value (pflag.Value interface)
+func (v *secretKeyFileValue) Type() string {
+	return "PATH"
+}
+
+//getSecretKeys returns secret keys from --secret-key-file option (nil if not set)
+func getSecretKeys(cmd *cobra.Command) []*keyring.SecretKey {
+	f := cmd.Flags().Lookup("secret-key-file")
+	if f == nil {
+		return nil
+	}
+	if v, ok := f.Value.(*secretKeyFileValue); ok {
+		return v.keys
+	}
+	return nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
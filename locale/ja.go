@@ -0,0 +1,346 @@
+package locale
+
+import "regexp"
+
+//japanese is message catalog of Japanese
+var japanese = &Catalog{
+	lang: "ja",
+	messages: map[string]string{
+		//packets
+		"Public-Key Encrypted Session Key Packet":        "公開鍵暗号化セッション鍵パケット",
+		"Signature Packet":                               "署名パケット",
+		"Symmetric-Key Encrypted Session Key Packet":     "共通鍵暗号化セッション鍵パケット",
+		"One-Pass Signature Packet":                      "ワンパス署名パケット",
+		"Secret-Key Packet":                              "秘密鍵パケット",
+		"Public-Key Packet":                              "公開鍵パケット",
+		"Secret-Subkey Packet":                           "秘密副鍵パケット",
+		"Compressed Data Packet":                         "圧縮データパケット",
+		"Symmetrically Encrypted Data Packet":            "共通鍵暗号化データパケット",
+		"Marker Packet (Obsolete Literal Packet)":        "マーカーパケット (旧リテラルパケット)",
+		"Literal Data Packet":                            "リテラルデータパケット",
+		"Trust Packet":                                   "信用パケット",
+		"User ID Packet":                                 "ユーザIDパケット",
+		"Public-Subkey Packet":                           "公開副鍵パケット",
+		"User Attribute Packet":                          "ユーザ属性パケット",
+		"Sym. Encrypted Integrity Protected Data Packet": "完全性保護付き共通鍵暗号化データパケット",
+		"Modification Detection Code Packet":             "改竄検知コードパケット",
+		"AEAD Encrypted Data Packet":                     "AEAD 暗号化データパケット",
+		"Private or Experimental Values":                 "プライベートまたは実験用の値",
+
+		//sub-packets
+		"Signature Creation Time":                "署名作成日時",
+		"Signature Expiration Time":              "署名有効期限",
+		"Exportable Certification":               "エクスポート可能な証明",
+		"Trust Signature":                        "信用署名",
+		"Regular Expression":                     "正規表現",
+		"Revocable":                              "失効可能",
+		"Key Expiration Time":                    "鍵有効期限",
+		"Placeholder for backward compatibility": "後方互換性のためのプレースホルダ",
+		"Preferred Symmetric Algorithms":         "優先共通鍵暗号アルゴリズム",
+		"Revocation Key":                         "失効鍵",
+		"Issuer":                                 "発行者",
+		"Notation Data":                          "注釈データ",
+		"Preferred Hash Algorithms":              "優先ハッシュアルゴリズム",
+		"Preferred Compression Algorithms":       "優先圧縮アルゴリズム",
+		"Key Server Preferences":                 "鍵サーバ設定",
+		"Preferred Key Server":                   "優先鍵サーバ",
+		"Primary User ID":                        "主ユーザID",
+		"Policy URI":                             "ポリシー URI",
+		"Key Flags":                              "鍵フラグ",
+		"Signer's User ID":                       "署名者のユーザID",
+		"Reason for Revocation":                  "失効理由",
+		"Features":                               "機能",
+		"Signature Target":                       "署名対象",
+		"Embedded Signature":                     "埋め込み署名",
+		"Issuer Fingerprint":                     "発行者の指紋",
+		"Preferred AEAD Algorithms":              "優先 AEAD アルゴリズム",
+		"Intended Recipient Fingerprint":         "想定受信者の指紋",
+		"Attested Certifications":                "認証済み証明",
+		"Key Block":                              "鍵ブロック",
+		"Image Attribute":                        "画像属性",
+		"Hashed Subpacket":                       "ハッシュ対象サブパケット",
+		"Unhashed Subpacket":                     "ハッシュ対象外サブパケット",
+		"Subpacket":                              "サブパケット",
+
+		//fields
+		"Version":                               "バージョン",
+		"current":                               "現行",
+		"old":                                   "旧",
+		"draft":                                 "ドラフト",
+		"Public key creation time":              "公開鍵作成日時",
+		"Public-key Algorithm":                  "公開鍵アルゴリズム",
+		"Symmetric Algorithm":                   "共通鍵暗号アルゴリズム",
+		"Hash Algorithm":                        "ハッシュアルゴリズム",
+		"Compression Algorithm":                 "圧縮アルゴリズム",
+		"AEAD Algorithm":                        "AEAD アルゴリズム",
+		"Signiture Type":                        "署名種別",
+		"Hashed material":                       "ハッシュ対象データ",
+		"Signature creation time":               "署名作成日時",
+		"Creation time":                         "作成日時",
+		"Hash left 2 bytes":                     "ハッシュ値の左 2 バイト",
+		"2-octet checksum":                      "2 オクテットのチェックサム",
+		"Key ID":                                "鍵 ID",
+		"Fingerprint":                           "指紋",
+		"Key version":                           "鍵バージョン",
+		"anonymous recipient":                   "匿名の受信者",
+		"ECC Curve OID":                         "楕円曲線 OID",
+		"KDF parameters":                        "KDF パラメータ",
+		"String-to-Key (S2K) Algorithm":         "String-to-Key (S2K) アルゴリズム",
+		"Salt":                                  "ソルト",
+		"Count":                                 "カウント",
+		"Chunk size":                            "チャンクサイズ",
+		"Encrypted data":                        "暗号化データ",
+		"Decrypted data":                        "復号データ",
+		"Encrypted session key":                 "暗号化セッション鍵",
+		"Encrypted data and authentication tag": "暗号化データと認証タグ",
+		"Encrypted session key and authentication tag": "暗号化セッション鍵と認証タグ",
+		"Decrypted session key":                        "復号セッション鍵",
+		"Decrypted secret-key material":                "復号秘密鍵データ",
+		"Key pair check":                               "鍵ペア検査",
+		"Summary authentication tag for the AEAD mode": "AEAD モードの総合認証タグ",
+		"nonce for the AEAD":                           "AEAD のナンス",
+		"Compressed data":                              "圧縮データ",
+		"Literal data format":                          "リテラルデータ形式",
+		"Literal data":                                 "リテラルデータ",
+		"File name":                                    "ファイル名",
+		"Modification Detection Code":                  "改竄検知コード",
+		"Multi-precision integer":                      "多倍長整数",
+		"Multi-precision integers of DSA":              "DSA の多倍長整数",
+		"Multi-precision integers of ECDH":             "ECDH の多倍長整数",
+		"Multi-precision integers of ECDSA":            "ECDSA の多倍長整数",
+		"Multi-precision integers of EdDSA":            "EdDSA の多倍長整数",
+		"Unknown data":                                 "不明なデータ",
+		"Image data":                                   "画像データ",
+		"Encoding":                                     "符号化",
+		"Flag":                                         "フラグ",
+		"Class":                                        "クラス",
+		"Level":                                        "レベル",
+		"Trust amount":                                 "信用量",
+		"Valid days":                                   "有効日数",
+		"Type":                                         "種別",
+		"Key data":                                     "鍵データ",
+		"Name":                                         "名前",
+		"Value":                                        "値",
+		"Human-readable":                               "人間可読",
+		"Exportable":                                   "エクスポート可能",
+		"Not exportable":                               "エクスポート不可",
+		"Not revocable":                                "失効不可",
+		"Primary":                                      "主",
+		"Not primary":                                  "主ではない",
+		"Sensitive":                                    "機密",
+		"Normal":                                       "通常",
+		"Serial Number":                                "シリアル番号",
+		"Additional resultrmation":                     "追加情報",
+		"Unknown":                                      "不明",
+		"unknown":                                      "不明",
+		"Unknown reason":                               "不明な理由",
+		"Reserved":                                     "予約済み",
+		"binary":                                       "バイナリ",
+		"text":                                         "テキスト",
+		"UTF-8 text":                                   "UTF-8 テキスト",
+		"local":                                        "ローカル",
+		"quick check: ok":                              "クイックチェック: OK",
+		"MDC: ok":                                      "MDC: OK",
+		"authentication tags: ok":                      "認証タグ: OK",
+		"authentication tag: ok":                       "認証タグ: OK",
+		"SHA-1: ok":                                    "SHA-1: OK",
+		"checksum: ok":                                 "チェックサム: OK",
+		"ok":                                           "OK",
+		"invalid session key":                          "不正なセッション鍵",
+		"integrity check failed":                       "完全性検査に失敗",
+		"unsupported algorithm":                        "未対応のアルゴリズム",
+		"symmetric key (encoded)":                      "共通鍵 (符号化済み)",
+		"uncompressed format":                          "非圧縮形式",
+		"Native point format of the curve follows":                                               "曲線固有の点形式",
+		"Only X coordinate follows":                                                              "X 座標のみ",
+		"Only Y coordinate follows":                                                              "Y 座標のみ",
+		"sym alg is specified in pub-key encrypted session key":                                  "共通鍵暗号アルゴリズムは公開鍵暗号化セッション鍵で指定",
+		"sym alg is specified in sym-key encrypted session key":                                  "共通鍵暗号アルゴリズムは共通鍵暗号化セッション鍵で指定",
+		"sym alg is specified in sym. encrypted integrity protected data":                        "共通鍵暗号アルゴリズムは完全性保護付き共通鍵暗号化データで指定",
+		"sym alg is IDEA, simple string-to-key":                                                  "共通鍵暗号アルゴリズムは IDEA (simple string-to-key)",
+		"plain text + MDC SHA1(20 bytes); sym alg is specified in pub-key encrypted session key": "平文 + MDC SHA1(20 バイト); 共通鍵暗号アルゴリズムは公開鍵暗号化セッション鍵で指定",
+		"plain text + MDC SHA1(20 bytes); sym alg is specified in sym-key encrypted session key": "平文 + MDC SHA1(20 バイト); 共通鍵暗号アルゴリズムは共通鍵暗号化セッション鍵で指定",
+		"s2k usage 0; plain secret-key material":                                                 "s2k usage 0; 平文の秘密鍵データ",
+		"s2k usage 253; encrypted secret-key material and AEAD authentication tag":               "s2k usage 253; 暗号化された秘密鍵データと AEAD 認証タグ",
+		"s2k usage 254; encrypted secret-key material and 20-octet SHA-1 hash":                   "s2k usage 254; 暗号化された秘密鍵データと 20 オクテットの SHA-1 ハッシュ値",
+
+		//algorithms
+		"RSA (Encrypt or Sign)":                       "RSA (暗号化または署名)",
+		"RSA Encrypt-Only":                            "RSA 暗号化専用",
+		"RSA Sign-Only":                               "RSA 署名専用",
+		"Elgamal (Encrypt-Only)":                      "Elgamal (暗号化専用)",
+		"DSA (Digital Signature Algorithm)":           "DSA (デジタル署名アルゴリズム)",
+		"ECDH public key algorithm":                   "ECDH 公開鍵アルゴリズム",
+		"ECDSA public key algorithm":                  "ECDSA 公開鍵アルゴリズム",
+		"Reserved (formerly Elgamal Encrypt or Sign)": "予約済み (旧 Elgamal 暗号化または署名)",
+		"Reserved for Diffie-Hellman":                 "Diffie-Hellman 用に予約",
+		"Private/Experimental algorithm":              "プライベート/実験用アルゴリズム",
+		"Plaintext or unencrypted data":               "平文または暗号化されていないデータ",
+		"AES with 128-bit key":                        "AES (128ビット鍵)",
+		"AES with 192-bit key":                        "AES (192ビット鍵)",
+		"AES with 256-bit key":                        "AES (256ビット鍵)",
+		"Camellia with 128-bit key":                   "Camellia (128ビット鍵)",
+		"Camellia with 192-bit key":                   "Camellia (192ビット鍵)",
+		"Camellia with 256-bit key":                   "Camellia (256ビット鍵)",
+		"Twofish with 256-bit key":                    "Twofish (256ビット鍵)",
+		"TripleDES (168 bit key derived from 192)":    "TripleDES (192ビットから導出した168ビット鍵)",
+		"CAST5 (128 bit key, as per)":                 "CAST5 (128ビット鍵)",
+		"Blowfish (128 bit key, 16 rounds)":           "Blowfish (128ビット鍵, 16ラウンド)",
+		"Uncompressed":                                "非圧縮",
+		"Simple S2K":                                  "単純 S2K",
+		"Salted S2K":                                  "ソルト付き S2K",
+		"Iterated and Salted S2K":                     "反復ソルト付き S2K",
+		"EAX mode":                                    "EAX モード",
+		"OCB mode <RFC7253>":                          "OCB モード <RFC7253>",
+		"GCM mode":                                    "GCM モード",
+
+		//signature types
+		"Signature of a binary document":                            "バイナリ文書への署名",
+		"Signature of a canonical text document":                    "正規化テキスト文書への署名",
+		"Standalone signature":                                      "単独署名",
+		"Generic certification of a User ID and Public-Key packet":  "ユーザIDと公開鍵パケットへの一般的な証明",
+		"Persona certification of a User ID and Public-Key packet":  "ユーザIDと公開鍵パケットへの未確認の証明",
+		"Casual certification of a User ID and Public-Key packet":   "ユーザIDと公開鍵パケットへの簡易確認の証明",
+		"Positive certification of a User ID and Public-Key packet": "ユーザIDと公開鍵パケットへの厳格な確認の証明",
+		"Attested Key Signature":                                    "認証済み鍵署名",
+		"Subkey Binding Signature":                                  "副鍵束縛署名",
+		"Primary Key Binding Signature":                             "主鍵束縛署名",
+		"Signature directly on a key":                               "鍵への直接署名",
+		"Key revocation signature":                                  "鍵失効署名",
+		"Subkey revocation signature":                               "副鍵失効署名",
+		"Certification revocation signature":                        "証明失効署名",
+		"Timestamp signature":                                       "タイムスタンプ署名",
+		"Third-Party Confirmation signature":                        "第三者確認署名",
+
+		//flags
+		"This key may be used to certify other keys.":                                                                 "この鍵は他の鍵の証明に使用できる。",
+		"This key may be used to sign data.":                                                                          "この鍵はデータへの署名に使用できる。",
+		"This key may be used to encrypt communications.":                                                             "この鍵は通信の暗号化に使用できる。",
+		"This key may be used to encrypt storage.":                                                                    "この鍵はストレージの暗号化に使用できる。",
+		"The private component of this key may have been split by a secret-sharing mechanism.":                        "この鍵の秘密部分は秘密分散によって分割されているかもしれない。",
+		"This key may be used for authentication.":                                                                    "この鍵は認証に使用できる。",
+		"The private component of this key may be in the possession of more than one person.":                         "この鍵の秘密部分は複数人が所持しているかもしれない。",
+		"This key may be used for timestamping.":                                                                      "この鍵はタイムスタンプに使用できる。",
+		"This key may be used as an additional decryption subkey (ADSK).":                                             "この鍵は追加の復号副鍵 (ADSK) として使用できる。",
+		"Modification Detection (packets 18 and 19)":                                                                  "改竄検知 (パケット 18 および 19)",
+		"AEAD Encrypted Data Packet (packet 20) and version 5 Symmetric-Key Encrypted Session Key Packets (packet 3)": "AEAD 暗号化データパケット (パケット 20) およびバージョン 5 の共通鍵暗号化セッション鍵パケット (パケット 3)",
+		"Version 5 Public-Key Packet format and corresponding new fingerprint format":                                 "バージョン 5 の公開鍵パケット形式および対応する新しい指紋形式",
+		"No-modify": "変更不可",
+
+		//reasons for revocation
+		"No reason specified (key revocations or cert revocations)":   "理由の指定なし (鍵または証明の失効)",
+		"Key is superseded (key revocations)":                         "鍵の置き換え (鍵の失効)",
+		"Key material has been compromised (key revocations)":         "鍵の危殆化 (鍵の失効)",
+		"Key is retired and no longer used (key revocations)":         "鍵の廃止 (鍵の失効)",
+		"User ID resultrmation is no longer valid (cert revocations)": "ユーザID情報の無効化 (証明の失効)",
+
+		//key material
+		"RSA public modulus n":                          "RSA 公開モジュラス n",
+		"RSA public encryption exponent e":              "RSA 公開暗号化指数 e",
+		"RSA secret exponent d":                         "RSA 秘密指数 d",
+		"RSA secret prime value p":                      "RSA 秘密素数 p",
+		"RSA secret prime value q (p < q)":              "RSA 秘密素数 q (p < q)",
+		"RSA u, the multiplicative inverse of p, mod q": "RSA u (mod q における p の逆元)",
+		"RSA signature value m^d mod n":                 "RSA 署名値 m^d mod n",
+		"RSA encrypted key (d, p, q, u)":                "RSA 暗号化された鍵 (d, p, q, u)",
+		"RSA m^e mod n; m = sym alg(1 byte) + checksum(2 bytes) + PKCS#1 block encoding EME-PKCS1-v1_5": "RSA 値 m^e mod n; m = 共通鍵暗号アルゴリズム (1 バイト) + チェックサム (2 バイト) + PKCS#1 ブロック符号化 EME-PKCS1-v1_5",
+		"DSA secret exponent x":                 "DSA 秘密指数 x",
+		"DSA value r":                           "DSA 値 r",
+		"DSA value s":                           "DSA 値 s",
+		"DSA encrypted key":                     "DSA 暗号化された鍵",
+		"DSA p":                                 "DSA 素数 p",
+		"DSA q (q is a prime divisor of p-1)":   "DSA 素数 q (p-1 の素因数)",
+		"DSA g":                                 "DSA 群の生成元 g",
+		"DSA y (= g^x mod p where x is secret)": "DSA 公開値 y (= g^x mod p, x は秘密)",
+		"ElGamal prime p":                       "ElGamal 素数 p",
+		"ElGamal group generator g":             "ElGamal 群の生成元 g",
+		"ElGamal secret exponent x":             "ElGamal 秘密指数 x",
+		"Elgamal encrypted key":                 "Elgamal 暗号化された鍵",
+		"ElGamal public key value y (= g^x mod p where x is secret)": "ElGamal 公開鍵の値 y (= g^x mod p, x は秘密)",
+		"ElGamal a = g^k mod p":             "ElGamal 値 a = g^k mod p",
+		"ElGamal b = (h - a*x)/k mod p - 1": "ElGamal 値 b = (h - a*x)/k mod p - 1",
+		"ElGamal g^k mod p":                 "ElGamal 値 g^k mod p",
+		"ElGamal m * y^k mod p; m = sym alg(1 byte) + checksum(2 bytes) + PKCS#1 block encoding EME-PKCS1-v1_5": "ElGamal 値 m * y^k mod p; m = 共通鍵暗号アルゴリズム (1 バイト) + チェックサム (2 バイト) + PKCS#1 ブロック符号化 EME-PKCS1-v1_5",
+		"ECDSA value r":       "ECDSA 値 r",
+		"ECDSA value s":       "ECDSA 値 s",
+		"ECDSA secret key":    "ECDSA 秘密鍵",
+		"ECDSA encrypted key": "ECDSA 暗号化された鍵",
+		"EC point r":          "楕円曲線上の点 r",
+		"ECDH secret key":     "ECDH 秘密鍵",
+		"ECDH encrypted key":  "ECDH 暗号化された鍵",
+		"EdDSA secret key":    "EdDSA 秘密鍵",
+		"EdDSA encrypted key": "EdDSA 暗号化された鍵",
+		"EdDSA value s in the little endian representation": "EdDSA 値 s (リトルエンディアン表現)",
+		"X25519 public key":            "X25519 公開鍵",
+		"X25519 ephemeral public key":  "X25519 一時公開鍵",
+		"X25519 secret key":            "X25519 秘密鍵",
+		"X25519 encrypted key":         "X25519 暗号化された鍵",
+		"X448 public key":              "X448 公開鍵",
+		"X448 ephemeral public key":    "X448 一時公開鍵",
+		"X448 secret key":              "X448 秘密鍵",
+		"X448 encrypted key":           "X448 暗号化された鍵",
+		"wrapped session key":          "ラップされたセッション鍵",
+		"(masked secret-key material)": "(マスクされた秘密鍵データ)",
+		"(masked session key)":         "(マスクされたセッション鍵)",
+
+		//notes
+		"Private or experimental":       "プライベートまたは実験用",
+		"private or experimental use":   "プライベートまたは実験用",
+		"another one pass signature":    "後続のワンパス署名あり",
+		"other than one pass signature": "ワンパス署名以外",
+		"certification digests":         "証明ダイジェスト",
+		"Hash":                          "ハッシュ",
+		"Public-Key":                    "公開鍵",
+		"Secret-Key":                    "秘密鍵",
+		"Trust":                         "信用",
+		"User ID":                       "ユーザID",
+		"<critical>":                    "<クリティカル>",
+		"0 is forever":                  "0 は無期限",
+		"0 byte":                        "0 バイト",
+		"need 20 octets length":         "20 オクテット長が必要",
+		"need 32 octets length":         "32 オクテット長が必要",
+		"Mode not Specified":            "モード指定なし",
+		"Sym. Encryption Mode":          "共通鍵暗号化モード",
+		"Pubkey Encryption Mode":        "公開鍵暗号化モード",
+		"Unknown Mode":                  "不明なモード",
+
+		//decryption with secret keys
+		"no matching secret key":                          "一致する秘密鍵なし",
+		"invalid passphrase":                              "不正なパスフレーズ",
+		"passphrase is required for protected secret key": "保護された秘密鍵にはパスフレーズが必要",
+		"decryption is impossible: secret key is on smartcard (GNU divert-to-card stub)": "復号不可: 秘密鍵はスマートカード上にある (GNU divert-to-card スタブ)",
+		"decryption is impossible: secret key is not included (GNU dummy stub)":          "復号不可: 秘密鍵が含まれていない (GNU dummy スタブ)",
+		"secret key does not match public key":                                           "秘密鍵が公開鍵と一致しない",
+	},
+	patterns: []pattern{
+		{regexp.MustCompile(`^(\d+) bytes$`), "$1 バイト"},
+		{regexp.MustCompile(`^(\d+) bits$`), "$1 ビット"},
+		{regexp.MustCompile(`^(\d+)bits key size$`), "鍵長 $1 ビット"},
+		{regexp.MustCompile(`^([\d.]+) days after$`), "$1 日後"},
+		{regexp.MustCompile(`^(\S+) EC point$`), "$1 楕円曲線上の点"},
+		{regexp.MustCompile(`^Extension Number (\d+)$`), "拡張番号 $1"},
+		{regexp.MustCompile(`^Unknown flag(\d+)\((0x[0-9a-f]+)\)$`), "不明なフラグ$1($2)"},
+		{regexp.MustCompile(`^flag (0x[0-9a-f]+)$`), "フラグ $1"},
+		{regexp.MustCompile(`^Multi-precision integers of Unknown \(pub (\d+)\)$`), "不明な多倍長整数 (pub $1)"},
+		{regexp.MustCompile(`^Multi-precision integers of unknown encrypted key \(pub (\d+)\)$`), "不明な暗号化された鍵の多倍長整数 (pub $1)"},
+		{regexp.MustCompile(`^Multi-precision integers of unknown secret key \(pub (\d+)\)$`), "不明な秘密鍵の多倍長整数 (pub $1)"},
+		{regexp.MustCompile(`^illegal (.+)$`), "不正な $1"},
+		{regexp.MustCompile(`^invalid (.+)$`), "無効な $1"},
+		{regexp.MustCompile(`^s2k usage (\d+); encrypted secret-key material and 2-octet checksum$`), "s2k usage $1; 暗号化された秘密鍵データと 2 オクテットのチェックサム"},
+	},
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
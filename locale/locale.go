@@ -0,0 +1,203 @@
+package locale
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//critical is mark of critical sub-packet in name of item (e.g. "Issuer <critical>")
+const critical = "<critical>"
+
+//Catalog class is message catalog of language (messages in English are keys)
+type Catalog struct {
+	lang     string
+	messages map[string]string
+	patterns []pattern
+}
+
+//pattern class is message with variable parts (e.g. "82 bytes")
+type pattern struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+//catalogs is table of message catalogs (English is default and has no catalog)
+var catalogs = map[string]*Catalog{
+	"ja": japanese,
+}
+
+//Get returns message catalog by language name (e.g. "ja", "ja_JP.UTF-8"; nil is English)
+func Get(name string) (*Catalog, bool) {
+	lang := normalize(name)
+	switch lang {
+	case "", "c", "posix", "en":
+		return nil, true
+	}
+	c, ok := catalogs[lang]
+	return c, ok
+}
+
+//Languages returns sorted names of languages
+func Languages() []string {
+	langs := []string{"en"}
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+//FromEnv returns name of language by LC_ALL, LC_MESSAGES or LANG environment variable
+func FromEnv() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang := os.Getenv(env); len(lang) > 0 {
+			return lang
+		}
+	}
+	return ""
+}
+
+//normalize returns language code of locale name (e.g. "ja_JP.UTF-8" -> "ja")
+func normalize(name string) string {
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+//Lang returns language code of catalog
+func (c *Catalog) Lang() string {
+	if c == nil {
+		return "en"
+	}
+	return c.lang
+}
+
+//Translate returns message translated by catalog (trailing parenthesized parts are translated separately)
+func (c *Catalog) Translate(s string) string {
+	if c == nil || len(s) == 0 {
+		return s
+	}
+	if msg, ok := c.messages[s]; ok {
+		return msg
+	}
+	if head := strings.TrimSuffix(s, " "+critical); head != s {
+		return c.Translate(head) + " " + c.Translate(critical)
+	}
+	for _, p := range c.patterns {
+		if p.re.MatchString(s) {
+			return p.re.ReplaceAllString(s, p.repl)
+		}
+	}
+	if head, inner, ok := splitParen(s); ok {
+		return c.Translate(head) + " (" + c.Translate(inner) + ")"
+	}
+	return s
+}
+
+//splitParen splits string into head and last parenthesized part (e.g. "CAST5 (sym 3)" -> "CAST5", "sym 3")
+func splitParen(s string) (string, string, bool) {
+	if !strings.HasSuffix(s, ")") {
+		return "", "", false
+	}
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				if i < 2 || s[i-1] != ' ' {
+					return "", "", false
+				}
+				return s[:i-1], s[i+1 : len(s)-1], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+//freeTexts is table of items whose values are free text (not translated)
+var freeTexts = map[string]bool{
+	"User ID":                  true,
+	"Signer's User ID":         true,
+	"File name":                true,
+	"Name":                     true,
+	"Value":                    true,
+	"Policy URI":               true,
+	"Preferred Key Server":     true,
+	"Regular Expression":       true,
+	"Additional resultrmation": true,
+}
+
+//baseName returns name of item without parenthesized parts (e.g. "Signer's User ID (sub 28)" -> "Signer's User ID")
+func baseName(name string) string {
+	for {
+		head, _, ok := splitParen(name)
+		if !ok {
+			return name
+		}
+		name = head
+	}
+}
+
+//Localize returns copy of result.Info with translated names, values and notes (JSON keys are not changed)
+func (c *Catalog) Localize(info *result.Info) *result.Info {
+	if c == nil || info == nil {
+		return info
+	}
+	res := result.New()
+	for _, itm := range info.Packets {
+		if itm != nil {
+			res.Add(c.localizeItem(itm))
+		}
+	}
+	return res
+}
+
+//LocalizeItem returns copy of item with translated name, value and note (sub-items are not translated)
+func (c *Catalog) LocalizeItem(item *result.Item) *result.Item {
+	if c == nil || item == nil {
+		return item
+	}
+	itm := *item
+	itm.Name = c.Translate(item.Name)
+	if !freeTexts[baseName(item.Name)] {
+		itm.Value = c.Translate(item.Value)
+	}
+	itm.Note = c.Translate(item.Note)
+	return &itm
+}
+
+//localizeItem returns copy of item with translated strings
+func (c *Catalog) localizeItem(item *result.Item) *result.Item {
+	itm := c.LocalizeItem(item)
+	itm.Items = nil
+	for _, child := range item.Items {
+		if child != nil {
+			itm.Items = append(itm.Items, c.localizeItem(child))
+		}
+	}
+	return itm
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
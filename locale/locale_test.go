@@ -0,0 +1,125 @@
+package locale
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+)
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name string
+		lang string
+		ok   bool
+	}{
+		{name: "", lang: "en", ok: true},
+		{name: "C", lang: "en", ok: true},
+		{name: "en_US.UTF-8", lang: "en", ok: true},
+		{name: "ja", lang: "ja", ok: true},
+		{name: "ja_JP.UTF-8", lang: "ja", ok: true},
+		{name: "JA-jp", lang: "ja", ok: true},
+		{name: "xx", lang: "en", ok: false},
+	}
+	for _, tc := range testCases {
+		c, ok := Get(tc.name)
+		if ok != tc.ok {
+			t.Errorf("Get(%v) is \"%v\", want \"%v\".", tc.name, ok, tc.ok)
+		}
+		if c.Lang() != tc.lang {
+			t.Errorf("Get(%v).Lang() is \"%v\", want \"%v\".", tc.name, c.Lang(), tc.lang)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "ja_JP.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if lang := FromEnv(); lang != "ja_JP.UTF-8" {
+		t.Errorf("FromEnv() is \"%v\", want \"%v\".", lang, "ja_JP.UTF-8")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	c, _ := Get("ja")
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{s: "", want: ""},
+		{s: "Signature Packet", want: "署名パケット"},
+		{s: "82 bytes", want: "82 バイト"},
+		{s: "Key Flags (sub 27)", want: "鍵フラグ (sub 27)"},
+		{s: "nistp256 (256bits key size)", want: "nistp256 (鍵長 256 ビット)"},
+		{s: "AES with 128-bit key (sym 7)", want: "AES (128ビット鍵) (sym 7)"},
+		{s: "illegal pubkey", want: "不正な pubkey"},
+		{s: "SHA2-256 (hash 8)", want: "SHA2-256 (hash 8)"},
+		{s: "Issuer <critical> (sub 16)", want: "発行者 <クリティカル> (sub 16)"},
+		{s: "EC point r", want: "楕円曲線上の点 r"},
+		{s: "ElGamal public key value y (= g^x mod p where x is secret)", want: "ElGamal 公開鍵の値 y (= g^x mod p, x は秘密)"},
+		{s: "need 20 octets length", want: "20 オクテット長が必要"},
+		{s: "Multi-precision integers of unknown secret key (pub 100)", want: "不明な秘密鍵の多倍長整数 (pub 100)"},
+	}
+	for _, tc := range testCases {
+		if str := c.Translate(tc.s); str != tc.want {
+			t.Errorf("Translate(%v) is \"%v\", want \"%v\".", tc.s, str, tc.want)
+		}
+	}
+	var en *Catalog
+	if str := en.Translate("Signature Packet"); str != "Signature Packet" {
+		t.Errorf("Translate() is \"%v\", want \"%v\".", str, "Signature Packet")
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	file, err := os.Open("../testdata/eccpub.asc")
+	if err != nil {
+		t.Fatalf("os.Open() = \"%+v\", want nil error.", err)
+	}
+	defer file.Close()
+	p, err := parse.New(context.New(context.Set(context.ARMOR, true)), file)
+	if err != nil {
+		t.Fatalf("parse.New() = \"%+v\", want nil error.", err)
+	}
+	info, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() = \"%+v\", want nil error.", err)
+	}
+	org := info.String()
+	c, _ := Get("ja")
+	res := c.Localize(info)
+	if str := info.String(); str != org {
+		t.Errorf("Localize() changes original result to \"%v\".", str)
+	}
+	want := "公開鍵パケット (tag 6) (82 バイト)\n\tバージョン: 4 (現行)\n"
+	if str := res.String(); !strings.HasPrefix(str, want) {
+		t.Errorf("Localize() = \"%v\", want prefix \"%v\".", str, want)
+	}
+	if uid := res.Packets[1].Items[0]; uid.Name != "ユーザID" || uid.Value != "John Doe (forECC) <john@examle.com>" {
+		t.Errorf("Localize() = \"%v: %v\", want \"%v\".", uid.Name, uid.Value, "ユーザID: John Doe (forECC) <john@examle.com>")
+	}
+	if res.Packets[0].Code != info.Packets[0].Code {
+		t.Errorf("Localize().Code is \"%v\", want \"%v\".", res.Packets[0].Code, info.Packets[0].Code)
+	}
+	if r := (*Catalog)(nil).Localize(info); r != info {
+		t.Errorf("Localize() of English returns copy, want original.")
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
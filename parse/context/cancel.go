@@ -0,0 +1,47 @@
+package context
+
+import (
+	stdcontext "context"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//WithContext sets context.Context for cancellation of parsing, and returns Context instance itself.
+func (c *Context) WithContext(ctx stdcontext.Context) *Context {
+	if c == nil {
+		return nil
+	}
+	c.ctx = ctx
+	return c
+}
+
+//Context returns context.Context for cancellation of parsing.
+func (c *Context) Context() stdcontext.Context {
+	if c == nil || c.ctx == nil {
+		return stdcontext.Background()
+	}
+	return c.ctx
+}
+
+//Err returns non-nil error if parsing is canceled or deadline exceeded.
+func (c *Context) Err() error {
+	if c == nil || c.ctx == nil {
+		return nil
+	}
+	return errs.Wrap(c.ctx.Err())
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,18 +1,52 @@
 package context
 
 import (
+	stdcontext "context"
 	"fmt"
 	"strings"
 
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/keyring"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
 )
 
+//Options class for parsing options (read only while parsing)
+type Options struct {
+	options map[OptCode]bool
+}
+
+//Get returns option.
+func (o *Options) Get(code OptCode) bool {
+	if o == nil {
+		return false
+	}
+	if f, ok := o.options[code]; ok {
+		return f
+	}
+	return false
+}
+
+//copy returns copy of Options instance
+func (o *Options) copy() *Options {
+	opts := &Options{options: map[OptCode]bool{}}
+	if o != nil {
+		for k, v := range o.options {
+			opts.options[k] = v
+		}
+	}
+	return opts
+}
+
 //Context class fir parsing packets
 type Context struct {
-	options map[OptCode]bool
+	opts *Options
+	//state of parsing
+	ctx stdcontext.Context
 	SymAlgMode
 	SigCreationTime *values.DateTime
 	KeyCreationTime *values.DateTime
+	sessionKey      *SessionKey
+	passphrase      []byte
+	secretKeys      []*keyring.SecretKey
 }
 
 //OptFunc is self-referential function for functional options pattern
@@ -20,7 +54,7 @@ type OptFunc func(*Context)
 
 // New returns a new Context instance
 func New(opts ...OptFunc) *Context {
-	c := &Context{options: map[OptCode]bool{}, SymAlgMode: ModeNotSpecified}
+	c := &Context{opts: &Options{options: map[OptCode]bool{}}, SymAlgMode: ModeNotSpecified}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -37,25 +71,39 @@ func SetByString(name string, f bool) OptFunc {
 	return func(c *Context) { c.Set(GetOptCode(name), f) }
 }
 
+//Fork returns a new Context instance with copy of options and initial state of parsing.
+//Parsing with forked Context does not interfere with the original.
+func (c *Context) Fork() *Context {
+	if c == nil {
+		return New()
+	}
+	return &Context{opts: c.opts.copy(), ctx: c.ctx, SymAlgMode: ModeNotSpecified, sessionKey: c.sessionKey, passphrase: c.passphrase, secretKeys: c.secretKeys}
+}
+
+//Options returns options in Context.
+func (c *Context) Options() *Options {
+	if c == nil {
+		return nil
+	}
+	return c.opts
+}
+
 //Set sets option to Context.
 func (c *Context) Set(code OptCode, f bool) {
 	if c == nil {
 		return
 	}
 	if code.integer() > 0 {
-		c.options[code] = f
+		c.opts.options[code] = f
 	}
 }
 
-//Set sets option to Context.
+//Get returns option in Context.
 func (c *Context) Get(code OptCode) bool {
 	if c == nil {
 		return false
 	}
-	if f, ok := c.options[code]; ok {
-		return f
-	}
-	return false
+	return c.opts.Get(code)
 }
 
 //Armor return flag value of armorFlag
@@ -85,6 +133,9 @@ func (c *Context) Private() bool { return c.Get(PRIVATE) || c.Get(DEBUG) }
 //UTC return flag value of utcFlag
 func (c *Context) UTC() bool { return c.Get(UTC) }
 
+//ShowSecrets return flag value of showSecretsFlag (not implied by debugFlag)
+func (c *Context) ShowSecrets() bool { return c.Get(SECRETS) }
+
 //Stringer
 func (c *Context) String() string {
 	strs := []string{}
@@ -109,6 +160,8 @@ func (c *Context) String() string {
 			flag = c.Private()
 		case UTC:
 			flag = c.UTC()
+		case SECRETS:
+			flag = c.ShowSecrets()
 		}
 		strs = append(strs, fmt.Sprintf("%v:%v", OptCode(cd), flag))
 	}
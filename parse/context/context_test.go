@@ -51,6 +51,26 @@ func TestSSetSymAlgModePubEnc(t *testing.T) {
 	}
 }
 
+func TestFork(t *testing.T) {
+	cxt := New(Set(UTC, true))
+	cxt.SetAlgPubEnc()
+	fork := cxt.Fork()
+	if !fork.UTC() {
+		t.Errorf("Fork().UTC() = %v, want %v.", fork.UTC(), true)
+	}
+	if fork.AlgMode() != ModeNotSpecified {
+		t.Errorf("Fork().Mode = %v, want \"%v\".", fork.AlgMode(), ModeNotSpecified)
+	}
+	fork.Set(UTC, false)
+	fork.SetAlgSymEnc()
+	if !cxt.UTC() {
+		t.Errorf("Context.UTC() = %v, want %v.", cxt.UTC(), true)
+	}
+	if !cxt.IsPubEnc() {
+		t.Errorf("Context.Mode = %v, want \"%v\".", cxt.AlgMode(), ModePubEnc)
+	}
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
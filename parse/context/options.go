@@ -12,12 +12,13 @@ const (
 	ARMOR                  //accepts ASCII input only
 	CERT                   //dumps attested certification in signature packets (tag 2)
 	DEBUG                  //for debug
-	GDUMP                  //selects alternate (GnuPG type) dump format (not used)
+	GDUMP                  //selects alternate (GnuPG type) dump format
 	INTEGER                //dumps multi-precision integers
 	LITERAL                //dumps literal packets (tag 11)
 	MARKER                 //dumps marker packets (tag 10)
 	PRIVATE                //dumps private packets (tag 60-63)
 	UTC                    //output UTC time
+	SECRETS                //shows secret key material (masked by default)
 )
 
 var optcodeMap = map[OptCode]string{
@@ -30,6 +31,7 @@ var optcodeMap = map[OptCode]string{
 	MARKER:  "marker",
 	PRIVATE: "private",
 	UTC:     "utc",
+	SECRETS: "show-secrets",
 }
 
 //GetOptCode returns OptCode from string
@@ -6,7 +6,7 @@ import (
 
 func TestNewOptions(t *testing.T) {
 	o := New()
-	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 
@@ -24,8 +24,9 @@ func TestSetAllOpt(t *testing.T) {
 		Set(MARKER, true),
 		Set(PRIVATE, true),
 		Set(UTC, true),
+		Set(SECRETS, true),
 	)
-	res := "armor:true,cert:true,debug:true,gdump:true,int:true,literal:true,marker:true,private:true,utc:true"
+	res := "armor:true,cert:true,debug:true,gdump:true,int:true,literal:true,marker:true,private:true,utc:true,show-secrets:true"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -33,7 +34,7 @@ func TestSetAllOpt(t *testing.T) {
 
 func TestArmorOpt(t *testing.T) {
 	o := New(SetByString("ARMOR", true))
-	res := "armor:true,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false"
+	res := "armor:true,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -41,7 +42,7 @@ func TestArmorOpt(t *testing.T) {
 
 func TestCertOpt(t *testing.T) {
 	o := New(SetByString("CERT", true))
-	res := "armor:false,cert:true,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false"
+	res := "armor:false,cert:true,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -49,7 +50,7 @@ func TestCertOpt(t *testing.T) {
 
 func TestDebugOpt(t *testing.T) {
 	o := New(SetByString("DEBUG", true))
-	res := "armor:false,cert:true,debug:true,gdump:true,int:true,literal:true,marker:true,private:true,utc:false"
+	res := "armor:false,cert:true,debug:true,gdump:true,int:true,literal:true,marker:true,private:true,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -57,7 +58,7 @@ func TestDebugOpt(t *testing.T) {
 
 func TestGDumpOpt(t *testing.T) {
 	o := New(SetByString("GDUMP", true))
-	res := "armor:false,cert:false,debug:false,gdump:true,int:false,literal:false,marker:false,private:false,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:true,int:false,literal:false,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -65,7 +66,7 @@ func TestGDumpOpt(t *testing.T) {
 
 func TestIntegerOpt(t *testing.T) {
 	o := New(SetByString("INT", true))
-	res := "armor:false,cert:false,debug:false,gdump:false,int:true,literal:false,marker:false,private:false,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:true,literal:false,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -73,7 +74,7 @@ func TestIntegerOpt(t *testing.T) {
 
 func TestLiteralOpt(t *testing.T) {
 	o := New(SetByString("Literal", true))
-	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:true,marker:false,private:false,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:true,marker:false,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -81,7 +82,7 @@ func TestLiteralOpt(t *testing.T) {
 
 func TestMarkerOpt(t *testing.T) {
 	o := New(SetByString("Marker", true))
-	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:true,private:false,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:true,private:false,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -89,7 +90,7 @@ func TestMarkerOpt(t *testing.T) {
 
 func TestPrivateOpt(t *testing.T) {
 	o := New(SetByString("Private", true))
-	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:true,utc:false"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:true,utc:false,show-secrets:false"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
@@ -97,7 +98,15 @@ func TestPrivateOpt(t *testing.T) {
 
 func TestUTCOpt(t *testing.T) {
 	o := New(SetByString("UTC", true))
-	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:true"
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:true,show-secrets:false"
+	if o.String() != res {
+		t.Errorf("Options()  = %v, want %v.", o.String(), res)
+	}
+}
+
+func TestShowSecretsOpt(t *testing.T) {
+	o := New(SetByString("show-secrets", true))
+	res := "armor:false,cert:false,debug:false,gdump:false,int:false,literal:false,marker:false,private:false,utc:false,show-secrets:true"
 	if o.String() != res {
 		t.Errorf("Options()  = %v, want %v.", o.String(), res)
 	}
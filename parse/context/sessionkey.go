@@ -0,0 +1,122 @@
+package context
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/keyring"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//SessionKey class is symmetric algorithm and session key for decrypting encrypted data packets
+type SessionKey struct {
+	SymID values.SymID
+	Key   []byte
+}
+
+//ParseSessionKey returns SessionKey instance from "ALGO:HEX" string (e.g. output of gpg --show-session-key)
+func ParseSessionKey(s string) (*SessionKey, error) {
+	s = strings.Trim(strings.TrimSpace(s), "'\"")
+	elms := strings.SplitN(s, ":", 2)
+	if len(elms) != 2 {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("session_key", "ALGO:HEX format"))
+	}
+	alg, err := strconv.ParseUint(strings.TrimSpace(elms[0]), 10, 8)
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err), errs.WithContext("algorithm", elms[0]))
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(elms[1]))
+	if err != nil || len(key) == 0 {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err), errs.WithContext("key", "hexadecimal octets"))
+	}
+	return &SessionKey{SymID: values.SymID(alg), Key: key}, nil
+}
+
+//String returns "ALGO:HEX" string
+func (sk *SessionKey) String() string {
+	if sk == nil {
+		return ""
+	}
+	return strconv.Itoa(int(sk.SymID)) + ":" + strings.ToUpper(hex.EncodeToString(sk.Key))
+}
+
+//WithSessionKey returns closure as type OptFunc
+func WithSessionKey(sk *SessionKey) OptFunc {
+	return func(c *Context) { c.SetSessionKey(sk) }
+}
+
+//SetSessionKey sets session key for decrypting encrypted data packets.
+func (c *Context) SetSessionKey(sk *SessionKey) {
+	if c == nil {
+		return
+	}
+	c.sessionKey = sk
+}
+
+//SessionKey returns session key for decrypting encrypted data packets (nil if not set).
+func (c *Context) SessionKey() *SessionKey {
+	if c == nil {
+		return nil
+	}
+	return c.sessionKey
+}
+
+//WithPassphrase returns closure as type OptFunc
+func WithPassphrase(passphrase []byte) OptFunc {
+	return func(c *Context) { c.SetPassphrase(passphrase) }
+}
+
+//SetPassphrase sets passphrase for decrypting symmetric-key encrypted session key packets.
+func (c *Context) SetPassphrase(passphrase []byte) {
+	if c == nil {
+		return
+	}
+	c.passphrase = passphrase
+}
+
+//Passphrase returns passphrase for decrypting symmetric-key encrypted session key packets (nil if not set).
+func (c *Context) Passphrase() []byte {
+	if c == nil {
+		return nil
+	}
+	return c.passphrase
+}
+
+//WithSecretKeys returns closure as type OptFunc
+func WithSecretKeys(keys []*keyring.SecretKey) OptFunc {
+	return func(c *Context) { c.SetSecretKeys(keys) }
+}
+
+//SetSecretKeys sets secret keys for decrypting public-key encrypted session key packets.
+func (c *Context) SetSecretKeys(keys []*keyring.SecretKey) {
+	if c == nil {
+		return
+	}
+	c.secretKeys = keys
+}
+
+//SecretKeys returns secret keys for decrypting public-key encrypted session key packets (nil if not set).
+func (c *Context) SecretKeys() []*keyring.SecretKey {
+	if c == nil {
+		return nil
+	}
+	return c.secretKeys
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,62 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+func TestParseSessionKey(t *testing.T) {
+	testCases := []struct {
+		s   string
+		str string
+		err error
+	}{
+		{s: "9:5715297AA4E3DD36254C63C84E8101CBD8C250EA75EF696709829D8730786C02", str: "9:5715297AA4E3DD36254C63C84E8101CBD8C250EA75EF696709829D8730786C02", err: nil},
+		{s: "'3:79debf2265eb299851d31485f7e87db5'", str: "3:79DEBF2265EB299851D31485F7E87DB5", err: nil},
+		{s: "79DEBF2265EB299851D31485F7E87DB5", str: "", err: ecode.ErrSessionKey},
+		{s: "AES:79DEBF2265EB299851D31485F7E87DB5", str: "", err: ecode.ErrSessionKey},
+		{s: "3:XYZ", str: "", err: ecode.ErrSessionKey},
+		{s: "3:", str: "", err: ecode.ErrSessionKey},
+	}
+	for _, tc := range testCases {
+		sk, err := ParseSessionKey(tc.s)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("ParseSessionKey(%v) = \"%+v\", want \"%+v\".", tc.s, err, tc.err)
+		}
+		if sk.String() != tc.str {
+			t.Errorf("ParseSessionKey(%v) = \"%v\", want \"%v\".", tc.s, sk, tc.str)
+		}
+	}
+}
+
+func TestSessionKeyFork(t *testing.T) {
+	sk, _ := ParseSessionKey("3:79DEBF2265EB299851D31485F7E87DB5")
+	cxt := New(WithSessionKey(sk))
+	if cxt.Fork().SessionKey() != sk {
+		t.Errorf("Context.SessionKey() = \"%v\", want \"%v\".", cxt.Fork().SessionKey(), sk)
+	}
+	if !bytes.Equal(cxt.SessionKey().Key, sk.Key) {
+		t.Errorf("Context.SessionKey() = \"%v\", want \"%v\".", cxt.SessionKey(), sk)
+	}
+	if New().SessionKey() != nil {
+		t.Errorf("Context.SessionKey() = \"%v\", want nil.", New().SessionKey())
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
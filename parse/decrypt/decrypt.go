@@ -0,0 +1,262 @@
+package decrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/blowfish"
+	"golang.org/x/crypto/cast5"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/openpgp/packet"
+	"golang.org/x/crypto/twofish"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+var symIDKeyLen = map[values.SymID]int{
+	2:  24, //TripleDES (168 bit key derived from 192)
+	3:  16, //CAST5
+	4:  16, //Blowfish
+	7:  16, //AES with 128-bit key
+	8:  24, //AES with 192-bit key
+	9:  32, //AES with 256-bit key
+	10: 32, //Twofish with 256-bit key
+}
+
+//KeyLen returns length of key for symmetric algorithm (0 if algorithm is not supported)
+func KeyLen(symid values.SymID) int {
+	return symIDKeyLen[symid]
+}
+
+//newBlock returns cipher.Block instance of symmetric algorithm
+func newBlock(symid values.SymID, key []byte) (cipher.Block, error) {
+	l := KeyLen(symid)
+	if l == 0 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", symid.String()))
+	}
+	if len(key) != l {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("symid", symid.String()), errs.WithContext("key_length", len(key)))
+	}
+	var block cipher.Block
+	var err error
+	switch symid {
+	case 2:
+		block, err = des.NewTripleDESCipher(key)
+	case 3:
+		block, err = cast5.NewCipher(key)
+	case 4:
+		block, err = blowfish.NewCipher(key)
+	case 7, 8, 9:
+		block, err = aes.NewCipher(key)
+	case 10:
+		block, err = twofish.NewCipher(key)
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("symid", symid.String()))
+	}
+	return block, nil
+}
+
+//newAEAD returns cipher.AEAD instance of AEAD algorithm
+func newAEAD(aeadid values.AEADID, block cipher.Block) (cipher.AEAD, error) {
+	switch aeadid {
+	case 1:
+		return newEAX(block)
+	case 2:
+		return newOCB(block)
+	case 3:
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithCause(err), errs.WithContext("aeadid", aeadid.String()))
+		}
+		return aead, nil
+	}
+	return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("aeadid", aeadid.String()))
+}
+
+//SED returns plain data of Symmetrically Encrypted Data Packet (tag 9) decrypted by session key (quick check octets are checked)
+func SED(symid values.SymID, key, data []byte) ([]byte, error) {
+	plain, err := cfb(symid, key, data, packet.OCFBResync)
+	if err != nil {
+		return nil, err
+	}
+	return plain[symid.IVLen()+2:], nil
+}
+
+//SEIPD1 returns plain data (excluding Modification Detection Code Packet) of Sym. Encrypted Integrity Protected Data Packet (tag 18) version 1 decrypted by session key.
+//data is body of packet without version octet. Plain data is returned with ecode.ErrIntegrity error if MDC is not matched.
+func SEIPD1(symid values.SymID, key, data []byte) ([]byte, error) {
+	plain, err := cfb(symid, key, data, packet.OCFBNoResync)
+	if err != nil {
+		return nil, err
+	}
+	bs := symid.IVLen()
+	if len(plain) < bs+2+22 || plain[len(plain)-22] != 0xd3 || plain[len(plain)-21] != 0x14 {
+		return plain[bs+2:], errs.Wrap(ecode.ErrIntegrity, errs.WithContext("mdc", "not found"))
+	}
+	mdc := sha1.Sum(plain[:len(plain)-20])
+	if subtle.ConstantTimeCompare(mdc[:], plain[len(plain)-20:]) != 1 {
+		return plain[bs+2:], errs.Wrap(ecode.ErrIntegrity, errs.WithContext("mdc", "not matched"))
+	}
+	return plain[bs+2 : len(plain)-22], nil
+}
+
+//cfb returns plain data (including random prefix) decrypted with OpenPGP CFB mode
+func cfb(symid values.SymID, key, data []byte, resync packet.OCFBResyncOption) ([]byte, error) {
+	block, err := newBlock(symid, key)
+	if err != nil {
+		return nil, err
+	}
+	bs := block.BlockSize()
+	if len(data) < bs+2 {
+		return nil, errs.Wrap(io.ErrUnexpectedEOF, errs.WithContext("length", len(data)))
+	}
+	plain := make([]byte, len(data))
+	copy(plain, data[:bs+2])
+	stream := packet.NewOCFBDecrypter(block, plain[:bs+2], resync)
+	if stream == nil {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("quick_check", "not matched"))
+	}
+	stream.XORKeyStream(plain[bs+2:], data[bs+2:])
+	return plain, nil
+}
+
+//AEAD returns plain data of AEAD Encrypted Data Packet (tag 20) version 1 decrypted by session key.
+//data is encrypted chunks and final authentication tag.
+func AEAD(symid values.SymID, aeadid values.AEADID, chunk byte, iv, key, data []byte) ([]byte, error) {
+	block, err := newBlock(symid, key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(aeadid, block)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aead.NonceSize() {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("iv_length", len(iv)))
+	}
+	header := []byte{0xd4, 0x01, byte(symid), byte(aeadid), chunk}
+	nonce := func(index uint64) []byte {
+		n := make([]byte, len(iv))
+		copy(n, iv)
+		idx := make([]byte, 8)
+		binary.BigEndian.PutUint64(idx, index)
+		xorBytes(n[len(n)-8:], idx)
+		return n
+	}
+	adata := func(index uint64, total []byte) []byte {
+		idx := make([]byte, 8)
+		binary.BigEndian.PutUint64(idx, index)
+		return concat(header, idx, total)
+	}
+	return chunks(aead, chunk, data, nonce, adata)
+}
+
+//SEIPD2 returns plain data of Sym. Encrypted Integrity Protected Data Packet (tag 18) version 2 decrypted by session key.
+//data is encrypted chunks and final authentication tag.
+func SEIPD2(symid values.SymID, aeadid values.AEADID, chunk byte, salt, key, data []byte) ([]byte, error) {
+	if KeyLen(symid) == 0 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", symid.String()))
+	}
+	if len(key) != KeyLen(symid) {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("symid", symid.String()), errs.WithContext("key_length", len(key)))
+	}
+	header := []byte{0xd2, 0x02, byte(symid), byte(aeadid), chunk}
+	//nonce size is checked after making key
+	ivLen := aeadid.IVLen() - 8
+	if ivLen <= 0 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("aeadid", aeadid.String()))
+	}
+	derived := make([]byte, len(key)+ivLen)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, salt, header), derived); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	block, err := newBlock(symid, derived[:len(key)])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(aeadid, block)
+	if err != nil {
+		return nil, err
+	}
+	iv := derived[len(key):]
+	if len(iv)+8 != aead.NonceSize() {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("aeadid", aeadid.String()))
+	}
+	nonce := func(index uint64) []byte {
+		idx := make([]byte, 8)
+		binary.BigEndian.PutUint64(idx, index)
+		return concat(iv, idx)
+	}
+	adata := func(_ uint64, total []byte) []byte {
+		return concat(header, total)
+	}
+	return chunks(aead, chunk, data, nonce, adata)
+}
+
+//chunks returns plain data of chunked AEAD encryption (adata gets total octets of plain data for final authentication tag only)
+func chunks(aead cipher.AEAD, chunk byte, data []byte, nonce func(uint64) []byte, adata func(uint64, []byte) []byte) ([]byte, error) {
+	if chunk > 16 {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("chunk_size", chunk))
+	}
+	tagLen := aead.Overhead()
+	if len(data) < tagLen {
+		return nil, errs.Wrap(io.ErrUnexpectedEOF, errs.WithContext("length", len(data)))
+	}
+	size := (1 << (uint(chunk) + 6)) + tagLen
+	body, final := data[:len(data)-tagLen], data[len(data)-tagLen:]
+	plain := &bytes.Buffer{}
+	index := uint64(0)
+	for len(body) > 0 {
+		l := size
+		if len(body) < l {
+			l = len(body)
+		}
+		p, err := aead.Open(nil, nonce(index), body[:l], adata(index, nil))
+		if err != nil {
+			return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithCause(err), errs.WithContext("chunk", index))
+		}
+		plain.Write(p)
+		body = body[l:]
+		index++
+	}
+	total := make([]byte, 8)
+	binary.BigEndian.PutUint64(total, uint64(plain.Len()))
+	if _, err := aead.Open(nil, nonce(index), final, adata(index, total)); err != nil {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithCause(err), errs.WithContext("chunk", "final"))
+	}
+	return plain.Bytes(), nil
+}
+
+//concat returns concatenated octets
+func concat(bs ...[]byte) []byte {
+	var b []byte
+	for _, s := range bs {
+		b = append(b, s...)
+	}
+	return b
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
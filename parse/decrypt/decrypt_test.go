@@ -0,0 +1,251 @@
+package decrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/openpgp/packet"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestOCB(t *testing.T) {
+	//RFC 7253 Appendix A
+	testCases := []struct {
+		nonce, adata, plain, cipher string
+	}{
+		{nonce: "BBAA99887766554433221100", adata: "", plain: "", cipher: "785407BFFFC8AD9EDCC5520AC9111EE6"},
+		{nonce: "BBAA99887766554433221101", adata: "0001020304050607", plain: "0001020304050607", cipher: "6820B3657B6F615A5725BDA0D3B4EB3A257C9AF1F8F03009"},
+		{nonce: "BBAA99887766554433221102", adata: "0001020304050607", plain: "", cipher: "81017F8203F081277152FADE694A0A00"},
+		{nonce: "BBAA99887766554433221103", adata: "", plain: "0001020304050607", cipher: "45DD69F8F5AAE72414054CD1F35D82760B2CD00D2F99BFA9"},
+		{nonce: "BBAA99887766554433221104", adata: "000102030405060708090A0B0C0D0E0F", plain: "000102030405060708090A0B0C0D0E0F", cipher: "571D535B60B277188BE5147170A9A22C3AD7A4FF3835B8C5701C1CCEC8FC3358"},
+	}
+	block, _ := aes.NewCipher(mustHex("000102030405060708090A0B0C0D0E0F"))
+	aead, err := newOCB(block)
+	if err != nil {
+		t.Fatalf("newOCB() = \"%+v\", want nil.", err)
+	}
+	for _, tc := range testCases {
+		c := aead.Seal(nil, mustHex(tc.nonce), mustHex(tc.plain), mustHex(tc.adata))
+		if !bytes.Equal(c, mustHex(tc.cipher)) {
+			t.Errorf("ocb.Seal() = %X, want %v.", c, tc.cipher)
+		}
+		p, err := aead.Open(nil, mustHex(tc.nonce), mustHex(tc.cipher), mustHex(tc.adata))
+		if err != nil {
+			t.Errorf("ocb.Open() = \"%+v\", want nil.", err)
+		} else if !bytes.Equal(p, mustHex(tc.plain)) {
+			t.Errorf("ocb.Open() = %X, want %v.", p, tc.plain)
+		}
+	}
+}
+
+func TestEAX(t *testing.T) {
+	//test vectors in "The EAX Mode of Operation" (Bellare, Rogaway and Wagner)
+	testCases := []struct {
+		key, nonce, adata, plain, cipher string
+	}{
+		{key: "233952DEE4D5ED5F9B9C6D6FF80FF478", nonce: "62EC67F9C3A4A407FCB2A8C49031A8B3", adata: "6BFB914FD07EAE6B", plain: "", cipher: "E037830E8389F27B025A2D6527E79D01"},
+		{key: "91945D3F4DCBEE0BF45EF52255F095A4", nonce: "BECAF043B0A23D843194BA972C66DEBD", adata: "FA3BFD4806EB53FA", plain: "F7FB", cipher: "19DD5C4C9331049D0BDAB0277408F67967E5"},
+		{key: "01F74AD64077F2E704C0F60ADA3DD523", nonce: "70C3DB4F0D26368400A10ED05D2BFF5E", adata: "234A3463C1264AC6", plain: "1A47CB4933", cipher: "D851D5BAE03A59F238A23E39199DC9266626C40F80"},
+	}
+	for _, tc := range testCases {
+		block, _ := aes.NewCipher(mustHex(tc.key))
+		aead, err := newEAX(block)
+		if err != nil {
+			t.Fatalf("newEAX() = \"%+v\", want nil.", err)
+		}
+		c := aead.Seal(nil, mustHex(tc.nonce), mustHex(tc.plain), mustHex(tc.adata))
+		if !bytes.Equal(c, mustHex(tc.cipher)) {
+			t.Errorf("eax.Seal() = %X, want %v.", c, tc.cipher)
+		}
+		p, err := aead.Open(nil, mustHex(tc.nonce), mustHex(tc.cipher), mustHex(tc.adata))
+		if err != nil {
+			t.Errorf("eax.Open() = \"%+v\", want nil.", err)
+		} else if !bytes.Equal(p, mustHex(tc.plain)) {
+			t.Errorf("eax.Open() = %X, want %v.", p, tc.plain)
+		}
+		c[0] ^= 0x01
+		if _, err := aead.Open(nil, mustHex(tc.nonce), c, mustHex(tc.adata)); !errs.Is(err, ecode.ErrIntegrity) {
+			t.Errorf("eax.Open() = \"%+v\", want \"%+v\".", err, ecode.ErrIntegrity)
+		}
+	}
+}
+
+var (
+	testKey   = mustHex("5715297AA4E3DD36254C63C84E8101CBD8C250EA75EF696709829D8730786C02")
+	testPlain = bytes.Repeat([]byte("Hello, world!\n"), 10)
+)
+
+//encryptCFB returns data encrypted with OpenPGP CFB mode (for testing)
+func encryptCFB(plain []byte, resync packet.OCFBResyncOption) []byte {
+	block, _ := aes.NewCipher(testKey)
+	stream, prefix := packet.NewOCFBEncrypter(block, make([]byte, 16), resync)
+	data := make([]byte, len(plain))
+	stream.XORKeyStream(data, plain)
+	return append(prefix, data...)
+}
+
+func TestSED(t *testing.T) {
+	data := encryptCFB(testPlain, packet.OCFBResync)
+	plain, err := SED(9, testKey, data)
+	if err != nil {
+		t.Errorf("SED() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(plain, testPlain) {
+		t.Errorf("SED() = %q, want %q.", plain, testPlain)
+	}
+	wrong := append([]byte{}, testKey...)
+	wrong[0] ^= 0xff
+	if _, err := SED(9, wrong, data); !errs.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("SED() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+	if _, err := SED(9, testKey[:16], data); !errs.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("SED() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+	if _, err := SED(1, testKey, data); !errs.Is(err, ecode.ErrUnsupportedAlg) {
+		t.Errorf("SED() = \"%+v\", want \"%+v\".", err, ecode.ErrUnsupportedAlg)
+	}
+}
+
+func TestSEIPD1(t *testing.T) {
+	//plain data + MDC packet (random prefix is all zero)
+	h := sha1.New()
+	h.Write(make([]byte, 18))
+	h.Write(testPlain)
+	h.Write([]byte{0xd3, 0x14})
+	plain := append(append(append([]byte{}, testPlain...), 0xd3, 0x14), h.Sum(nil)...)
+	data := encryptCFB(plain, packet.OCFBNoResync)
+
+	res, err := SEIPD1(9, testKey, data)
+	if err != nil {
+		t.Errorf("SEIPD1() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(res, testPlain) {
+		t.Errorf("SEIPD1() = %q, want %q.", res, testPlain)
+	}
+	data[len(data)-1] ^= 0x01
+	if _, err := SEIPD1(9, testKey, data); !errs.Is(err, ecode.ErrIntegrity) {
+		t.Errorf("SEIPD1() = \"%+v\", want \"%+v\".", err, ecode.ErrIntegrity)
+	}
+}
+
+//sealChunks returns chunked AEAD encryption of plain data (for testing)
+func sealChunks(aead cipher.AEAD, chunk byte, plain []byte, nonce func(uint64) []byte, adata func(uint64, []byte) []byte) []byte {
+	size := 1 << (uint(chunk) + 6)
+	var data []byte
+	index := uint64(0)
+	for rest := plain; len(rest) > 0; index++ {
+		l := size
+		if len(rest) < l {
+			l = len(rest)
+		}
+		data = aead.Seal(data, nonce(index), rest[:l], adata(index, nil))
+		rest = rest[l:]
+	}
+	total := make([]byte, 8)
+	binary.BigEndian.PutUint64(total, uint64(len(plain)))
+	return aead.Seal(data, nonce(index), nil, adata(index, total))
+}
+
+func index8(index uint64) []byte {
+	idx := make([]byte, 8)
+	binary.BigEndian.PutUint64(idx, index)
+	return idx
+}
+
+func TestAEAD(t *testing.T) {
+	testCases := []struct {
+		aeadid values.AEADID
+		iv     []byte
+	}{
+		{aeadid: 1, iv: mustHex("000102030405060708090A0B0C0D0E0F")},
+		{aeadid: 2, iv: mustHex("000102030405060708090A0B0C0D0E")},
+		{aeadid: 3, iv: mustHex("000102030405060708090A0B")},
+	}
+	for _, tc := range testCases {
+		block, _ := aes.NewCipher(testKey)
+		aead, err := newAEAD(tc.aeadid, block)
+		if err != nil {
+			t.Fatalf("newAEAD() = \"%+v\", want nil.", err)
+		}
+		nonce := func(index uint64) []byte {
+			n := append([]byte{}, tc.iv...)
+			xorBytes(n[len(n)-8:], index8(index))
+			return n
+		}
+		adata := func(index uint64, total []byte) []byte {
+			return concat([]byte{0xd4, 0x01, 9, byte(tc.aeadid), 0}, index8(index), total)
+		}
+		data := sealChunks(aead, 0, testPlain, nonce, adata)
+		plain, err := AEAD(9, tc.aeadid, 0, tc.iv, testKey, data)
+		if err != nil {
+			t.Errorf("AEAD(%v) = \"%+v\", want nil.", tc.aeadid, err)
+		} else if !bytes.Equal(plain, testPlain) {
+			t.Errorf("AEAD(%v) = %q, want %q.", tc.aeadid, plain, testPlain)
+		}
+		data[len(data)-1] ^= 0x01
+		if _, err := AEAD(9, tc.aeadid, 0, tc.iv, testKey, data); !errs.Is(err, ecode.ErrIntegrity) {
+			t.Errorf("AEAD(%v) = \"%+v\", want \"%+v\".", tc.aeadid, err, ecode.ErrIntegrity)
+		}
+	}
+}
+
+func TestSEIPD2(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x5a}, 32)
+	for _, aeadid := range []values.AEADID{1, 2, 3} {
+		info := []byte{0xd2, 0x02, 9, byte(aeadid), 1}
+		derived := make([]byte, 32+aeadid.IVLen()-8)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, testKey, salt, info), derived); err != nil {
+			t.Fatalf("hkdf = \"%+v\", want nil.", err)
+		}
+		block, _ := aes.NewCipher(derived[:32])
+		aead, _ := newAEAD(aeadid, block)
+		nonce := func(index uint64) []byte {
+			return concat(derived[32:], index8(index))
+		}
+		adata := func(_ uint64, total []byte) []byte {
+			return concat(info, total)
+		}
+		data := sealChunks(aead, 1, testPlain, nonce, adata)
+		plain, err := SEIPD2(9, aeadid, 1, salt, testKey, data)
+		if err != nil {
+			t.Errorf("SEIPD2(%v) = \"%+v\", want nil.", aeadid, err)
+		} else if !bytes.Equal(plain, testPlain) {
+			t.Errorf("SEIPD2(%v) = %q, want %q.", aeadid, plain, testPlain)
+		}
+		if _, err := SEIPD2(9, aeadid, 1, salt, testKey[:16], data); !errs.Is(err, ecode.ErrSessionKey) {
+			t.Errorf("SEIPD2(%v) = \"%+v\", want \"%+v\".", aeadid, err, ecode.ErrSessionKey)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,141 @@
+package decrypt
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+//eax class is EAX mode of operation (128-bit block cipher, 16 octets nonce and tag)
+type eax struct {
+	block  cipher.Block
+	k1, k2 []byte //sub-keys of OMAC
+}
+
+//newEAX returns cipher.AEAD instance of EAX mode
+func newEAX(block cipher.Block) (cipher.AEAD, error) {
+	if block.BlockSize() != 16 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("block_size", block.BlockSize()))
+	}
+	l := make([]byte, 16)
+	block.Encrypt(l, l)
+	k1 := double(l)
+	return &eax{block: block, k1: k1, k2: double(k1)}, nil
+}
+
+//NonceSize returns size of nonce
+func (e *eax) NonceSize() int { return 16 }
+
+//Overhead returns size of authentication tag
+func (e *eax) Overhead() int { return 16 }
+
+//Seal encrypts and authenticates plaintext
+func (e *eax) Seal(dst, nonce, plaintext, adata []byte) []byte {
+	n := e.omac(0, nonce)
+	h := e.omac(1, adata)
+	ret, out := sliceForAppend(dst, len(plaintext)+16)
+	cipher.NewCTR(e.block, n).XORKeyStream(out, plaintext)
+	c := e.omac(2, out[:len(plaintext)])
+	tag := out[len(plaintext):]
+	for i := range tag {
+		tag[i] = n[i] ^ h[i] ^ c[i]
+	}
+	return ret
+}
+
+//Open decrypts and authenticates ciphertext
+func (e *eax) Open(dst, nonce, ciphertext, adata []byte) ([]byte, error) {
+	if len(ciphertext) < 16 {
+		return nil, errs.Wrap(ecode.ErrIntegrity)
+	}
+	ct, tag := ciphertext[:len(ciphertext)-16], ciphertext[len(ciphertext)-16:]
+	n := e.omac(0, nonce)
+	h := e.omac(1, adata)
+	c := e.omac(2, ct)
+	expected := make([]byte, 16)
+	for i := range expected {
+		expected[i] = n[i] ^ h[i] ^ c[i]
+	}
+	if subtle.ConstantTimeCompare(expected, tag) != 1 {
+		return nil, errs.Wrap(ecode.ErrIntegrity)
+	}
+	ret, out := sliceForAppend(dst, len(ct))
+	cipher.NewCTR(e.block, n).XORKeyStream(out, ct)
+	return ret, nil
+}
+
+//omac returns OMAC (CMAC) of data with tweak t
+func (e *eax) omac(t byte, data []byte) []byte {
+	mac := make([]byte, 16)
+	mac[15] = t
+	if len(data) == 0 {
+		//[t] block is the last block
+		xorBytes(mac, e.k1)
+		e.block.Encrypt(mac, mac)
+		return mac
+	}
+	e.block.Encrypt(mac, mac)
+	for len(data) > 16 {
+		xorBytes(mac, data[:16])
+		e.block.Encrypt(mac, mac)
+		data = data[16:]
+	}
+	xorBytes(mac, data)
+	if len(data) == 16 {
+		xorBytes(mac, e.k1)
+	} else {
+		mac[len(data)] ^= 0x80
+		xorBytes(mac, e.k2)
+	}
+	e.block.Encrypt(mac, mac)
+	return mac
+}
+
+//double returns doubled value of 128-bit block in GF(2^128)
+func double(b []byte) []byte {
+	d := make([]byte, len(b))
+	carry := b[0] >> 7
+	for i := 0; i < len(b)-1; i++ {
+		d[i] = b[i]<<1 | b[i+1]>>7
+	}
+	d[len(b)-1] = b[len(b)-1] << 1
+	d[len(b)-1] ^= 0x87 * carry
+	return d
+}
+
+//xorBytes sets dst[i] ^= src[i] (length of src is less than or equal to dst)
+func xorBytes(dst, src []byte) {
+	for i := range src {
+		dst[i] ^= src[i]
+	}
+}
+
+//sliceForAppend extends in by n octets, and returns extended slice and its new part
+func sliceForAppend(in []byte, n int) ([]byte, []byte) {
+	total := len(in) + n
+	var head []byte
+	if cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	return head, head[len(in):]
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,173 @@
+package decrypt
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"math/bits"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+//ocb class is OCB mode of operation (RFC 7253; 128-bit block cipher, 15 octets nonce and 16 octets tag)
+type ocb struct {
+	block   cipher.Block
+	lStar   []byte
+	lDollar []byte
+	l       [][]byte //L_0, L_1, ...
+}
+
+//newOCB returns cipher.AEAD instance of OCB mode
+func newOCB(block cipher.Block) (cipher.AEAD, error) {
+	if block.BlockSize() != 16 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("block_size", block.BlockSize()))
+	}
+	lStar := make([]byte, 16)
+	block.Encrypt(lStar, lStar)
+	lDollar := double(lStar)
+	l := [][]byte{double(lDollar)}
+	for i := 1; i < 64; i++ {
+		l = append(l, double(l[i-1]))
+	}
+	return &ocb{block: block, lStar: lStar, lDollar: lDollar, l: l}, nil
+}
+
+//NonceSize returns size of nonce
+func (o *ocb) NonceSize() int { return 15 }
+
+//Overhead returns size of authentication tag
+func (o *ocb) Overhead() int { return 16 }
+
+//Seal encrypts and authenticates plaintext
+func (o *ocb) Seal(dst, nonce, plaintext, adata []byte) []byte {
+	ret, out := sliceForAppend(dst, len(plaintext)+16)
+	tag := o.crypt(true, out, nonce, plaintext, adata)
+	copy(out[len(plaintext):], tag)
+	return ret
+}
+
+//Open decrypts and authenticates ciphertext
+func (o *ocb) Open(dst, nonce, ciphertext, adata []byte) ([]byte, error) {
+	if len(ciphertext) < 16 {
+		return nil, errs.Wrap(ecode.ErrIntegrity)
+	}
+	ct, tag := ciphertext[:len(ciphertext)-16], ciphertext[len(ciphertext)-16:]
+	ret, out := sliceForAppend(dst, len(ct))
+	if subtle.ConstantTimeCompare(o.crypt(false, out, nonce, ct, adata), tag) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errs.Wrap(ecode.ErrIntegrity)
+	}
+	return ret, nil
+}
+
+//crypt encrypts or decrypts src to dst, and returns authentication tag
+func (o *ocb) crypt(encrypt bool, dst, nonce, src, adata []byte) []byte {
+	//offset from nonce
+	n := make([]byte, 16)
+	copy(n[16-len(nonce):], nonce)
+	n[15-len(nonce)] |= 0x01
+	bottom := uint(n[15] & 0x3f)
+	n[15] &= 0xc0
+	ktop := make([]byte, 16)
+	o.block.Encrypt(ktop, n)
+	stretch := make([]byte, 24)
+	copy(stretch, ktop)
+	for i := 0; i < 8; i++ {
+		stretch[16+i] = ktop[i] ^ ktop[i+1]
+	}
+	offset := make([]byte, 16)
+	byteShift, bitShift := bottom/8, bottom%8
+	for i := range offset {
+		offset[i] = stretch[i+int(byteShift)] << bitShift
+		if bitShift > 0 {
+			offset[i] |= stretch[i+int(byteShift)+1] >> (8 - bitShift)
+		}
+	}
+
+	checksum := make([]byte, 16)
+	buf := make([]byte, 16)
+	i := 0
+	for ; len(src)-i*16 >= 16; i++ {
+		xorBytes(offset, o.l[bits.TrailingZeros(uint(i+1))])
+		p, c := src[i*16:(i+1)*16], dst[i*16:(i+1)*16]
+		copy(buf, p)
+		xorBytes(buf, offset)
+		if encrypt {
+			xorBytes(checksum, p)
+			o.block.Encrypt(buf, buf)
+		} else {
+			o.block.Decrypt(buf, buf)
+		}
+		xorBytes(buf, offset)
+		copy(c, buf)
+		if !encrypt {
+			xorBytes(checksum, c)
+		}
+	}
+	if rest := src[i*16:]; len(rest) > 0 {
+		xorBytes(offset, o.lStar)
+		pad := make([]byte, 16)
+		o.block.Encrypt(pad, offset)
+		out := dst[i*16:]
+		for j := range rest {
+			out[j] = rest[j] ^ pad[j]
+		}
+		plain := rest
+		if !encrypt {
+			plain = out[:len(rest)]
+		}
+		xorBytes(checksum, plain)
+		checksum[len(rest)] ^= 0x80
+	}
+	tag := make([]byte, 16)
+	copy(tag, checksum)
+	xorBytes(tag, offset)
+	xorBytes(tag, o.lDollar)
+	o.block.Encrypt(tag, tag)
+	xorBytes(tag, o.hash(adata))
+	return tag
+}
+
+//hash returns HASH(K, A) of OCB mode
+func (o *ocb) hash(adata []byte) []byte {
+	sum := make([]byte, 16)
+	offset := make([]byte, 16)
+	buf := make([]byte, 16)
+	i := 0
+	for ; len(adata)-i*16 >= 16; i++ {
+		xorBytes(offset, o.l[bits.TrailingZeros(uint(i+1))])
+		copy(buf, adata[i*16:(i+1)*16])
+		xorBytes(buf, offset)
+		o.block.Encrypt(buf, buf)
+		xorBytes(sum, buf)
+	}
+	if rest := adata[i*16:]; len(rest) > 0 {
+		xorBytes(offset, o.lStar)
+		for j := range buf {
+			buf[j] = 0
+		}
+		copy(buf, rest)
+		buf[len(rest)] = 0x80
+		xorBytes(buf, offset)
+		o.block.Encrypt(buf, buf)
+		xorBytes(sum, buf)
+	}
+	return sum
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
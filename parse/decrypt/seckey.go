@@ -0,0 +1,71 @@
+package decrypt
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//SecretKeyCFB returns plain secret-key material (s2k usage 254 and 255) decrypted with CFB mode.
+func SecretKeyCFB(symid values.SymID, key, iv, data []byte) ([]byte, error) {
+	block, err := newBlock(symid, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errs.Wrap(io.ErrUnexpectedEOF, errs.WithContext("iv_length", len(iv)))
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCFBDecrypter(block, iv).XORKeyStream(plain, data)
+	return plain, nil
+}
+
+//SecretKeyAEAD returns plain secret-key material (s2k usage 253) decrypted with AEAD.
+//Key is derived by HKDF from S2K-derived key, and associated data is packet type octet and public key fields (pub) of the packet.
+func SecretKeyAEAD(symid values.SymID, aeadid values.AEADID, tag values.TagID, version byte, key, iv, pub, data []byte) ([]byte, error) {
+	if KeyLen(symid) == 0 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", symid.String()))
+	}
+	typ := 0xc0 | byte(tag)
+	derived := make([]byte, len(key))
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte{typ, version, byte(symid), byte(aeadid)}), derived); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	block, err := newBlock(symid, derived)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(aeadid, block)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aead.NonceSize() {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("iv_length", len(iv)))
+	}
+	plain, err := aead.Open(nil, iv, data, concat([]byte{typ}, pub))
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithCause(err))
+	}
+	return plain, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,65 @@
+package decrypt
+
+import (
+	"crypto/cipher"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//SKESK4 returns symmetric algorithm and session key decrypted from encrypted session key in Symmetric-Key Encrypted Session Key Packet (tag 3) version 4.
+//kek is key derived from passphrase by S2K.
+func SKESK4(symid values.SymID, kek, esk []byte) (values.SymID, []byte, error) {
+	block, err := newBlock(symid, kek)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(esk) < 2 {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("esk_length", len(esk)))
+	}
+	plain := make([]byte, len(esk))
+	cipher.NewCFBDecrypter(block, make([]byte, block.BlockSize())).XORKeyStream(plain, esk)
+	sym := values.SymID(plain[0])
+	if l := KeyLen(sym); l == 0 || l != len(plain)-1 {
+		//wrong passphrase makes unknown algorithm or illegal length of key
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("symid", sym.String()), errs.WithContext("key_length", len(plain)-1))
+	}
+	return sym, plain[1:], nil
+}
+
+//SKESK5 returns session key decrypted from encrypted session key and authentication tag in Symmetric-Key Encrypted Session Key Packet (tag 3) version 5.
+//kek is key derived from passphrase by S2K.
+func SKESK5(symid values.SymID, aeadid values.AEADID, iv, kek, data []byte) ([]byte, error) {
+	block, err := newBlock(symid, kek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(aeadid, block)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aead.NonceSize() {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("iv_length", len(iv)))
+	}
+	key, err := aead.Open(nil, iv, data, []byte{0xc3, 0x05, byte(symid), byte(aeadid)})
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	return key, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,69 @@
+package decrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+func TestSKESK4(t *testing.T) {
+	kek := mustHex("79DEBF2265EB299851D31485F7E87DB5")
+	block, _ := aes.NewCipher(kek)
+	plain := append([]byte{9}, testKey...)
+	esk := make([]byte, len(plain))
+	cipher.NewCFBEncrypter(block, make([]byte, 16)).XORKeyStream(esk, plain)
+
+	sym, key, err := SKESK4(7, kek, esk)
+	if err != nil {
+		t.Errorf("SKESK4() = \"%+v\", want nil.", err)
+	} else if sym != 9 || !bytes.Equal(key, testKey) {
+		t.Errorf("SKESK4() = %v:%X, want 9:%X.", sym, key, testKey)
+	}
+	if _, _, err := SKESK4(7, kek, esk[:17]); !errs.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("SKESK4() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+	if _, _, err := SKESK4(3, kek, esk); err == nil {
+		t.Error("SKESK4() = nil, not want nil.")
+	}
+}
+
+func TestSKESK5(t *testing.T) {
+	kek := mustHex("79DEBF2265EB299851D31485F7E87DB5")
+	iv := mustHex("000102030405060708090A0B0C0D0E")
+	block, _ := aes.NewCipher(kek)
+	aead, _ := newOCB(block)
+	data := aead.Seal(nil, iv, testKey, []byte{0xc3, 0x05, 7, 2})
+
+	key, err := SKESK5(7, 2, iv, kek, data)
+	if err != nil {
+		t.Errorf("SKESK5() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(key, testKey) {
+		t.Errorf("SKESK5() = %X, want %X.", key, testKey)
+	}
+	data[0] ^= 0x01
+	if _, err := SKESK5(7, 2, iv, kek, data); !errs.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("SKESK5() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+	if _, err := SKESK5(7, 2, iv[:12], kek, data); !errs.Is(err, ecode.ErrIntegrity) {
+		t.Errorf("SKESK5() = \"%+v\", want \"%+v\".", err, ecode.ErrIntegrity)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
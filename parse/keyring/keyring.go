@@ -0,0 +1,328 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/armtext"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/s2k"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//SecretKey class is secret key in Secret-Key Packet (tag 5) or Secret-Subkey Packet (tag 7)
+type SecretKey struct {
+	tag         values.TagID
+	version     byte
+	pubID       values.PubID
+	pub         []byte //public key fields (from version number to end of key material)
+	material    []byte //public key material
+	fingerprint []byte
+	usage       byte //s2k usage
+	symid       values.SymID
+	aeadid      values.AEADID
+	s2k         *s2k.S2K
+	iv          []byte
+	data        []byte //secret-key material (encrypted or plain) with checksum
+	mutex       sync.Mutex
+	secret      []byte //plain secret-key material without checksum (nil if locked)
+}
+
+//ReadSecretKeys returns secret keys in transferable secret keys (binary or ASCII armored data).
+//Packets except secret keys and secret keys with unsupported version or algorithm are skipped.
+func ReadSecretKeys(r io.Reader) ([]*SecretKey, error) {
+	if r == nil {
+		return nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var body io.Reader = bytes.NewReader(buf.Bytes())
+	if txt, err := armtext.Get(bytes.NewReader(buf.Bytes())); err == nil {
+		block, err := armor.Decode(txt)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		body = block.Body
+	}
+	keys := []*SecretKey{}
+	op := packet.NewOpaqueReader(body)
+	for {
+		p, err := op.Next()
+		if err != nil {
+			if errs.Is(err, io.EOF) {
+				break
+			}
+			return nil, errs.Wrap(err)
+		}
+		switch values.TagID(p.Tag) {
+		case 5, 7:
+			key, err := ParseSecretKey(values.TagID(p.Tag), p.Contents)
+			if err != nil {
+				if errs.Is(err, ecode.ErrUnsupportedAlg) {
+					continue
+				}
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errs.Wrap(ecode.ErrNoSecretKey)
+	}
+	return keys, nil
+}
+
+//ParseSecretKey returns SecretKey instance from body of Secret-Key (tag 5) or Secret-Subkey (tag 7) Packet.
+func ParseSecretKey(tag values.TagID, body []byte) (*SecretKey, error) {
+	r := reader.New(body)
+	ver, err := r.ReadByte()
+	if err != nil {
+		return nil, errs.New("illegal version", errs.WithCause(err))
+	}
+	if ver != 4 && ver != 5 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("version", ver))
+	}
+	k := &SecretKey{tag: tag, version: ver}
+	if _, err := r.ReadBytes(4); err != nil {
+		return nil, errs.New("illegal creation time", errs.WithCause(err))
+	}
+	pubid, err := r.ReadByte()
+	if err != nil {
+		return nil, errs.New("illegal pubid", errs.WithCause(err))
+	}
+	k.pubID = values.PubID(pubid)
+	if ver == 5 {
+		l, err := r.ReadBytes(4)
+		if err != nil {
+			return nil, errs.New("illegal length of key material", errs.WithCause(err))
+		}
+		if k.material, err = r.ReadBytes(int64(binary.BigEndian.Uint32(l))); err != nil {
+			return nil, errs.New("illegal key material", errs.WithCause(err))
+		}
+	} else {
+		start := r.Size() - r.Rest()
+		if err := skipMaterial(k.pubID, r); err != nil {
+			return nil, err
+		}
+		k.material = body[start : r.Size()-r.Rest()]
+	}
+	k.pub = body[:r.Size()-r.Rest()]
+	k.fingerprint = fingerprint(ver, k.pub)
+
+	if err := k.parseSecret(r); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+//parseSecret parses secret-key fields
+func (k *SecretKey) parseSecret(r *reader.Reader) error {
+	usage, err := r.ReadByte()
+	if err != nil {
+		return errs.New("illegal s2k usage", errs.WithCause(err))
+	}
+	k.usage = usage
+	opt := r
+	if k.version == 5 {
+		l, err := r.ReadByte()
+		if err != nil {
+			return errs.New("illegal length of option field", errs.WithCause(err))
+		}
+		b, err := r.ReadBytes(int64(l))
+		if err != nil {
+			return errs.New("illegal option field", errs.WithCause(err))
+		}
+		opt = reader.New(b)
+	}
+	hasIV := true
+	switch usage {
+	case 0:
+		hasIV = false
+	case 253, 254, 255:
+		alg, err := opt.ReadByte()
+		if err != nil {
+			return errs.New("illegal symid", errs.WithCause(err))
+		}
+		k.symid = values.SymID(alg)
+		if usage == 253 {
+			alg, err := opt.ReadByte()
+			if err != nil {
+				return errs.New("illegal AEAD", errs.WithCause(err))
+			}
+			k.aeadid = values.AEADID(alg)
+		}
+		k.s2k = s2k.New(opt)
+		if err := k.s2k.Parse(result.NewItem(), false); err != nil {
+			return errs.New("illegal s2k", errs.WithCause(err))
+		}
+		hasIV = k.s2k.HasIV()
+	default:
+		//symmetric algorithm without S2K specifier (legacy)
+		k.symid = values.SymID(usage)
+	}
+	if hasIV {
+		l := k.symid.IVLen()
+		if usage == 253 {
+			l = k.aeadid.IVLen()
+		}
+		if k.iv, err = opt.ReadBytes(int64(l)); err != nil {
+			return errs.New("illegal s2k iv", errs.WithCause(err))
+		}
+	}
+	if k.version == 5 {
+		if _, err := r.ReadBytes(4); err != nil {
+			return errs.New("illegal length of key material", errs.WithCause(err))
+		}
+	}
+	if r.Rest() > 0 {
+		k.data = r.GetBody()[r.Size()-r.Rest():]
+	}
+	if usage == 0 {
+		//error of checksum is reported by Unlock method
+		if plain, err := checksum(k.data); err == nil {
+			k.secret = plain
+		}
+	}
+	return nil
+}
+
+//skipMaterial skips public key material of public key algorithm
+func skipMaterial(pubID values.PubID, r *reader.Reader) error {
+	mpis := 0
+	switch true {
+	case pubID.IsRSA():
+		mpis = 2
+	case pubID.IsDSA():
+		mpis = 4
+	case pubID.IsElgamal():
+		mpis = 3
+	case pubID.IsECDH(), pubID.IsECDSA(), pubID.IsEdDSA():
+		if _, err := values.NewOID(r); err != nil {
+			return errs.Wrap(err)
+		}
+		mpis = 1
+	case pubID.IsX25519():
+		_, err := r.ReadBytes(32)
+		return errs.Wrap(err)
+	case pubID.IsX448():
+		_, err := r.ReadBytes(56)
+		return errs.Wrap(err)
+	default:
+		return errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("pubid", pubID.String()))
+	}
+	for i := 0; i < mpis; i++ {
+		if _, err := values.NewMPI(r); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	if pubID.IsECDH() {
+		//KDF parameters
+		if _, err := values.NewECParm(r); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	return nil
+}
+
+//fingerprint returns fingerprint of public key
+func fingerprint(ver byte, pub []byte) []byte {
+	if ver == 5 {
+		h := sha256.New()
+		l := make([]byte, 4)
+		binary.BigEndian.PutUint32(l, uint32(len(pub)))
+		h.Write([]byte{0x9a})
+		h.Write(l)
+		h.Write(pub)
+		return h.Sum(nil)
+	}
+	h := sha1.New()
+	l := make([]byte, 2)
+	binary.BigEndian.PutUint16(l, uint16(len(pub)))
+	h.Write([]byte{0x99})
+	h.Write(l)
+	h.Write(pub)
+	return h.Sum(nil)
+}
+
+//PubID returns public key algorithm ID
+func (k *SecretKey) PubID() values.PubID {
+	if k == nil {
+		return 0
+	}
+	return k.pubID
+}
+
+//Fingerprint returns fingerprint of key
+func (k *SecretKey) Fingerprint() []byte {
+	if k == nil {
+		return nil
+	}
+	return k.fingerprint
+}
+
+//KeyID returns key ID (8 octets) of key
+func (k *SecretKey) KeyID() []byte {
+	if k == nil || len(k.fingerprint) < 8 {
+		return nil
+	}
+	if k.version == 5 {
+		return k.fingerprint[:8]
+	}
+	return k.fingerprint[len(k.fingerprint)-8:]
+}
+
+//Match returns true if id is key ID or fingerprint of key (wildcard key ID (all zero) or empty id of anonymous recipient matches any key).
+func (k *SecretKey) Match(id []byte) bool {
+	if k == nil {
+		return false
+	}
+	if bytes.Equal(id, make([]byte, len(id))) {
+		return true
+	}
+	return bytes.Equal(id, k.KeyID()) || bytes.Equal(id, k.fingerprint)
+}
+
+//GNUExtension returns extension number of GNU S2K (1001: gnu-dummy, 1002: gnu-divert-to-card, 0: not GNU extension)
+func (k *SecretKey) GNUExtension() int {
+	if k == nil {
+		return 0
+	}
+	return k.s2k.GNUExtension()
+}
+
+//IsLocked returns true if secret-key material is encrypted and not unlocked.
+func (k *SecretKey) IsLocked() bool {
+	if k == nil {
+		return true
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.secret == nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,412 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+func readKeys(t *testing.T, path string) []*SecretKey {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open() = \"%+v\", want nil.", err)
+	}
+	defer f.Close()
+	keys, err := ReadSecretKeys(f)
+	if err != nil {
+		t.Fatalf("ReadSecretKeys() = \"%+v\", want nil.", err)
+	}
+	return keys
+}
+
+//pkesk returns version, key ID (version 3) or fingerprint (version 6) and key material of first Public-Key Encrypted Session Key Packet in armored message
+func pkesk(t *testing.T, path string) (byte, []byte, []byte) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open() = \"%+v\", want nil.", err)
+	}
+	defer f.Close()
+	block, err := armor.Decode(f)
+	if err != nil {
+		t.Fatalf("armor.Decode() = \"%+v\", want nil.", err)
+	}
+	op := packet.NewOpaqueReader(block.Body)
+	for {
+		p, err := op.Next()
+		if err != nil {
+			t.Fatalf("OpaqueReader.Next() = \"%+v\", want nil.", err)
+		}
+		if p.Tag == 1 {
+			if p.Contents[0] == 6 {
+				n := int(p.Contents[1])
+				if n == 0 {
+					return 6, []byte{}, p.Contents[3:]
+				}
+				return 6, p.Contents[3 : 2+n], p.Contents[3+n:]
+			}
+			return p.Contents[0], p.Contents[1:9], p.Contents[10:]
+		}
+	}
+}
+
+func TestReadSecretKeys(t *testing.T) {
+	testCases := []struct {
+		path  string
+		ids   []string
+		pubID []values.PubID
+	}{
+		{path: "../../testdata/decrypt/alice-sec.asc", ids: []string{"0xbe831b3cc1aaee9b", "0x567f9ccc90616400"}, pubID: []values.PubID{1, 1}},
+		{path: "../../testdata/decrypt/bob-sec.asc", ids: []string{"0x14f029c280b356b2", "0xa8a0d34351479dcc", "0xaf1204218e3b9eab", "0xa6d66f28651749c0"}, pubID: []values.PubID{22, 18, 18, 16}},
+		{path: "../../testdata/decrypt/x448-sec.asc", ids: []string{"0x2b4eaeb4ec3ef247"}, pubID: []values.PubID{26}},
+	}
+	for _, tc := range testCases {
+		keys := readKeys(t, tc.path)
+		if len(keys) != len(tc.ids) {
+			t.Errorf("ReadSecretKeys() = %d keys, want %d keys.", len(keys), len(tc.ids))
+			continue
+		}
+		for i, k := range keys {
+			if id := values.NewKeyID(k.KeyID()).String(); id != tc.ids[i] {
+				t.Errorf("KeyID() = \"%v\", want \"%v\".", id, tc.ids[i])
+			}
+			if k.PubID() != tc.pubID[i] {
+				t.Errorf("PubID() = \"%v\", want \"%v\".", k.PubID(), tc.pubID[i])
+			}
+			if !k.Match(k.KeyID()) || !k.Match(k.Fingerprint()) || !k.Match(make([]byte, 8)) {
+				t.Errorf("Match() = false, want true.")
+			}
+		}
+	}
+	if _, err := ReadSecretKeys(bytes.NewReader([]byte{0xa8, 0x03, 'P', 'G', 'P'})); !errors.Is(err, ecode.ErrNoSecretKey) {
+		t.Errorf("ReadSecretKeys() = \"%+v\", want \"%+v\".", err, ecode.ErrNoSecretKey)
+	}
+	if _, err := ReadSecretKeys(strings.NewReader("no key")); err == nil {
+		t.Errorf("ReadSecretKeys() = nil, want error.")
+	}
+}
+
+func TestDecryptSessionKey(t *testing.T) {
+	testCases := []struct {
+		keys       string
+		msg        string
+		passphrase []byte
+		key        string
+		err        error
+	}{
+		{keys: "alice-sec.asc", msg: "pk-skesk.asc", passphrase: nil, key: "9:B1DEDE31BECE2B242B3E896528DC22DAFB30A87322FE6DEAC672D859C6CF7F0B", err: nil},
+		{keys: "bob-sec.asc", msg: "pk-cv25519.asc", passphrase: []byte("pass"), key: "9:E13F51171F1140EDF136990B3CB2DE2661D1E5DEE601BC06FD9609B3296CEF92", err: nil},
+		{keys: "bob-sec.asc", msg: "pk-nistp256.asc", passphrase: []byte("pass"), key: "9:148BCDCD36DA133B8B1036D7ED2B65EE6868A947E8D4C097CC66FEBCD757FEA7", err: nil},
+		{keys: "bob-sec.asc", msg: "pk-elg.asc", passphrase: []byte("pass"), key: "9:C797312CE07B54A48B73F52D03B1B16D9F6E77DF1C11B5C27CD97466DA227297", err: nil},
+		{keys: "bob-sec.asc", msg: "pk-elg.asc", passphrase: nil, key: "", err: ecode.ErrNeedPassphrase},
+		{keys: "bob-sec.asc", msg: "pk-elg.asc", passphrase: []byte("wrong"), key: "", err: ecode.ErrPassphrase},
+		{keys: "x448-sec.asc", msg: "pk-x448.asc", passphrase: nil, key: "0:E9973234225E86B835C6B8409B1FE8672230B9979B7D5E577093D52118718AED", err: nil},
+	}
+	for _, tc := range testCases {
+		keys := readKeys(t, "../../testdata/decrypt/"+tc.keys)
+		ver, keyID, data := pkesk(t, "../../testdata/decrypt/"+tc.msg)
+		var key *SecretKey
+		for _, k := range keys {
+			if k.Match(keyID) {
+				key = k
+			}
+		}
+		if key == nil {
+			t.Errorf("Match(%x) = false, want true.", keyID)
+			continue
+		}
+		err := key.Unlock(tc.passphrase)
+		if err == nil {
+			var sym values.SymID
+			var sk []byte
+			sym, sk, err = key.DecryptSessionKey(ver, data)
+			if err == nil {
+				if str := fmt.Sprintf("%d:%X", sym, sk); str != tc.key {
+					t.Errorf("DecryptSessionKey() = \"%v\", want \"%v\".", str, tc.key)
+				}
+			}
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("DecryptSessionKey() = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+	}
+}
+
+func TestStub(t *testing.T) {
+	keys := readKeys(t, "../../testdata/decrypt/alice-sec.asc")
+	testCases := []struct {
+		secret []byte
+		ext    int
+		err    error
+	}{
+		{secret: []byte{0xff, 0x00, 0x65, 0x00, 'G', 'N', 'U', 0x01}, ext: 1001, err: ecode.ErrDummyKey},
+		{secret: []byte{0xff, 0x00, 0x65, 0x00, 'G', 'N', 'U', 0x02, 0x04, 0x01, 0x02, 0x03, 0x04}, ext: 1002, err: ecode.ErrDivertToCard},
+	}
+	for _, tc := range testCases {
+		k, err := ParseSecretKey(7, append(append([]byte{}, keys[1].pub...), tc.secret...))
+		if err != nil {
+			t.Errorf("ParseSecretKey() = \"%+v\", want nil.", err)
+			continue
+		}
+		if k.GNUExtension() != tc.ext {
+			t.Errorf("GNUExtension() = %v, want %v.", k.GNUExtension(), tc.ext)
+		}
+		if err := k.Unlock([]byte("pass")); !errors.Is(err, tc.err) {
+			t.Errorf("Unlock() = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+	}
+}
+
+func TestX25519(t *testing.T) {
+	secret := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := curve25519.X25519(secret, curve25519.Basepoint)
+	sum := uint16(0)
+	for _, b := range secret {
+		sum += uint16(b)
+	}
+	body := append([]byte{0x04, 0x00, 0x00, 0x00, 0x00, 25}, pub...)
+	body = append(body, 0x00)
+	body = append(body, secret...)
+	body = append(body, byte(sum>>8), byte(sum))
+	k, err := ParseSecretKey(7, body)
+	if err != nil {
+		t.Fatalf("ParseSecretKey() = \"%+v\", want nil.", err)
+	}
+	if k.IsLocked() {
+		t.Errorf("IsLocked() = true, want false.")
+	}
+
+	//encrypt session key
+	sessionKey := []byte("0123456789abcdef")
+	ephSecret := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, ephSecret); err != nil {
+		t.Fatal(err)
+	}
+	eph, _ := curve25519.X25519(ephSecret, curve25519.Basepoint)
+	shared, _ := curve25519.X25519(ephSecret, pub)
+	kek := make([]byte, 16)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, concat(eph, pub, shared), nil, []byte("OpenPGP X25519")), kek); err != nil {
+		t.Fatal(err)
+	}
+	wrapped := keyWrap(kek, sessionKey)
+	data := append(append(append([]byte{}, eph...), byte(len(wrapped)+1), 7), wrapped...)
+
+	sym, key, err := k.DecryptSessionKey(3, data)
+	if err != nil {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want nil.", err)
+	} else if sym != 7 || !bytes.Equal(key, sessionKey) {
+		t.Errorf("DecryptSessionKey() = %v:%x, want %v:%x.", sym, key, 7, sessionKey)
+	}
+	data[len(data)-1] ^= 0x01
+	if _, _, err := k.DecryptSessionKey(3, data); !errors.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+
+	//version 6 packet has no symmetric algorithm octet
+	data = append(append(append([]byte{}, eph...), byte(len(wrapped))), wrapped...)
+	sym, key, err = k.DecryptSessionKey(6, data)
+	if err != nil {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want nil.", err)
+	} else if sym != 0 || !bytes.Equal(key, sessionKey) {
+		t.Errorf("DecryptSessionKey() = %v:%x, want %v:%x.", sym, key, 0, sessionKey)
+	}
+	if _, _, err := k.DecryptSessionKey(3, data); !errors.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+	if _, _, err := k.DecryptSessionKey(5, data); !errors.Is(err, ecode.ErrUnsupportedAlg) {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want \"%+v\".", err, ecode.ErrUnsupportedAlg)
+	}
+}
+
+func TestX448(t *testing.T) {
+	//RFC 7748 Section 5.2
+	scalar, _ := hex.DecodeString("3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3")
+	point, _ := hex.DecodeString("06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086")
+	want, _ := hex.DecodeString("ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f")
+	if u, err := x448(scalar, point); err != nil {
+		t.Errorf("x448() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(u, want) {
+		t.Errorf("x448() = %x, want %x.", u, want)
+	}
+	if _, err := x448(scalar, make([]byte, 56)); !errors.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("x448() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+
+	keys := readKeys(t, "../../testdata/decrypt/x448-sec.asc")
+	k := keys[0]
+	if err := k.Verify(); err != nil {
+		t.Errorf("Verify() = \"%+v\", want nil.", err)
+	}
+
+	//encrypt session key for version 3 packet
+	sessionKey := []byte("0123456789abcdef0123456789abcdef")
+	ephSecret := make([]byte, 56)
+	if _, err := io.ReadFull(rand.Reader, ephSecret); err != nil {
+		t.Fatal(err)
+	}
+	eph, _ := x448(ephSecret, x448Basepoint)
+	shared, _ := x448(ephSecret, k.material)
+	kek := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha512.New, concat(eph, k.material, shared), nil, []byte("OpenPGP X448")), kek); err != nil {
+		t.Fatal(err)
+	}
+	wrapped := keyWrap(kek, sessionKey)
+	data := append(append(append([]byte{}, eph...), byte(len(wrapped)+1), 9), wrapped...)
+	sym, key, err := k.DecryptSessionKey(3, data)
+	if err != nil {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want nil.", err)
+	} else if sym != 9 || !bytes.Equal(key, sessionKey) {
+		t.Errorf("DecryptSessionKey() = %v:%x, want %v:%x.", sym, key, 9, sessionKey)
+	}
+	//AES-128 does not match length of session key
+	data[len(eph)+1] = 7
+	if _, _, err := k.DecryptSessionKey(3, data); !errors.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("DecryptSessionKey() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, path := range []string{"alice-sec.asc", "bob-sec.asc"} {
+		for _, k := range readKeys(t, "../../testdata/decrypt/"+path) {
+			if err := k.Unlock([]byte("pass")); err != nil {
+				t.Errorf("Unlock() = \"%+v\", want nil.", err)
+				continue
+			}
+			if err := k.Verify(); err != nil {
+				t.Errorf("Verify(%v) = \"%+v\", want nil.", k.PubID(), err)
+			}
+		}
+	}
+	keys := readKeys(t, "../../testdata/decrypt/alice-sec.asc")
+	if err := keys[1].Verify(); err != nil {
+		t.Errorf("Verify() = \"%+v\", want nil.", err)
+	}
+	//secret key of other key pair
+	keys[1].secret = keys[0].secret
+	if err := keys[1].Verify(); !errors.Is(err, ecode.ErrKeyMismatch) {
+		t.Errorf("Verify() = \"%+v\", want \"%+v\".", err, ecode.ErrKeyMismatch)
+	}
+	keys = readKeys(t, "../../testdata/decrypt/bob-sec.asc")
+	if err := keys[0].Verify(); !errors.Is(err, ecode.ErrNeedPassphrase) {
+		t.Errorf("Verify() = \"%+v\", want \"%+v\".", err, ecode.ErrNeedPassphrase)
+	}
+}
+
+func TestUnlockAEAD(t *testing.T) {
+	secret := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := curve25519.X25519(secret, curve25519.Basepoint)
+	pubFields := append([]byte{0x04, 0x00, 0x00, 0x00, 0x00, 25}, pub...)
+
+	//s2k usage 253, AES-256, GCM, Simple S2K with SHA2-256
+	passphrase := []byte("pass")
+	s2kKey := sha256.Sum256(passphrase)
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, s2kKey[:], nil, []byte{0xc7, 0x04, 9, 3}), key); err != nil {
+		t.Fatal(err)
+	}
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := aead.Seal(nil, nonce, secret, append([]byte{0xc7}, pubFields...))
+	body := append(append([]byte{}, pubFields...), 253, 9, 3, 0x00, 8)
+	body = append(body, nonce...)
+	body = append(body, encrypted...)
+
+	k, err := ParseSecretKey(7, body)
+	if err != nil {
+		t.Fatalf("ParseSecretKey() = \"%+v\", want nil.", err)
+	}
+	if err := k.Unlock([]byte("wrong")); !errors.Is(err, ecode.ErrPassphrase) {
+		t.Errorf("Unlock() = \"%+v\", want \"%+v\".", err, ecode.ErrPassphrase)
+	}
+	if err := k.Unlock(passphrase); err != nil {
+		t.Errorf("Unlock() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(k.Secret(), secret) {
+		t.Errorf("Secret() = %x, want %x.", k.Secret(), secret)
+	}
+	if err := k.Verify(); err != nil {
+		t.Errorf("Verify() = \"%+v\", want nil.", err)
+	}
+}
+
+func TestKeyUnwrap(t *testing.T) {
+	//RFC 3394 Section 4.1
+	kek, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F")
+	key, _ := hex.DecodeString("00112233445566778899AABBCCDDEEFF")
+	wrapped, _ := hex.DecodeString("1FA68B0A8112B447AEF34BD8FB5A7B829D3E862371D2CFE5")
+	if w := keyWrap(kek, key); !bytes.Equal(w, wrapped) {
+		t.Errorf("keyWrap() = %x, want %x.", w, wrapped)
+	}
+	if k, err := keyUnwrap(kek, wrapped); err != nil {
+		t.Errorf("keyUnwrap() = \"%+v\", want nil.", err)
+	} else if !bytes.Equal(k, key) {
+		t.Errorf("keyUnwrap() = %x, want %x.", k, key)
+	}
+	wrapped[0] ^= 0x01
+	if _, err := keyUnwrap(kek, wrapped); !errors.Is(err, ecode.ErrSessionKey) {
+		t.Errorf("keyUnwrap() = \"%+v\", want \"%+v\".", err, ecode.ErrSessionKey)
+	}
+}
+
+//keyWrap returns key wrapped by AES key wrap algorithm (RFC 3394)
+func keyWrap(kek, key []byte) []byte {
+	block, _ := aes.NewCipher(kek)
+	n := len(key) / 8
+	a := []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+	r := append([]byte{}, key...)
+	b := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 1; i <= n; i++ {
+			copy(b, a)
+			copy(b[8:], r[(i-1)*8:i*8])
+			block.Encrypt(b, b)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(b[:8])^uint64(n*j+i))
+			copy(r[(i-1)*8:], b[8:])
+		}
+	}
+	return append(a, r...)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,389 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/openpgp/elgamal"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+var (
+	oidCurve25519 = []byte{0x2B, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01}
+	oidCurves     = []struct {
+		oid   []byte
+		curve elliptic.Curve
+	}{
+		{oid: []byte{0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}, curve: elliptic.P256()},
+		{oid: []byte{0x2b, 0x81, 0x04, 0x00, 0x22}, curve: elliptic.P384()},
+		{oid: []byte{0x2b, 0x81, 0x04, 0x00, 0x23}, curve: elliptic.P521()},
+	}
+	kdfHashes = map[values.HashID]crypto.Hash{
+		8:  crypto.SHA256,
+		9:  crypto.SHA384,
+		10: crypto.SHA512,
+		11: crypto.SHA224,
+	}
+)
+
+//DecryptSessionKey returns symmetric algorithm and session key from key material (data) of Public-Key Encrypted Session Key Packet version ver.
+//Symmetric algorithm is 0 for version 6 packet (it is specified in Sym. Encrypted Integrity Protected Data Packet version 2).
+//Secret key must be unlocked before.
+func (k *SecretKey) DecryptSessionKey(ver byte, data []byte) (values.SymID, []byte, error) {
+	if k == nil {
+		return 0, nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	if ver != 3 && ver != 6 {
+		return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("version", ver))
+	}
+	k.mutex.Lock()
+	secret := k.secret
+	k.mutex.Unlock()
+	if secret == nil {
+		return 0, nil, errs.Wrap(ecode.ErrNeedPassphrase, errs.WithContext("keyid", values.NewKeyID(k.KeyID()).String()))
+	}
+	switch true {
+	case k.pubID.IsRSA():
+		return k.rsa(ver, secret, data)
+	case k.pubID.IsElgamal():
+		return k.elgamal(ver, secret, data)
+	case k.pubID.IsECDH():
+		return k.ecdh(ver, secret, data)
+	case k.pubID.IsX25519():
+		return k.xdh(ver, secret, data, x25519Params)
+	case k.pubID.IsX448():
+		return k.xdh(ver, secret, data, x448Params)
+	}
+	return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("pubid", k.pubID.String()))
+}
+
+func (k *SecretKey) rsa(ver byte, secret, data []byte) (values.SymID, []byte, error) {
+	pub, err := readInts(k.material, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	sec, err := readInts(secret, 3) //d, p, q
+	if err != nil {
+		return 0, nil, err
+	}
+	c, err := readInts(data, 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	priv := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: pub[0], E: int(pub[1].Int64())},
+		D:         sec[0],
+		Primes:    []*big.Int{sec[1], sec[2]},
+	}
+	priv.Precompute()
+	m, err := rsa.DecryptPKCS1v15(nil, priv, leftPad(c[0].Bytes(), (pub[0].BitLen()+7)/8))
+	if err != nil {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	return sessionKey(ver, m)
+}
+
+func (k *SecretKey) elgamal(ver byte, secret, data []byte) (values.SymID, []byte, error) {
+	pub, err := readInts(k.material, 3) //p, g, y
+	if err != nil {
+		return 0, nil, err
+	}
+	sec, err := readInts(secret, 1) //x
+	if err != nil {
+		return 0, nil, err
+	}
+	c, err := readInts(data, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	priv := &elgamal.PrivateKey{
+		PublicKey: elgamal.PublicKey{P: pub[0], G: pub[1], Y: pub[2]},
+		X:         sec[0],
+	}
+	m, err := elgamal.Decrypt(priv, c[0], c[1])
+	if err != nil {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	return sessionKey(ver, m)
+}
+
+func (k *SecretKey) ecdh(ver byte, secret, data []byte) (values.SymID, []byte, error) {
+	pr := reader.New(k.material)
+	oid, err := values.NewOID(pr)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	if _, err := values.NewMPI(pr); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	kdf, err := values.NewECParm(pr)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	if len(kdf) < 3 || kdf[0] != 0x01 {
+		return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("kdf", "unknown parameters"))
+	}
+	sec, err := values.NewMPI(reader.New(secret))
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	dr := reader.New(data)
+	eph, err := values.NewMPI(dr)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	wrapped, err := values.NewECParm(dr)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+
+	//shared secret
+	var z []byte
+	if bytes.Equal(oid, oidCurve25519) {
+		p := eph.Rawdata()
+		if len(p) != 33 || p[0] != 0x40 {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("ephemeral_key", "invalid point"))
+		}
+		//secret key is stored in reverse order of native format
+		scalar := reverse(leftPad(sec.Rawdata(), 32))
+		if z, err = curve25519.X25519(scalar, p[1:]); err != nil {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+		}
+	} else {
+		var curve elliptic.Curve
+		for _, c := range oidCurves {
+			if bytes.Equal(oid, c.oid) {
+				curve = c.curve
+			}
+		}
+		if curve == nil {
+			return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("curve", oid.String()))
+		}
+		x, y := elliptic.Unmarshal(curve, eph.Rawdata())
+		if x == nil {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("ephemeral_key", "invalid point"))
+		}
+		sx, _ := curve.ScalarMult(x, y, sec.Rawdata())
+		z = leftPad(sx.Bytes(), (curve.Params().BitSize+7)/8)
+	}
+
+	//KDF
+	h, ok := kdfHashes[values.HashID(kdf[1])]
+	if !ok || !h.Available() {
+		return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("hashid", values.HashID(kdf[1]).String()))
+	}
+	kekSym := values.SymID(kdf[2])
+	l := decrypt.KeyLen(kekSym)
+	if l == 0 || l > h.Size() {
+		return 0, nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", kekSym.String()))
+	}
+	param := &bytes.Buffer{}
+	param.WriteByte(byte(len(oid)))
+	param.Write(oid)
+	param.WriteByte(byte(k.pubID))
+	param.WriteByte(byte(len(kdf)))
+	param.Write(kdf)
+	param.WriteString("Anonymous Sender    ")
+	param.Write(k.fingerprint)
+	hh := h.New()
+	hh.Write([]byte{0x00, 0x00, 0x00, 0x01})
+	hh.Write(z)
+	hh.Write(param.Bytes())
+	kek := hh.Sum(nil)[:l]
+
+	m, err := keyUnwrap(kek, wrapped)
+	if err != nil {
+		return 0, nil, err
+	}
+	//remove PKCS#5 padding
+	if n := int(m[len(m)-1]); n > 0 && n <= 8 && n < len(m) {
+		m = m[:len(m)-n]
+	} else {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("padding", n))
+	}
+	return sessionKey(ver, m)
+}
+
+//xdhParams class is parameters of X25519 or X448 for decrypting session key (RFC 9580 Section 5.1.6 and 5.1.7)
+type xdhParams struct {
+	name string //name of algorithm (info of HKDF is "OpenPGP "+name)
+	size int    //length of keys
+	dh   func(scalar, point []byte) ([]byte, error)
+	hash func() hash.Hash //hash of HKDF
+	kek  int              //length of key for AES key wrap
+}
+
+var (
+	x25519Params = &xdhParams{name: "X25519", size: 32, dh: curve25519.X25519, hash: sha256.New, kek: 16}
+	x448Params   = &xdhParams{name: "X448", size: 56, dh: x448, hash: sha512.New, kek: 32}
+)
+
+func (k *SecretKey) xdh(ver byte, secret, data []byte, p *xdhParams) (values.SymID, []byte, error) {
+	if len(k.material) != p.size || len(secret) != p.size {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("key_length", len(secret)))
+	}
+	dr := reader.New(data)
+	eph, err := dr.ReadBytes(int64(p.size))
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	wrapped, err := values.NewECParm(dr)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	//cleartext symmetric algorithm octet of version 3 packet precedes wrapped key
+	var sym values.SymID
+	if ver == 3 {
+		if len(wrapped) == 0 {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("wrapped_key_length", len(wrapped)))
+		}
+		sym = values.SymID(wrapped[0])
+		wrapped = wrapped[1:]
+	}
+	shared, err := p.dh(secret, eph)
+	if err != nil {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	kek := make([]byte, p.kek)
+	if _, err := io.ReadFull(hkdf.New(p.hash, concat(eph, k.material, shared), nil, []byte("OpenPGP "+p.name)), kek); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	key, err := keyUnwrap(kek, wrapped)
+	if err != nil {
+		return 0, nil, err
+	}
+	if sym != 0 && decrypt.KeyLen(sym) != len(key) {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("symid", sym.String()), errs.WithContext("key_length", len(key)))
+	}
+	return sym, key, nil
+}
+
+//sessionKey returns symmetric algorithm and session key from decrypted value (algorithm octet (version 3 only), session key and 2-octet checksum)
+func sessionKey(ver byte, m []byte) (values.SymID, []byte, error) {
+	if ver == 6 {
+		key, err := checksum(m)
+		if err != nil {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+		}
+		if len(key) == 0 {
+			return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("key_length", len(key)))
+		}
+		return 0, key, nil
+	}
+	if len(m) < 3 {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("length", len(m)))
+	}
+	sym := values.SymID(m[0])
+	key, err := checksum(m[1:])
+	if err != nil {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	if decrypt.KeyLen(sym) != len(key) {
+		return 0, nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("symid", sym.String()), errs.WithContext("key_length", len(key)))
+	}
+	return sym, key, nil
+}
+
+//readInts returns n multi-precision integers in data
+func readInts(data []byte, n int) ([]*big.Int, error) {
+	r := reader.New(data)
+	ints := make([]*big.Int, 0, n)
+	for i := 0; i < n; i++ {
+		mpi, err := values.NewMPI(r)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		ints = append(ints, new(big.Int).SetBytes(mpi.Rawdata()))
+	}
+	return ints, nil
+}
+
+//leftPad returns octets padded with zero to size
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	p := make([]byte, size)
+	copy(p[size-len(b):], b)
+	return p
+}
+
+//reverse returns octets in reverse order
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+//concat returns concatenated octets
+func concat(bs ...[]byte) []byte {
+	var b []byte
+	for _, s := range bs {
+		b = append(b, s...)
+	}
+	return b
+}
+
+//keyUnwrap returns key unwrapped by AES key wrap algorithm (RFC 3394)
+func keyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped)%8 != 0 || len(wrapped) < 24 {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("wrapped_key_length", len(wrapped)))
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithCause(err))
+	}
+	n := len(wrapped)/8 - 1
+	a := make([]byte, 8)
+	copy(a, wrapped[:8])
+	r := make([]byte, n*8)
+	copy(r, wrapped[8:])
+	b := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(b, binary.BigEndian.Uint64(a)^t)
+			copy(b[8:], r[(i-1)*8:i*8])
+			block.Decrypt(b, b)
+			copy(a, b[:8])
+			copy(r[(i-1)*8:], b[8:])
+		}
+	}
+	if !bytes.Equal(a, []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}) {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("key_wrap", "integrity check failed"))
+	}
+	return r, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
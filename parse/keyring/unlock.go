@@ -0,0 +1,121 @@
+package keyring
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//Unlock decrypts secret-key material by passphrase, and checks its integrity (checksum, SHA-1 hash or AEAD authentication tag).
+//Unlock does nothing if secret-key material is already available.
+func (k *SecretKey) Unlock(passphrase []byte) error {
+	if k == nil {
+		return errs.Wrap(ecode.ErrNullPointer)
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if k.secret != nil {
+		return nil
+	}
+	switch k.s2k.GNUExtension() {
+	case 1001:
+		return errs.Wrap(ecode.ErrDummyKey, errs.WithContext("keyid", values.NewKeyID(k.KeyID()).String()))
+	case 1002:
+		return errs.Wrap(ecode.ErrDivertToCard, errs.WithContext("keyid", values.NewKeyID(k.KeyID()).String()))
+	}
+	switch k.usage {
+	case 0:
+		plain, err := checksum(k.data)
+		if err != nil {
+			return err
+		}
+		k.secret = plain
+		return nil
+	case 253, 254, 255:
+	default:
+		return errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("s2k_usage", k.usage))
+	}
+	if passphrase == nil {
+		return errs.Wrap(ecode.ErrNeedPassphrase, errs.WithContext("keyid", values.NewKeyID(k.KeyID()).String()))
+	}
+	l := decrypt.KeyLen(k.symid)
+	if l == 0 {
+		return errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", k.symid.String()))
+	}
+	key, err := k.s2k.Key(passphrase, l)
+	if err != nil {
+		return err
+	}
+	switch k.usage {
+	case 253:
+		plain, err := decrypt.SecretKeyAEAD(k.symid, k.aeadid, k.tag, k.version, key, k.iv, k.pub, k.data)
+		if err != nil {
+			if errs.Is(err, ecode.ErrIntegrity) {
+				return errs.Wrap(ecode.ErrPassphrase, errs.WithCause(err))
+			}
+			return err
+		}
+		k.secret = plain
+	case 254:
+		plain, err := decrypt.SecretKeyCFB(k.symid, key, k.iv, k.data)
+		if err != nil {
+			return err
+		}
+		if len(plain) < sha1.Size {
+			return errs.Wrap(ecode.ErrPassphrase, errs.WithContext("sha1", "not found"))
+		}
+		body := plain[:len(plain)-sha1.Size]
+		sum := sha1.Sum(body)
+		if subtle.ConstantTimeCompare(sum[:], plain[len(body):]) != 1 {
+			return errs.Wrap(ecode.ErrPassphrase, errs.WithContext("sha1", "not matched"))
+		}
+		k.secret = body
+	default:
+		plain, err := decrypt.SecretKeyCFB(k.symid, key, k.iv, k.data)
+		if err != nil {
+			return err
+		}
+		body, err := checksum(plain)
+		if err != nil {
+			return errs.Wrap(ecode.ErrPassphrase, errs.WithCause(err))
+		}
+		k.secret = body
+	}
+	return nil
+}
+
+//checksum returns data without 2-octet checksum (sum of all octets mod 65536) if checksum is matched
+func checksum(data []byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("checksum", "not found"))
+	}
+	body := data[:len(data)-2]
+	sum := uint16(0)
+	for _, b := range body {
+		sum += uint16(b)
+	}
+	if sum != binary.BigEndian.Uint16(data[len(body):]) {
+		return nil, errs.Wrap(ecode.ErrIntegrity, errs.WithContext("checksum", "not matched"))
+	}
+	return body, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
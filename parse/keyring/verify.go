@@ -0,0 +1,174 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"math/big"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+var oidEd25519 = []byte{0x2B, 0x06, 0x01, 0x04, 0x01, 0xDA, 0x47, 0x0F, 0x01}
+
+//Secret returns plain secret-key material without checksum (nil if locked)
+func (k *SecretKey) Secret() []byte {
+	if k == nil {
+		return nil
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.secret
+}
+
+//Verify checks that secret-key material matches public key material (returns ecode.ErrKeyMismatch if not matched).
+//Secret key must be unlocked before.
+func (k *SecretKey) Verify() error {
+	if k == nil {
+		return errs.Wrap(ecode.ErrNullPointer)
+	}
+	secret := k.Secret()
+	if secret == nil {
+		return errs.Wrap(ecode.ErrNeedPassphrase, errs.WithContext("keyid", values.NewKeyID(k.KeyID()).String()))
+	}
+	switch true {
+	case k.pubID.IsRSA():
+		return k.verifyRSA(secret)
+	case k.pubID.IsDSA():
+		return k.verifyDLog(secret, 4) //p, q, g, y
+	case k.pubID.IsElgamal():
+		return k.verifyDLog(secret, 3) //p, g, y
+	case k.pubID.IsECDH(), k.pubID.IsECDSA(), k.pubID.IsEdDSA():
+		return k.verifyEC(secret)
+	case k.pubID.IsX25519():
+		pub, err := curve25519.X25519(secret, curve25519.Basepoint)
+		if err != nil {
+			return errs.Wrap(ecode.ErrKeyMismatch, errs.WithCause(err))
+		}
+		return mismatch(bytes.Equal(pub, k.material))
+	case k.pubID.IsX448():
+		pub, err := x448(secret, x448Basepoint)
+		if err != nil {
+			return errs.Wrap(ecode.ErrKeyMismatch, errs.WithCause(err))
+		}
+		return mismatch(bytes.Equal(pub, k.material))
+	}
+	return errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("pubid", k.pubID.String()))
+}
+
+func (k *SecretKey) verifyRSA(secret []byte) error {
+	pub, err := readInts(k.material, 2) //n, e
+	if err != nil {
+		return err
+	}
+	sec, err := readInts(secret, 4) //d, p, q, u
+	if err != nil {
+		return err
+	}
+	n, e := pub[0], pub[1]
+	d, p, q, u := sec[0], sec[1], sec[2], sec[3]
+	if n.Sign() <= 0 || p.Sign() <= 0 || q.Sign() <= 0 {
+		return errs.Wrap(ecode.ErrKeyMismatch)
+	}
+	if new(big.Int).Mul(p, q).Cmp(n) != 0 {
+		return errs.Wrap(ecode.ErrKeyMismatch, errs.WithContext("rsa", "n != p * q"))
+	}
+	if new(big.Int).Mod(new(big.Int).Mul(u, p), q).Cmp(big.NewInt(1)) != 0 {
+		return errs.Wrap(ecode.ErrKeyMismatch, errs.WithContext("rsa", "u * p != 1 (mod q)"))
+	}
+	m := big.NewInt(2)
+	c := new(big.Int).Exp(m, e, n)
+	return mismatch(new(big.Int).Exp(c, d, n).Cmp(m) == 0)
+}
+
+//verifyDLog checks y = g^x mod p (g and y are last two of n public integers)
+func (k *SecretKey) verifyDLog(secret []byte, n int) error {
+	pub, err := readInts(k.material, n)
+	if err != nil {
+		return err
+	}
+	sec, err := readInts(secret, 1) //x
+	if err != nil {
+		return err
+	}
+	p, g, y := pub[0], pub[n-2], pub[n-1]
+	if p.Sign() <= 0 {
+		return errs.Wrap(ecode.ErrKeyMismatch)
+	}
+	return mismatch(new(big.Int).Exp(g, sec[0], p).Cmp(y) == 0)
+}
+
+func (k *SecretKey) verifyEC(secret []byte) error {
+	pr := reader.New(k.material)
+	oid, err := values.NewOID(pr)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	point, err := values.NewMPI(pr)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	sec, err := values.NewMPI(reader.New(secret))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	p := point.Rawdata()
+	switch true {
+	case k.pubID.IsECDH() && bytes.Equal(oid, oidCurve25519):
+		if len(p) != 33 || p[0] != 0x40 {
+			return errs.Wrap(ecode.ErrKeyMismatch, errs.WithContext("public_key", "invalid point"))
+		}
+		//secret key is stored in reverse order of native format
+		pub, err := curve25519.X25519(reverse(leftPad(sec.Rawdata(), 32)), curve25519.Basepoint)
+		if err != nil {
+			return errs.Wrap(ecode.ErrKeyMismatch, errs.WithCause(err))
+		}
+		return mismatch(bytes.Equal(pub, p[1:]))
+	case k.pubID.IsEdDSA() && bytes.Equal(oid, oidEd25519):
+		if len(p) != 33 || p[0] != 0x40 || len(sec.Rawdata()) > ed25519.SeedSize {
+			return errs.Wrap(ecode.ErrKeyMismatch, errs.WithContext("public_key", "invalid point"))
+		}
+		pub := ed25519.NewKeyFromSeed(leftPad(sec.Rawdata(), ed25519.SeedSize)).Public().(ed25519.PublicKey)
+		return mismatch(bytes.Equal(pub, p[1:]))
+	case !k.pubID.IsEdDSA():
+		for _, c := range oidCurves {
+			if bytes.Equal(oid, c.oid) {
+				x, y := elliptic.Unmarshal(c.curve, p)
+				if x == nil {
+					return errs.Wrap(ecode.ErrKeyMismatch, errs.WithContext("public_key", "invalid point"))
+				}
+				sx, sy := c.curve.ScalarBaseMult(sec.Rawdata())
+				return mismatch(sx.Cmp(x) == 0 && sy.Cmp(y) == 0)
+			}
+		}
+	}
+	return errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("curve", oid.String()))
+}
+
+//mismatch returns ecode.ErrKeyMismatch if not ok
+func mismatch(ok bool) error {
+	if ok {
+		return nil
+	}
+	return errs.Wrap(ecode.ErrKeyMismatch)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
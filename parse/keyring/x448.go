@@ -0,0 +1,81 @@
+package keyring
+
+import (
+	"math/big"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+var (
+	x448P   = new(big.Int).Sub(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 448), new(big.Int).Lsh(big.NewInt(1), 224)), big.NewInt(1))
+	x448A24 = big.NewInt(39081)
+	//x448Basepoint is u-coordinate of base point of Curve448
+	x448Basepoint = append([]byte{5}, make([]byte, 55)...)
+)
+
+//x448 returns X448 function of scalar and u-coordinate (RFC 7748 Section 5).
+//It is not constant time, and so is used only for dumping.
+func x448(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != 56 || len(point) != 56 {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("x448_length", len(scalar)))
+	}
+	k := reverse(scalar)
+	k[55] &= 252
+	k[0] |= 128
+	kn := new(big.Int).SetBytes(k)
+	x1 := new(big.Int).SetBytes(reverse(point))
+	x1.Mod(x1, x448P)
+	x2, z2 := big.NewInt(1), big.NewInt(0)
+	x3, z3 := new(big.Int).Set(x1), big.NewInt(1)
+	swap := uint(0)
+	for t := 447; t >= 0; t-- {
+		kt := kn.Bit(t)
+		if swap^kt == 1 {
+			x2, x3 = x3, x2
+			z2, z3 = z3, z2
+		}
+		swap = kt
+		a := mod(new(big.Int).Add(x2, z2))
+		aa := mod(new(big.Int).Mul(a, a))
+		b := mod(new(big.Int).Sub(x2, z2))
+		bb := mod(new(big.Int).Mul(b, b))
+		e := mod(new(big.Int).Sub(aa, bb))
+		c := mod(new(big.Int).Add(x3, z3))
+		d := mod(new(big.Int).Sub(x3, z3))
+		da := mod(new(big.Int).Mul(d, a))
+		cb := mod(new(big.Int).Mul(c, b))
+		x3 = mod(new(big.Int).Exp(new(big.Int).Add(da, cb), big.NewInt(2), x448P))
+		z3 = mod(new(big.Int).Mul(x1, new(big.Int).Exp(new(big.Int).Sub(da, cb), big.NewInt(2), x448P)))
+		x2 = mod(new(big.Int).Mul(aa, bb))
+		z2 = mod(new(big.Int).Mul(e, new(big.Int).Add(aa, new(big.Int).Mul(x448A24, e))))
+	}
+	if swap == 1 {
+		x2, z2 = x3, z3
+	}
+	u := mod(new(big.Int).Mul(x2, new(big.Int).Exp(z2, new(big.Int).Sub(x448P, big.NewInt(2)), x448P)))
+	if u.Sign() == 0 {
+		return nil, errs.Wrap(ecode.ErrSessionKey, errs.WithContext("x448", "low order point"))
+	}
+	return reverse(leftPad(u.Bytes(), 56)), nil
+}
+
+//mod returns n mod p of Curve448
+func mod(n *big.Int) *big.Int {
+	return n.Mod(n, x448P)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,6 +1,7 @@
 package parse
 
 import (
+	"context"
 	"io"
 
 	"github.com/spiegel-im-spiegel/errs"
@@ -12,6 +13,16 @@ func (p *Parser) Parse() (*result.Info, error) {
 	if p == nil {
 		return result.New(), nil
 	}
+	return p.ParseContext(p.cxt.Context())
+}
+
+//ParseContext returns packet result.
+//Parsing is canceled if ctx is canceled or deadline exceeded.
+func (p *Parser) ParseContext(ctx context.Context) (*result.Info, error) {
+	if p == nil {
+		return result.New(), nil
+	}
+	p.cxt.WithContext(ctx)
 	for {
 		if err := p.pct.Next(); err != nil {
 			if !errs.Is(err, io.EOF) { //EOF is not error
@@ -0,0 +1,88 @@
+package parse
+
+import (
+	stdcontext "context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+)
+
+var (
+	//Symmetric-Key Encrypted Session Key Packet + Symmetrically Encrypted Data Packet
+	symEncData   = []byte{0xc3, 0x04, 0x04, 0x03, 0x00, 0x01, 0xc9, 0x38, 0xe7, 0x2d, 0x2f, 0xb1, 0xf1, 0x0f, 0xc3, 0xce, 0x55, 0x5d, 0xb2, 0x8a, 0x4b, 0xe8, 0x4f, 0x43, 0x15, 0x6e, 0x7d, 0x90, 0x90, 0x53, 0x6a, 0x9a, 0xe3, 0xaa, 0x1c, 0x68, 0xd6, 0xd3, 0xfc, 0x6a, 0x4e, 0x79, 0xa8, 0xe7, 0xb1, 0xa5, 0x87, 0xea, 0xcc, 0xcc, 0x99, 0x66, 0x31, 0xad, 0xff, 0xe1, 0xa3, 0x03, 0xb6, 0x47, 0x85, 0x76, 0xbd, 0x0b}
+	symEncResult = `Symmetric-Key Encrypted Session Key Packet (tag 3) (4 bytes)
+	Version: 4 (current)
+	Symmetric Algorithm: CAST5 (128 bit key, as per) (sym 3)
+	String-to-Key (S2K) Algorithm: Simple S2K (s2k 0)
+		Hash Algorithm: MD5 (hash 1)
+Symmetrically Encrypted Data Packet (tag 9) (56 bytes)
+	Encrypted data: sym alg is specified in sym-key encrypted session key (56 bytes)
+`
+)
+
+func TestParseShared(t *testing.T) {
+	cxt := context.New(context.Set(context.UTC, true))
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := NewBytes(cxt, symEncData)
+			if err != nil {
+				t.Errorf("NewBytes() = \"%+v\", want nil error.", err)
+				return
+			}
+			info, err := p.Parse()
+			if err != nil {
+				t.Errorf("Parse() = \"%+v\", want nil error.", err)
+				return
+			}
+			if str := info.String(); str != symEncResult {
+				t.Errorf("Parse() = \"%v\", want \"%v\".", str, symEncResult)
+			}
+		}()
+	}
+	wg.Wait()
+	if cxt.AlgMode() != context.ModeNotSpecified {
+		t.Errorf("Context.Mode = %v, want \"%v\".", cxt.AlgMode(), context.ModeNotSpecified)
+	}
+}
+
+func TestParseCanceled(t *testing.T) {
+	ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+	cancel()
+	p, err := NewBytesWithContext(ctx, context.New(), symEncData)
+	if err != nil {
+		t.Errorf("NewBytesWithContext() = \"%+v\", want nil error.", err)
+		return
+	}
+	if _, err := p.Parse(); !errors.Is(err, stdcontext.Canceled) {
+		t.Errorf("Parse() = \"%+v\", want \"%+v\".", err, stdcontext.Canceled)
+	}
+
+	p, err = NewBytes(context.New(), symEncData)
+	if err != nil {
+		t.Errorf("NewBytes() = \"%+v\", want nil error.", err)
+		return
+	}
+	if _, err := p.ParseContext(ctx); !errors.Is(err, stdcontext.Canceled) {
+		t.Errorf("ParseContext() = \"%+v\", want \"%+v\".", err, stdcontext.Canceled)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
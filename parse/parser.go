@@ -2,6 +2,7 @@ package parse
 
 import (
 	"bytes"
+	stdcontext "context"
 	"io"
 
 	"golang.org/x/crypto/openpgp/armor"
@@ -10,29 +11,40 @@ import (
 	"github.com/spiegel-im-spiegel/gpgpdump/armtext"
 	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/tags"
 )
 
 //Parser class for pasing packet
 type Parser struct {
+	cxt  *context.Context
 	pct  *tags.Packets
 	info *result.Info
 }
 
-//New returns Parser instance
+//New returns Parser instance.
+//Parser uses a fork of cxt, so cxt can be shared with other parsers.
 func New(cxt *context.Context, reader io.Reader) (*Parser, error) {
-	if reader == nil {
+	return NewWithContext(cxt.Context(), cxt, reader)
+}
+
+//NewWithContext returns Parser instance with context.Context.
+//Parsing is canceled if ctx is canceled or deadline exceeded.
+func NewWithContext(ctx stdcontext.Context, cxt *context.Context, rd io.Reader) (*Parser, error) {
+	if rd == nil {
 		return nil, errs.Wrap(ecode.ErrNullPointer)
 	}
+	cxt = cxt.Fork().WithContext(ctx)
+	cr := reader.NewCancelReader(cxt.Context(), rd)
 	var r io.Reader
 	var err error
 	switch {
 	case cxt.Armor():
-		r, err = newReaderArmor(reader)
+		r, err = newReaderArmor(cr)
 	default:
 		buf := &bytes.Buffer{}
-		r, err = newReaderArmor(io.TeeReader(reader, buf))
+		r, err = newReaderArmor(io.TeeReader(cr, buf))
 		if err != nil {
 			r, err = buf, nil
 		}
@@ -48,12 +60,17 @@ func NewBytes(cxt *context.Context, data []byte) (*Parser, error) {
 	return New(cxt, bytes.NewReader(data))
 }
 
+//NewBytesWithContext returns Parser instance with context.Context
+func NewBytesWithContext(ctx stdcontext.Context, cxt *context.Context, data []byte) (*Parser, error) {
+	return NewWithContext(ctx, cxt, bytes.NewReader(data))
+}
+
 func newParser(cxt *context.Context, reader io.Reader, info *result.Info) (*Parser, error) {
 	p, err := tags.NewPackets(cxt, reader)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
-	return &Parser{pct: p, info: info}, nil
+	return &Parser{cxt: cxt, pct: p, info: info}, nil
 }
 
 func newReaderArmor(r io.Reader) (io.Reader, error) {
@@ -10,6 +10,9 @@ import (
 
 //ParsePub multi-precision integers of Public-key packet
 func (p *Pubkey) ParsePub(parent *result.Item) error {
+	if f := p.decoders().Pub; f != nil {
+		return errs.Wrap(f(p.cxt, p.reader, parent))
+	}
 	switch true {
 	case p.pubID.IsRSA():
 		return errs.Wrap(p.rsaPub(parent))
@@ -23,6 +26,10 @@ func (p *Pubkey) ParsePub(parent *result.Item) error {
 		return errs.Wrap(p.ecdsaPub(parent))
 	case p.pubID.IsEdDSA():
 		return errs.Wrap(p.eddsaPub(parent))
+	case p.pubID.IsX25519():
+		return errs.Wrap(p.octets(parent, "X25519 public key", 32))
+	case p.pubID.IsX448():
+		return errs.Wrap(p.octets(parent, "X448 public key", 56))
 	default:
 		parent.Add(result.NewItem(
 			result.Name(fmt.Sprintf("Multi-precision integers of Unknown (pub %d)", p.pubID)),
@@ -173,6 +180,20 @@ func (p *Pubkey) eddsaPub(item *result.Item) error {
 	return nil
 }
 
+//octets parses fixed-length octet string in native format of the curve (X25519 and X448)
+func (p *Pubkey) octets(item *result.Item, name string, size int64) error {
+	b, err := p.reader.ReadBytes(size)
+	if err != nil {
+		return errs.New(fmt.Sprintf("illegal %s (length: %d bytes)", name, size), errs.WithCause(err))
+	}
+	item.Add(result.NewItem(
+		result.Name(name),
+		result.Note(fmt.Sprintf("%d bytes", size)),
+		result.DumpStr(values.DumpBytes(b, p.cxt.Integer()).String()),
+	))
+	return nil
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
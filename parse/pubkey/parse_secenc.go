@@ -11,53 +11,67 @@ import (
 
 //ParseSecEnc multi-precision integers of public key algorithm for Secret-Key Packet (encrypted)
 func (p *Pubkey) ParseSecEnc(parent *result.Item) error {
+	var item *result.Item
 	switch true {
 	case p.pubID.IsRSA():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("RSA encrypted key (d, p, q, u)"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	case p.pubID.IsDSA():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("DSA encrypted key"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	case p.pubID.IsElgamal():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("Elgamal encrypted key"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	case p.pubID.IsECDH():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("ECDH encrypted key"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	case p.pubID.IsECDSA():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("ECDSA encrypted key"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	case p.pubID.IsEdDSA():
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name("EdDSA encrypted key"),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
+	case p.pubID.IsX25519():
+		item = result.NewItem(
+			result.Name("X25519 encrypted key"),
+			result.Note(fmt.Sprintf("%d bytes", p.size)),
+			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
+		)
+	case p.pubID.IsX448():
+		item = result.NewItem(
+			result.Name("X448 encrypted key"),
+			result.Note(fmt.Sprintf("%d bytes", p.size)),
+			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
+		)
 	default:
-		parent.Add(result.NewItem(
+		item = result.NewItem(
 			result.Name(fmt.Sprintf("Multi-precision integers of unknown encrypted key (pub %d)", p.pubID)),
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
-		))
+		)
 	}
 	if _, err := p.reader.Seek(0, io.SeekEnd); err != nil { //skip to EOF
 		return errs.Wrap(err)
 	}
+	parent.Add(item)
 	return nil
 }
 
@@ -10,6 +10,9 @@ import (
 
 //ParseSecPlain multi-precision integers of public key algorithm for Secret-Key Packet (plain)
 func (p *Pubkey) ParseSecPlain(parent *result.Item) error {
+	if f := p.decoders().SecPlain; f != nil {
+		return errs.Wrap(f(p.cxt, p.reader, parent))
+	}
 	switch true {
 	case p.pubID.IsRSA():
 		if err := p.rsaSec(parent); err != nil {
@@ -35,6 +38,14 @@ func (p *Pubkey) ParseSecPlain(parent *result.Item) error {
 		if err := p.eddsaSec(parent); err != nil {
 			return errs.Wrap(err)
 		}
+	case p.pubID.IsX25519():
+		if err := p.octets(parent, "X25519 secret key", 32); err != nil {
+			return errs.Wrap(err)
+		}
+	case p.pubID.IsX448():
+		if err := p.octets(parent, "X448 secret key", 56); err != nil {
+			return errs.Wrap(err)
+		}
 	default:
 		length := p.size - 2 //last 2-octet is checksum value
 		b, err := p.reader.ReadBytes(length)
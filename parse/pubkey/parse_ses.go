@@ -8,8 +8,11 @@ import (
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
 )
 
-//ParseSes multi-precision integers of public key algorithm for Public-Key Encrypted Session Key Packet
-func (p *Pubkey) ParseSes(parent *result.Item) error {
+//ParseSes multi-precision integers of public key algorithm for Public-Key Encrypted Session Key Packet (ver is version of packet)
+func (p *Pubkey) ParseSes(parent *result.Item, ver byte) error {
+	if f := p.decoders().Ses; f != nil {
+		return errs.Wrap(f(p.cxt, p.reader, parent))
+	}
 	switch true {
 	case p.pubID.IsRSA():
 		return errs.Wrap(p.rsaSes(parent))
@@ -35,6 +38,10 @@ func (p *Pubkey) ParseSes(parent *result.Item) error {
 			result.Note(fmt.Sprintf("%d bytes", p.size)),
 			result.DumpStr(values.Dump(p.reader, p.cxt.Debug()).String()),
 		))
+	case p.pubID.IsX25519():
+		return errs.Wrap(p.xdhSes(parent, ver, "X25519", 32))
+	case p.pubID.IsX448():
+		return errs.Wrap(p.xdhSes(parent, ver, "X448", 56))
 	default:
 		parent.Add(result.NewItem(
 			result.Name(fmt.Sprintf("Multi-precision integers of Unknown (pub %d)", p.pubID)),
@@ -87,6 +94,23 @@ func (p *Pubkey) ecdhSes(item *result.Item) error {
 	return nil
 }
 
+func (p *Pubkey) xdhSes(item *result.Item, ver byte, alg string, size int64) error {
+	if err := p.octets(item, alg+" ephemeral public key", size); err != nil {
+		return errs.Wrap(err)
+	}
+	ep, err := values.NewECParm(p.reader)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	i := ep.ToItem("wrapped session key", p.cxt.Integer())
+	if ver == 3 && len(ep) > 0 {
+		//cleartext symmetric algorithm octet of version 3 packet precedes wrapped key
+		i.Add(values.SymID(ep[0]).ToItem(p.cxt.Debug()))
+	}
+	item.Add(i)
+	return nil
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -19,6 +19,9 @@ var (
 	pubkeySes18b = []byte{0x12, 0x02, 0x03, 0x04}
 	pubkeySes18c = []byte{0x12, 0x02, 0x03, 0x04, 0xc3, 0xe7, 0xd7, 0x2b, 0xaf, 0x25, 0x2a, 0x19, 0xf6, 0x27, 0x80, 0xea, 0x7c, 0x4f, 0x6d, 0xca, 0x61, 0x22, 0x5a, 0xe3, 0xad, 0x0c, 0xfb, 0xd9, 0xa2, 0xd5, 0xa4, 0x30, 0x9a, 0xf3, 0xee, 0x34, 0x54, 0xae, 0xa8, 0xf6, 0x46, 0xac, 0x8a, 0xae, 0x38, 0xa6, 0x4f, 0xf3, 0xf2, 0xee, 0x30, 0x40, 0x62, 0x5b, 0x07, 0xe7, 0x2b, 0xee, 0x9a, 0x90, 0xd4, 0x6f, 0x1e, 0xd7, 0xc3, 0x26, 0x21, 0xab, 0x30, 0x4a, 0xfe, 0x88, 0xa2, 0x9f, 0x0e, 0xab, 0xf3, 0xbe}
 	pubkeySes19  = []byte{19, 0x02, 0x03, 0x04}
+	pubkeySes25  = []byte{25, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20, 0x19, 0x09, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e, 0x2f, 0x30, 0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37, 0x38}
+	pubkeySes25b = []byte{25, 0x01, 0x02, 0x03, 0x04}
+	pubkeySes25c = []byte{25, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20, 0x18, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e, 0x2f, 0x30, 0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37, 0x38}
 	pubkeySesUn  = []byte{100, 0x02, 0x03, 0x04}
 )
 
@@ -47,6 +50,20 @@ const (
 	pubkeySesResult19 = `
 	Multi-precision integers of ECDSA (3 bytes)
 		02 03 04
+`
+	pubkeySesResult25 = `
+	X25519 ephemeral public key (32 bytes)
+		01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10 11 12 13 14 15 16 17 18 19 1a 1b 1c 1d 1e 1f 20
+	wrapped session key (25 bytes)
+		09 21 22 23 24 25 26 27 28 29 2a 2b 2c 2d 2e 2f 30 31 32 33 34 35 36 37 38
+		Symmetric Algorithm: AES with 256-bit key (sym 9)
+			09
+`
+	pubkeySesResult25c = `
+	X25519 ephemeral public key (32 bytes)
+		01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10 11 12 13 14 15 16 17 18 19 1a 1b 1c 1d 1e 1f 20
+	wrapped session key (24 bytes)
+		21 22 23 24 25 26 27 28 29 2a 2b 2c 2d 2e 2f 30 31 32 33 34 35 36 37 38
 `
 	pubkeySesResultUnknown = `
 	Multi-precision integers of Unknown (pub 100) (3 bytes)
@@ -57,16 +74,20 @@ const (
 func TestPubkeySes(t *testing.T) {
 	testCases := []struct {
 		content []byte
+		ver     byte
 		res     string
 		err     error
 	}{
-		{content: pubkeySes16, res: pubkeySesResult16, err: nil},
-		{content: pubkeySes17, res: pubkeySesResult17, err: nil},
-		{content: pubkeySes18a, res: pubkeySesResult18a, err: nil},
-		{content: pubkeySes18b, res: "", err: io.ErrUnexpectedEOF},
-		{content: pubkeySes18c, res: "", err: io.ErrUnexpectedEOF},
-		{content: pubkeySes19, res: pubkeySesResult19, err: nil},
-		{content: pubkeySesUn, res: pubkeySesResultUnknown, err: nil},
+		{content: pubkeySes16, ver: 3, res: pubkeySesResult16, err: nil},
+		{content: pubkeySes17, ver: 3, res: pubkeySesResult17, err: nil},
+		{content: pubkeySes18a, ver: 3, res: pubkeySesResult18a, err: nil},
+		{content: pubkeySes18b, ver: 3, res: "", err: io.ErrUnexpectedEOF},
+		{content: pubkeySes18c, ver: 3, res: "", err: io.ErrUnexpectedEOF},
+		{content: pubkeySes19, ver: 3, res: pubkeySesResult19, err: nil},
+		{content: pubkeySes25, ver: 3, res: pubkeySesResult25, err: nil},
+		{content: pubkeySes25b, ver: 3, res: "", err: io.ErrUnexpectedEOF},
+		{content: pubkeySes25c, ver: 6, res: pubkeySesResult25c, err: nil},
+		{content: pubkeySesUn, ver: 3, res: pubkeySesResultUnknown, err: nil},
 	}
 	for _, tc := range testCases {
 		parent := result.NewItem()
@@ -79,7 +100,7 @@ func TestPubkeySes(t *testing.T) {
 			context.Set(context.PRIVATE, true),
 			context.Set(context.UTC, true),
 		)
-		if err := New(cxt, values.PubID(tc.content[0]), reader.New(tc.content[1:])).ParseSes(parent); !errors.Is(err, tc.err) {
+		if err := New(cxt, values.PubID(tc.content[0]), reader.New(tc.content[1:])).ParseSes(parent, tc.ver); !errors.Is(err, tc.err) {
 			t.Errorf("Parse() = \"%v\", want \"%v\".", err, tc.err)
 		} else if err != nil {
 			fmt.Printf("Info: %+v\n", err)
@@ -10,6 +10,9 @@ import (
 
 //ParseSig multi-precision integers of public key algorithm for Signiture packet
 func (p *Pubkey) ParseSig(parent *result.Item) error {
+	if f := p.decoders().Sig; f != nil {
+		return errs.Wrap(f(p.cxt, p.reader, parent))
+	}
 	switch true {
 	case p.pubID.IsRSA():
 		return errs.Wrap(p.rsaSig(parent))
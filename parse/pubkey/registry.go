@@ -0,0 +1,62 @@
+package pubkey
+
+import (
+	"sync"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//ParseFunc is function value of parsing key material for public key algorithm
+type ParseFunc func(cxt *context.Context, r *reader.Reader, parent *result.Item) error
+
+//Decoders is a set of ParseFunc functions for public key algorithm.
+//nil function is processed by built-in parser.
+type Decoders struct {
+	Pub      ParseFunc //Public-Key Packet
+	Sig      ParseFunc //Signature Packet
+	Ses      ParseFunc //Public-Key Encrypted Session Key Packet
+	SecPlain ParseFunc //Secret-Key Packet (plain)
+}
+
+var (
+	registryMutex sync.RWMutex
+	registered    = map[values.PubID]Decoders{}
+)
+
+//Register registers Decoders for public key algorithm (ex. private or experimental algorithm 100-110).
+func Register(pubID values.PubID, d Decoders) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registered[pubID] = d
+}
+
+//Unregister removes Decoders of public key algorithm.
+func Unregister(pubID values.PubID) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	delete(registered, pubID)
+}
+
+func (p *Pubkey) decoders() Decoders {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return registered[p.pubID]
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,81 @@
+package pubkey
+
+import (
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+func TestRegister(t *testing.T) {
+	Register(100, Decoders{
+		Pub: func(cxt *context.Context, r *reader.Reader, parent *result.Item) error {
+			mpi, err := values.NewMPI(r)
+			if err != nil {
+				return err
+			}
+			parent.Add(mpi.ToItem("Test public key", cxt.Integer()))
+			return nil
+		},
+	})
+	defer Unregister(100)
+	cxt := context.New(context.Set(context.INTEGER, true))
+
+	parent := result.NewItem()
+	if err := New(cxt, values.PubID(100), reader.New([]byte{0x00, 0x09, 0x01, 0x02})).ParsePub(parent); err != nil {
+		t.Errorf("ParsePub() = \"%+v\", want nil.", err)
+	}
+	res := "\n\tTest public key (9 bits)\n\t\t01 02\n"
+	if str := parent.String(); str != res {
+		t.Errorf("ParsePub() = \"%v\", want \"%v\".", str, res)
+	}
+
+	parent = result.NewItem()
+	if err := New(cxt, values.PubID(100), reader.New([]byte{0x01, 0x02})).ParseSig(parent); err != nil {
+		t.Errorf("ParseSig() = \"%+v\", want nil.", err)
+	}
+	res = "\n\tMulti-precision integers of Unknown (pub 100) (2 bytes)\n"
+	if str := parent.String(); str != res {
+		t.Errorf("ParseSig() = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	Register(100, Decoders{
+		Pub: func(cxt *context.Context, r *reader.Reader, parent *result.Item) error {
+			parent.Add(result.NewItem(result.Name("Test public key")))
+			return nil
+		},
+	})
+	Unregister(100)
+	if d := (&Pubkey{pubID: 100}).decoders(); d.Pub != nil {
+		t.Error("decoders() = function, want nil.")
+	}
+	cxt := context.New(context.Set(context.INTEGER, true))
+
+	parent := result.NewItem()
+	if err := New(cxt, values.PubID(100), reader.New([]byte{0x00, 0x09, 0x01, 0x02})).ParsePub(parent); err != nil {
+		t.Errorf("ParsePub() = \"%+v\", want nil.", err)
+	}
+	res := "\n\tMulti-precision integers of Unknown (pub 100) (4 bytes)\n"
+	if str := parent.String(); str != res {
+		t.Errorf("ParsePub() = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,46 @@
+package reader
+
+import (
+	"context"
+	"io"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//CancelReader class is io.Reader with cancellation by context.Context
+type CancelReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+//NewCancelReader returns CancelReader instance
+func NewCancelReader(ctx context.Context, r io.Reader) *CancelReader {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &CancelReader{ctx: ctx, r: r}
+}
+
+//Read returns []byte data (io.Reader compatible)
+//If context is canceled or deadline exceeded, Read returns error of context.
+func (r *CancelReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return r.r.Read(p)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,43 @@
+package reader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCancelReader(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	r := NewCancelReader(context.Background(), bytes.NewReader(data))
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Errorf("ReadAll() = \"%+v\", want nil error.", err)
+	}
+	if !bytes.Equal(b, data) {
+		t.Errorf("ReadAll() = %v, want %v.", b, data)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = NewCancelReader(ctx, bytes.NewReader(data))
+	if _, err := io.ReadAll(r); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadAll() = \"%+v\", want \"%+v\".", err, context.Canceled)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,230 @@
+package result
+
+import (
+	"strconv"
+	"strings"
+)
+
+//filter class is conditions for selecting items
+type filter struct {
+	tags            map[int]bool
+	subpackets      map[int]bool
+	maxDepth        int
+	excludeUnhashed bool
+	paths           [][]string
+}
+
+//FilterOpt is self-referential function for functional options pattern
+type FilterOpt func(*filter)
+
+//FilterTags returns function for selecting packets by tag
+func FilterTags(tags ...int) FilterOpt {
+	return func(f *filter) {
+		if f.tags == nil {
+			f.tags = map[int]bool{}
+		}
+		for _, tag := range tags {
+			f.tags[tag] = true
+		}
+	}
+}
+
+//FilterSubpackets returns function for selecting sub-packets in signature packets by type
+func FilterSubpackets(types ...int) FilterOpt {
+	return func(f *filter) {
+		if f.subpackets == nil {
+			f.subpackets = map[int]bool{}
+		}
+		for _, typ := range types {
+			f.subpackets[typ&0x7f] = true
+		}
+	}
+}
+
+//MaxDepth returns function for setting maximum depth of items (packets are depth 0, negative is unlimited)
+func MaxDepth(depth int) FilterOpt {
+	return func(f *filter) {
+		f.maxDepth = depth
+	}
+}
+
+//ExcludeUnhashed returns function for removing unhashed sub-packet areas
+func ExcludeUnhashed(flag bool) FilterOpt {
+	return func(f *filter) {
+		f.excludeUnhashed = flag
+	}
+}
+
+//FilterPath returns function for selecting items by path (e.g. "/3/Hashed Subpacket/*").
+//Each element of path is index of item (0 origin), name of item (note in parentheses may be omitted), or "*" (any item).
+//Numeric elements are positional: "/6" is 7th packet, not packet of tag 6 (use FilterTags and FilterSubpackets to select by tag and type).
+//Selected items are output with their sub-items and ancestors.
+func FilterPath(path string) FilterOpt {
+	return func(f *filter) {
+		elms := []string{}
+		for _, elm := range strings.Split(path, "/") {
+			if elm = strings.TrimSpace(elm); len(elm) > 0 {
+				elms = append(elms, elm)
+			}
+		}
+		f.paths = append(f.paths, elms)
+	}
+}
+
+//Filter returns new Info instance with items selected by conditions (original items are not changed)
+func (i *Info) Filter(opts ...FilterOpt) *Info {
+	if i == nil {
+		return nil
+	}
+	f := &filter{maxDepth: -1}
+	for _, opt := range opts {
+		opt(f)
+	}
+	packets := i.Packets
+	if len(f.paths) > 0 {
+		marks := map[*Item]bool{}
+		for _, path := range f.paths {
+			f.mark(packets, path, marks)
+		}
+		packets = f.selected(packets, marks)
+	}
+	if f.tags != nil {
+		packets = f.byTags(packets)
+	}
+	res := New()
+	for _, itm := range packets {
+		res.Add(f.item(itm, 0))
+	}
+	return res
+}
+
+//mark marks items matched with path (true is selected item, false is its ancestor)
+func (f *filter) mark(items []*Item, path []string, marks map[*Item]bool) bool {
+	if len(path) == 0 {
+		for _, itm := range items {
+			if itm != nil {
+				marks[itm] = true
+			}
+		}
+		return len(items) > 0
+	}
+	matched := false
+	idx := 0
+	for _, itm := range items {
+		if itm == nil {
+			continue
+		}
+		n := idx
+		idx++
+		if !matchPath(path[0], n, itm) {
+			continue
+		}
+		if len(path) == 1 {
+			marks[itm] = true
+			matched = true
+			continue
+		}
+		if f.mark(itm.Items, path[1:], marks) {
+			if _, ok := marks[itm]; !ok {
+				marks[itm] = false
+			}
+			matched = true
+		}
+	}
+	return matched
+}
+
+//selected returns marked items
+func (f *filter) selected(items []*Item, marks map[*Item]bool) []*Item {
+	list := []*Item{}
+	for _, itm := range items {
+		whole, ok := marks[itm]
+		if !ok {
+			continue
+		}
+		if whole {
+			list = append(list, itm)
+			continue
+		}
+		cp := *itm
+		cp.Items = f.selected(itm.Items, marks)
+		list = append(list, &cp)
+	}
+	return list
+}
+
+//byTags returns packets with selected tags (packets in containers are picked up if container is not selected)
+func (f *filter) byTags(items []*Item) []*Item {
+	list := []*Item{}
+	for _, itm := range items {
+		if itm == nil || itm.Kind != KindPacket {
+			continue
+		}
+		if !f.tags[itm.Code] {
+			list = append(list, f.byTags(itm.Items)...)
+			continue
+		}
+		cp := *itm
+		cp.Items = []*Item{}
+		for _, child := range itm.Items {
+			if child != nil && child.Kind == KindPacket {
+				cp.Items = append(cp.Items, f.byTags([]*Item{child})...)
+				continue
+			}
+			cp.Items = append(cp.Items, child)
+		}
+		list = append(list, &cp)
+	}
+	return list
+}
+
+//item returns copy of item with sub-items selected by conditions
+func (f *filter) item(itm *Item, depth int) *Item {
+	if itm == nil {
+		return nil
+	}
+	cp := *itm
+	cp.Items = nil
+	if f.maxDepth >= 0 && depth >= f.maxDepth {
+		return &cp
+	}
+	for _, child := range itm.Items {
+		if child == nil {
+			continue
+		}
+		if f.excludeUnhashed && child.Kind == KindUnhashedArea {
+			continue
+		}
+		if f.subpackets != nil && child.Kind == KindSubpacket && (itm.Kind == KindHashedArea || itm.Kind == KindUnhashedArea) && !f.subpackets[child.Code&0x7f] {
+			continue
+		}
+		cp.Items = append(cp.Items, f.item(child, depth+1))
+	}
+	return &cp
+}
+
+//matchPath returns true if item matches element of path (n is index of item)
+func matchPath(elm string, n int, itm *Item) bool {
+	if elm == "*" {
+		return true
+	}
+	if i, err := strconv.Atoi(elm); err == nil {
+		return i == n
+	}
+	return matchName(elm, itm)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,77 @@
+package result
+
+import "testing"
+
+func filterTestData() *Info {
+	sig := func(name string) *Item {
+		s := NewItem(Name("Signature Packet (tag 2)"), Meta(KindPacket, 2), Note(name))
+		s.Add(NewItem(Name("Version"), Value("4")))
+		hashed := NewItem(Name("Hashed Subpacket"), Meta(KindHashedArea, 0), Note("12 bytes"))
+		hashed.Add(NewItem(Name("Signature Creation Time (sub 2)"), Meta(KindSubpacket, 2)))
+		hashed.Add(NewItem(Name("Issuer Fingerprint (sub 33)"), Meta(KindSubpacket, 33)))
+		s.Add(hashed)
+		unhashed := NewItem(Name("Unhashed Subpacket"), Meta(KindUnhashedArea, 0), Note("10 bytes"))
+		unhashed.Add(NewItem(Name("Issuer (sub 16)"), Meta(KindSubpacket, 16)))
+		s.Add(unhashed)
+		return s
+	}
+	info := New()
+	info.Add(NewItem(Name("Public-Key Packet (tag 6)"), Meta(KindPacket, 6)))
+	info.Add(NewItem(Name("User ID Packet (tag 13)"), Meta(KindPacket, 13)))
+	info.Add(sig("sig1"))
+	comp := NewItem(Name("Compressed Data Packet (tag 8)"), Meta(KindPacket, 8))
+	comp.Add(NewItem(Name("Compression Algorithm"), Value("ZIP")))
+	comp.Add(sig("sig2"))
+	info.Add(comp)
+	return info
+}
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		opts []FilterOpt
+		want string
+	}{
+		{opts: []FilterOpt{}, want: filterTestData().String()},
+		{opts: []FilterOpt{FilterTags(6, 13)}, want: "Public-Key Packet (tag 6)\nUser ID Packet (tag 13)\n"},
+		{opts: []FilterOpt{FilterTags(2), MaxDepth(1)}, want: "Signature Packet (tag 2) (sig1)\n\tVersion: 4\n\tHashed Subpacket (12 bytes)\n\tUnhashed Subpacket (10 bytes)\nSignature Packet (tag 2) (sig2)\n\tVersion: 4\n\tHashed Subpacket (12 bytes)\n\tUnhashed Subpacket (10 bytes)\n"},
+		{opts: []FilterOpt{FilterTags(8), MaxDepth(1)}, want: "Compressed Data Packet (tag 8)\n\tCompression Algorithm: ZIP\n"},
+		{opts: []FilterOpt{MaxDepth(0)}, want: "Public-Key Packet (tag 6)\nUser ID Packet (tag 13)\nSignature Packet (tag 2) (sig1)\nCompressed Data Packet (tag 8)\n"},
+		{opts: []FilterOpt{FilterPath("/2"), FilterSubpackets(33, 16), ExcludeUnhashed(true)}, want: "Signature Packet (tag 2) (sig1)\n\tVersion: 4\n\tHashed Subpacket (12 bytes)\n\t\tIssuer Fingerprint (sub 33)\n"},
+		{opts: []FilterOpt{FilterPath("/2/Hashed Subpacket/*")}, want: "Signature Packet (tag 2) (sig1)\n\tHashed Subpacket (12 bytes)\n\t\tSignature Creation Time (sub 2)\n\t\tIssuer Fingerprint (sub 33)\n"},
+		{opts: []FilterOpt{FilterPath("/*/*/Unhashed Subpacket/0"), FilterPath("/1")}, want: "User ID Packet (tag 13)\nCompressed Data Packet (tag 8)\n\tSignature Packet (tag 2) (sig2)\n\t\tUnhashed Subpacket (10 bytes)\n\t\t\tIssuer (sub 16)\n"},
+		{opts: []FilterOpt{FilterPath("/9")}, want: ""},
+		{opts: []FilterOpt{FilterPath("/0")}, want: "Public-Key Packet (tag 6)\n"},
+		{opts: []FilterOpt{FilterPath("/6")}, want: ""},
+		{opts: []FilterOpt{FilterPath("/2/1/1")}, want: "Signature Packet (tag 2) (sig1)\n\tHashed Subpacket (12 bytes)\n\t\tIssuer Fingerprint (sub 33)\n"},
+	}
+	for _, tc := range testCases {
+		info := filterTestData()
+		if str := info.Filter(tc.opts...).String(); str != tc.want {
+			t.Errorf("Info.Filter() = \"%v\", want \"%v\".", str, tc.want)
+		}
+		if str := info.String(); str != filterTestData().String() {
+			t.Errorf("Info.Filter() changes original items: \"%v\".", str)
+		}
+	}
+}
+
+func TestFilterNil(t *testing.T) {
+	if info := (*Info)(nil).Filter(MaxDepth(0)); info != nil {
+		t.Errorf("Info.Filter() = \"%v\", want nil.", info)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
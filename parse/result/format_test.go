@@ -0,0 +1,144 @@
+package result
+
+import (
+	"testing"
+)
+
+func testInfo() *Info {
+	info := New()
+	item1 := NewItem(
+		Name("name1"),
+		Value("value1 \"quoted\""),
+		Note("note1"),
+		DumpStr("00 01 02"),
+	)
+	item2 := NewItem(
+		Name("name2"),
+		Note("note2 <&>"),
+		DumpStr("03 04 05"),
+	)
+	item1.Add(item2)
+	info.Add(item1)
+	info.Add(NewItem(Name("name3")))
+	return info
+}
+
+func TestTOML(t *testing.T) {
+	output := `[[Packet]]
+name = "name1"
+value = "value1 \"quoted\""
+dump = "00 01 02"
+note = "note1"
+  [[Packet.Item]]
+  name = "name2"
+  dump = "03 04 05"
+  note = "note2 <&>"
+
+[[Packet]]
+name = "name3"
+`
+	res, err := testInfo().TOML(2)
+	if err != nil {
+		t.Errorf("TOML() err = \"%+v\", want nil.", err)
+		return
+	}
+	if str := r2s(res); str != output {
+		t.Errorf("TOML() = \"%s\" want \"%s\"", str, output)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	output := `Packet:
+  - name: "name1"
+    value: "value1 \"quoted\""
+    dump: "00 01 02"
+    note: "note1"
+    Item:
+      - name: "name2"
+        dump: "03 04 05"
+        note: "note2 <&>"
+  - name: "name3"
+`
+	res, err := testInfo().YAML(0)
+	if err != nil {
+		t.Errorf("YAML() err = \"%+v\", want nil.", err)
+		return
+	}
+	if str := r2s(res); str != output {
+		t.Errorf("YAML() = \"%s\" want \"%s\"", str, output)
+	}
+}
+
+func TestXML(t *testing.T) {
+	output := `<?xml version="1.0" encoding="UTF-8"?>
+<Info>
+  <Packet>
+    <name>name1</name>
+    <value>value1 &#34;quoted&#34;</value>
+    <dump>00 01 02</dump>
+    <note>note1</note>
+    <Item>
+      <name>name2</name>
+      <dump>03 04 05</dump>
+      <note>note2 &lt;&amp;&gt;</note>
+    </Item>
+  </Packet>
+  <Packet>
+    <name>name3</name>
+  </Packet>
+</Info>
+`
+	res, err := testInfo().XML(2)
+	if err != nil {
+		t.Errorf("XML() err = \"%+v\", want nil.", err)
+		return
+	}
+	if str := r2s(res); str != output {
+		t.Errorf("XML() = \"%s\" want \"%s\"", str, output)
+	}
+}
+
+func TestFormatNull(t *testing.T) {
+	info := (*Info)(nil)
+	if res, _ := info.TOML(2); r2s(res) != "" {
+		t.Errorf("TOML() = \"%s\" want \"%s\"", r2s(res), "")
+	}
+	if res, _ := info.YAML(2); r2s(res) != "{}\n" {
+		t.Errorf("YAML() = \"%s\" want \"%s\"", r2s(res), "{}\n")
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{s: "", want: `""`},
+		{s: "abc", want: `"abc"`},
+		{s: "a\"b\\c", want: `"a\"b\\c"`},
+		{s: "a\tb\nc\r", want: `"a\tb\nc\r"`},
+		{s: "\x00\x7f", want: `"\u0000\u007F"`},
+		{s: "\xff", want: `"\uFFFD"`},
+		{s: "日本語", want: `"日本語"`},
+	}
+	for _, tc := range testCases {
+		if got := quoteString(tc.s); got != tc.want {
+			t.Errorf("quoteString(%q) = %v, want %v.", tc.s, got, tc.want)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
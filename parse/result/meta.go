@@ -0,0 +1,126 @@
+package result
+
+import "io"
+
+//Kind is kind of Item (metadata for renderers)
+type Kind int
+
+const (
+	KindNone          Kind = iota //no metadata
+	KindPacket                    //OpenPGP packet (Code is tag ID)
+	KindSubpacket                 //sub-packet (Code is sub-packet type octet with critical bit)
+	KindHashedArea                //hashed sub-packet area in signature packet
+	KindUnhashedArea              //unhashed sub-packet area in signature packet
+	KindSubpacketArea             //sub-packet area in user attribute packet
+)
+
+//Header is header information of OpenPGP packet
+type Header struct {
+	Offset    int64  //offset of packet in stream
+	CTB       byte   //packet tag octet (cipher type byte)
+	HeaderLen int    //length of packet header
+	BodyLen   int64  //length of packet body
+	Partial   bool   //partial body length
+	Octets    []byte //octets of packet header
+}
+
+//NewFormat returns true if new format packet header
+func (h *Header) NewFormat() bool {
+	if h == nil {
+		return false
+	}
+	return h.CTB&0x40 != 0
+}
+
+//Meta returns closure as type ItemOpt
+func Meta(kind Kind, code int) ItemOpt {
+	return func(i *Item) {
+		i.Kind = kind
+		i.Code = code
+	}
+}
+
+//Raw returns closure as type ItemOpt
+func Raw(raw []byte) ItemOpt {
+	return func(i *Item) {
+		i.Raw = raw
+	}
+}
+
+//PacketHeader returns closure as type ItemOpt
+func PacketHeader(h *Header) ItemOpt {
+	return func(i *Item) {
+		i.Header = h
+	}
+}
+
+//Span is range of octets of item (offsets are relative to data of packet or sub-packet including it)
+type Span struct {
+	Start int64
+	End   int64
+}
+
+//Len returns length of span
+func (s *Span) Len() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.End - s.Start
+}
+
+//tracker class records spans of items by position of seeker
+type tracker struct {
+	seeker io.Seeker
+	mark   int64
+}
+
+//skip moves mark to end of span set explicitly
+func (t *tracker) skip(s *Span) {
+	if s.End > t.mark {
+		t.mark = s.End
+	}
+}
+
+//span returns span from last mark to current position
+func (t *tracker) span() *Span {
+	pos, _ := t.seeker.Seek(0, io.SeekCurrent)
+	if pos < t.mark { //rolled back
+		t.mark = pos
+	}
+	s := &Span{Start: t.mark, End: pos}
+	t.mark = pos
+	return s
+}
+
+//Range returns closure as type ItemOpt (span of item is set explicitly)
+func Range(start, end int64) ItemOpt {
+	return func(i *Item) {
+		i.Span = &Span{Start: start, End: end}
+	}
+}
+
+//Track returns closure as type ItemOpt (spans of items added later are recorded by position of seeker)
+func Track(s io.Seeker) ItemOpt {
+	return func(i *Item) {
+		if s == nil {
+			return
+		}
+		pos, _ := s.Seek(0, io.SeekCurrent)
+		i.tracker = &tracker{seeker: s, mark: pos}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,63 @@
+package result
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestTrack(t *testing.T) {
+	r := bytes.NewReader([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	root := NewItem(Name("root"), Track(r))
+	_, _ = r.Seek(1, io.SeekCurrent)
+	item1 := NewItem(Name("item1"))
+	root.Add(item1)
+	_, _ = r.Seek(2, io.SeekCurrent)
+	item2 := NewItem(Name("item2"))
+	item1.Add(item2) //tracker is inherited
+	item3 := NewItem(Name("item3"), Range(3, 5))
+	root.Add(item3)
+	_, _ = r.Seek(0, io.SeekEnd)
+	item4 := NewItem(Name("item4"))
+	root.Add(item4)
+
+	testCases := []struct {
+		item       *Item
+		start, end int64
+	}{
+		{item: item1, start: 0, end: 1},
+		{item: item2, start: 1, end: 3},
+		{item: item3, start: 3, end: 5},
+		{item: item4, start: 5, end: 6},
+	}
+	for _, tc := range testCases {
+		if tc.item.Span == nil {
+			t.Errorf("Span of %s is nil, want [%d, %d).", tc.item.Name, tc.start, tc.end)
+			continue
+		}
+		if tc.item.Span.Start != tc.start || tc.item.Span.End != tc.end {
+			t.Errorf("Span of %s = [%d, %d), want [%d, %d).", tc.item.Name, tc.item.Span.Start, tc.item.Span.End, tc.start, tc.end)
+		}
+	}
+	if root.Span != nil {
+		t.Errorf("Span of root = %v, want nil.", root.Span)
+	}
+	if l := item3.Span.Len(); l != 2 {
+		t.Errorf("Span.Len() = %v, want %v.", l, 2)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
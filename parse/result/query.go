@@ -0,0 +1,525 @@
+package result
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+//Query class is compiled query expression for items
+//
+//	query     := step ( ( "/" | "//" ) step )*     (leading "/" or "//" is allowed)
+//	step      := ( "*" | name | "string" ) ( "[" predicate "]" )*
+//	predicate := number | expr
+//	expr      := term ( "or" term )* ; term := factor ( "and" factor )*
+//	factor    := "not" factor | "(" expr ")" | operand [ op literal ]
+//	operand   := "@name" | "@value" | "@note" | "@dump" | "@tag" | path [ "/@attr" ]
+//	op        := "=" | "!=" | "~" | "!~" | "<" | "<=" | ">" | ">="
+type Query struct {
+	path *queryPath
+}
+
+//queryPath class is sequence of steps
+type queryPath struct {
+	steps []*queryStep
+	attr  string //attribute of operand (e.g. "value")
+}
+
+//queryStep class is step in path
+type queryStep struct {
+	desc  bool   //descendant items
+	name  string //empty is any item
+	preds []queryPred
+}
+
+//queryPred class is predicate of step (expr is nil if index)
+type queryPred struct {
+	index int
+	expr  queryExpr
+}
+
+//queryExpr is interface for predicate expressions
+type queryExpr interface {
+	eval(itm *Item) bool
+}
+
+type queryOr struct{ l, r queryExpr }
+
+func (e *queryOr) eval(itm *Item) bool { return e.l.eval(itm) || e.r.eval(itm) }
+
+type queryAnd struct{ l, r queryExpr }
+
+func (e *queryAnd) eval(itm *Item) bool { return e.l.eval(itm) && e.r.eval(itm) }
+
+type queryNot struct{ e queryExpr }
+
+func (e *queryNot) eval(itm *Item) bool { return !e.e.eval(itm) }
+
+//queryCmp class is comparison (op is empty if test of existence)
+type queryCmp struct {
+	path *queryPath
+	op   string
+	lit  string
+	re   *regexp.Regexp
+}
+
+func (e *queryCmp) eval(itm *Item) bool {
+	targets := []*Item{itm}
+	if len(e.path.steps) > 0 {
+		targets = e.path.eval([]*Item{itm})
+	}
+	for _, t := range targets {
+		v := itemAttr(t, e.path.attr)
+		if e.compare(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *queryCmp) compare(v string) bool {
+	switch e.op {
+	case "":
+		return len(e.path.steps) > 0 || len(v) > 0
+	case "=":
+		return v == e.lit
+	case "!=":
+		return v != e.lit
+	case "~":
+		return e.re.MatchString(v)
+	case "!~":
+		return !e.re.MatchString(v)
+	}
+	x, ok1 := leadingNumber(v)
+	y, ok2 := leadingNumber(e.lit)
+	if !ok1 || !ok2 {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	case ">":
+		return x > y
+	case ">=":
+		return x >= y
+	}
+	return false
+}
+
+//CompileQuery returns compiled query
+func CompileQuery(expr string) (*Query, error) {
+	p := &queryParser{src: []rune(expr)}
+	path, err := p.path(false)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.error("unexpected character")
+	}
+	if len(path.steps) == 0 || len(path.attr) > 0 {
+		return nil, p.error("no step")
+	}
+	return &Query{path: path}, nil
+}
+
+//Query returns new Info instance with items matched by query expression
+func (i *Info) Query(expr string) (*Info, error) {
+	q, err := CompileQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Select(i), nil
+}
+
+//Select returns new Info instance with items matched by query (matched items are listed in Packets)
+func (q *Query) Select(i *Info) *Info {
+	res := New()
+	if i == nil || q == nil {
+		return res
+	}
+	for _, itm := range q.path.eval([]*Item{{Items: i.Packets}}) {
+		res.Add(itm)
+	}
+	return res
+}
+
+//eval returns items matched by path from context items
+func (p *queryPath) eval(cxt []*Item) []*Item {
+	for _, step := range p.steps {
+		next := []*Item{}
+		found := map[*Item]bool{}
+		for _, c := range cxt {
+			cands := []*Item{}
+			collect := func(itm *Item) {
+				if matchName(step.name, itm) {
+					cands = append(cands, itm)
+				}
+			}
+			if step.desc {
+				walkDescendants(c.Items, collect)
+			} else {
+				for _, itm := range c.Items {
+					if itm != nil {
+						collect(itm)
+					}
+				}
+			}
+			for _, pred := range step.preds {
+				cands = pred.filter(cands)
+			}
+			for _, itm := range cands {
+				if !found[itm] {
+					found[itm] = true
+					next = append(next, itm)
+				}
+			}
+		}
+		cxt = next
+	}
+	return cxt
+}
+
+func (pred queryPred) filter(items []*Item) []*Item {
+	if pred.expr == nil {
+		if pred.index < 0 || pred.index >= len(items) {
+			return []*Item{}
+		}
+		return items[pred.index : pred.index+1]
+	}
+	list := []*Item{}
+	for _, itm := range items {
+		if pred.expr.eval(itm) {
+			list = append(list, itm)
+		}
+	}
+	return list
+}
+
+func walkDescendants(items []*Item, fn func(*Item)) {
+	for _, itm := range items {
+		if itm == nil {
+			continue
+		}
+		fn(itm)
+		walkDescendants(itm.Items, fn)
+	}
+}
+
+//matchName returns true if name of item is name (note in parentheses may be omitted, empty name matches any item)
+func matchName(name string, itm *Item) bool {
+	return len(name) == 0 || itm.Name == name || strings.HasPrefix(itm.Name, name+" (")
+}
+
+//itemAttr returns attribute of item
+func itemAttr(itm *Item, attr string) string {
+	switch attr {
+	case "name":
+		return itm.Name
+	case "note":
+		return itm.Note
+	case "dump":
+		return itm.Dump
+	case "tag":
+		switch itm.Kind {
+		case KindPacket:
+			return strconv.Itoa(itm.Code)
+		case KindSubpacket:
+			return strconv.Itoa(itm.Code & 0x7f)
+		}
+		return ""
+	}
+	return itm.Value
+}
+
+//leadingNumber returns number at head of string (e.g. "4" in "4 (current)")
+func leadingNumber(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	if strings.HasPrefix(fields[0], "0x") {
+		n, err := strconv.ParseUint(fields[0][2:], 16, 64)
+		return float64(n), err == nil
+	}
+	n, err := strconv.ParseFloat(fields[0], 64)
+	return n, err == nil
+}
+
+//queryParser class is parser of query expression
+type queryParser struct {
+	src []rune
+	pos int
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *queryParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *queryParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+//consume returns true and skips s if rest of source begins with s
+func (p *queryParser) consume(s string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(string(p.src[p.pos:]), s) {
+		p.pos += len([]rune(s))
+		return true
+	}
+	return false
+}
+
+//keyword returns true and skips word if rest of source begins with word (and its boundary)
+func (p *queryParser) keyword(word string) bool {
+	p.skipSpace()
+	rest := string(p.src[p.pos:])
+	if !strings.HasPrefix(rest, word) {
+		return false
+	}
+	if next := []rune(rest[len(word):]); len(next) > 0 && !unicode.IsSpace(next[0]) && next[0] != '(' {
+		return false
+	}
+	p.pos += len([]rune(word))
+	return true
+}
+
+func (p *queryParser) error(msg string) error {
+	return errs.New(msg, errs.WithCause(ecode.ErrQuery), errs.WithContext("position", p.pos), errs.WithContext("query", string(p.src)))
+}
+
+//path parses path (inPred is true in predicate)
+func (p *queryParser) path(inPred bool) (*queryPath, error) {
+	path := &queryPath{}
+	first := true
+	for {
+		p.skipSpace()
+		desc := false
+		switch {
+		case p.consume("//"):
+			desc = true
+		case p.consume("/"):
+		case !first:
+			return path, nil
+		}
+		first = false
+		if p.consume("@") {
+			attr := p.word()
+			switch attr {
+			case "name", "value", "note", "dump", "tag":
+			default:
+				return nil, p.error("unknown attribute")
+			}
+			path.attr = attr
+			return path, nil
+		}
+		step := &queryStep{desc: desc}
+		name, err := p.name(inPred)
+		if err != nil {
+			return nil, err
+		}
+		if name != "*" {
+			step.name = name
+		}
+		for p.consume("[") {
+			pred, err := p.predicate()
+			if err != nil {
+				return nil, err
+			}
+			step.preds = append(step.preds, pred)
+			if !p.consume("]") {
+				return nil, p.error("missing ']'")
+			}
+		}
+		path.steps = append(path.steps, step)
+	}
+}
+
+//name parses name test of step
+func (p *queryParser) name(inPred bool) (string, error) {
+	p.skipSpace()
+	if p.peek() == '"' {
+		return p.quoted()
+	}
+	start := p.pos
+	for !p.eof() {
+		r := p.peek()
+		if strings.ContainsRune("/[]", r) {
+			break
+		}
+		if inPred {
+			if strings.ContainsRune("=!~<>)", r) {
+				break
+			}
+			rest := string(p.src[p.pos:])
+			if strings.HasPrefix(rest, " and ") || strings.HasPrefix(rest, " or ") {
+				break
+			}
+		}
+		p.pos++
+	}
+	name := strings.TrimSpace(string(p.src[start:p.pos]))
+	if len(name) == 0 {
+		return "", p.error("empty name")
+	}
+	return name, nil
+}
+
+//word parses word of letters
+func (p *queryParser) word() string {
+	start := p.pos
+	for !p.eof() && unicode.IsLetter(p.peek()) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+//quoted parses quoted string
+func (p *queryParser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+	for !p.eof() {
+		switch p.peek() {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(string(p.src[start:p.pos]))
+			if err != nil {
+				return "", p.error("invalid string")
+			}
+			return s, nil
+		}
+		p.pos++
+	}
+	return "", p.error("unterminated string")
+}
+
+//predicate parses predicate of step
+func (p *queryParser) predicate() (queryPred, error) {
+	p.skipSpace()
+	start := p.pos
+	for !p.eof() && unicode.IsDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos > start {
+		n, _ := strconv.Atoi(string(p.src[start:p.pos]))
+		p.skipSpace()
+		if p.peek() == ']' {
+			return queryPred{index: n}, nil
+		}
+		p.pos = start
+	}
+	e, err := p.or()
+	if err != nil {
+		return queryPred{}, err
+	}
+	return queryPred{expr: e}, nil
+}
+
+func (p *queryParser) or() (queryExpr, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l = &queryOr{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *queryParser) and() (queryExpr, error) {
+	l, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		r, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		l = &queryAnd{l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *queryParser) factor() (queryExpr, error) {
+	if p.keyword("not") {
+		e, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNot{e: e}, nil
+	}
+	if p.consume("(") {
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.error("missing ')'")
+		}
+		return e, nil
+	}
+	return p.comparison()
+}
+
+func (p *queryParser) comparison() (queryExpr, error) {
+	path, err := p.path(true)
+	if err != nil {
+		return nil, err
+	}
+	if len(path.attr) == 0 {
+		path.attr = "value"
+	}
+	cmp := &queryCmp{path: path}
+	for _, op := range []string{"!=", "!~", "<=", ">=", "=", "~", "<", ">"} {
+		if p.consume(op) {
+			cmp.op = op
+			break
+		}
+	}
+	if len(cmp.op) == 0 {
+		return cmp, nil
+	}
+	p.skipSpace()
+	if p.peek() == '"' {
+		if cmp.lit, err = p.quoted(); err != nil {
+			return nil, err
+		}
+	} else {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.peek()) && !strings.ContainsRune("])", p.peek()) {
+			p.pos++
+		}
+		cmp.lit = string(p.src[start:p.pos])
+	}
+	if cmp.op == "~" || cmp.op == "!~" {
+		re, err := regexp.Compile(cmp.lit)
+		if err != nil {
+			return nil, p.error("invalid regular expression")
+		}
+		cmp.re = re
+	}
+	return cmp, nil
+}
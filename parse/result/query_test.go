@@ -0,0 +1,68 @@
+package result
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+func queryTestData() *Info {
+	info := filterTestData()
+	sig := info.Packets[2]
+	sig.Items = append([]*Item{NewItem(Name("Hash Algorithm"), Value("SHA-1 (hash 2)"))}, sig.Items...)
+	sig = info.Packets[3].Items[1]
+	sig.Items = append([]*Item{NewItem(Name("Hash Algorithm"), Value("SHA2-256 (hash 8)"))}, sig.Items...)
+	return info
+}
+
+func TestQuery(t *testing.T) {
+	testCases := []struct {
+		expr string
+		want string
+	}{
+		{expr: `//Signature Packet[Hash Algorithm ~ "SHA-1"]/Version`, want: "Version: 4\n"},
+		{expr: `//Signature Packet[Hash Algorithm = "SHA2-256 (hash 8)"]/Hash Algorithm`, want: "Hash Algorithm: SHA2-256 (hash 8)\n"},
+		{expr: `//Issuer Fingerprint`, want: "Issuer Fingerprint (sub 33)\nIssuer Fingerprint (sub 33)\n"},
+		{expr: `/*[@tag = 2 or @tag = 13]`, want: "User ID Packet (tag 13)\n" + queryTestData().Packets[2].String()},
+		{expr: `/*[@tag >= 8]/Compression Algorithm`, want: "Compression Algorithm: ZIP\n"},
+		{expr: `/*[not(Version)][1]`, want: "User ID Packet (tag 13)\n"},
+		{expr: `//*[@note = "sig2"]/*[Issuer]`, want: "Unhashed Subpacket (10 bytes)\n\tIssuer (sub 16)\n"},
+		{expr: `//*[Hashed Subpacket/"Issuer Fingerprint" and @note != "sig1"]/Version`, want: "Version: 4\n"},
+		{expr: `//*[Version and (@note ~ "^sig" or @tag = 6)]/Version`, want: "Version: 4\nVersion: 4\n"},
+		{expr: `//Foo`, want: ""},
+	}
+	for _, tc := range testCases {
+		res, err := queryTestData().Query(tc.expr)
+		if err != nil {
+			t.Errorf("Info.Query(%v) = \"%+v\", want nil error.", tc.expr, err)
+			continue
+		}
+		if str := res.String(); str != tc.want {
+			t.Errorf("Info.Query(%v) = \"%v\", want \"%v\".", tc.expr, str, tc.want)
+		}
+	}
+}
+
+func TestQueryErr(t *testing.T) {
+	for _, expr := range []string{``, `/`, `/*[Version`, `/*[@foo = 1]`, `/*[Version ~ "("]`, `/*[Version = "foo]`, `/*[(Version]`, `/*]`, `//Signature Packet/@name`} {
+		if _, err := CompileQuery(expr); !errors.Is(err, ecode.ErrQuery) {
+			t.Errorf("CompileQuery(%v) = \"%+v\", want \"%+v\".", expr, err, ecode.ErrQuery)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
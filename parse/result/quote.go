@@ -0,0 +1,57 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//quoteString returns double-quoted string with escape sequences (TOML basic string and YAML double-quoted scalar compatible)
+func quoteString(s string) string {
+	bldr := &strings.Builder{}
+	bldr.WriteByte('"')
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch r {
+		case '"':
+			bldr.WriteString(`\"`)
+		case '\\':
+			bldr.WriteString(`\\`)
+		case '\b':
+			bldr.WriteString(`\b`)
+		case '\t':
+			bldr.WriteString(`\t`)
+		case '\n':
+			bldr.WriteString(`\n`)
+		case '\f':
+			bldr.WriteString(`\f`)
+		case '\r':
+			bldr.WriteString(`\r`)
+		default:
+			switch {
+			case r < 0x20, r == 0x7f, 0x80 <= r && r < 0xa0, r == 0xfeff, r == utf8.RuneError:
+				fmt.Fprintf(bldr, `\u%04X`, r)
+			default:
+				bldr.WriteRune(r)
+			}
+		}
+	}
+	bldr.WriteByte('"')
+	return bldr.String()
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -12,7 +12,7 @@ import (
 
 //Info is information class for OpenPGP packets
 type Info struct {
-	Packets []*Item `toml:"Packet,omitempty" json:"Packet,omitempty"`
+	Packets []*Item `toml:"Packet,omitempty" json:"Packet,omitempty" xml:"Packet,omitempty"`
 }
 
 //New returns Info instance
@@ -58,18 +58,28 @@ func (i *Info) ToString(indent string) *bytes.Buffer {
 	return buf
 }
 
-//Stringer as TOML format
+//Stringer as indented text format
 func (i *Info) String() string {
 	return i.ToString("\t").String()
 }
 
 //Item is information item class
 type Item struct {
-	Name  string  `toml:"name" json:"name"`
-	Value string  `toml:"value,omitempty" json:"value,omitempty"`
-	Dump  string  `toml:"dump,omitempty" json:"dump,omitempty"`
-	Note  string  `toml:"note,omitempty" json:"note,omitempty"`
-	Items []*Item `toml:"Item,omitempty" json:"Item,omitempty"`
+	Name        string  `toml:"name" json:"name" xml:"name"`
+	Value       string  `toml:"value,omitempty" json:"value,omitempty" xml:"value,omitempty"`
+	Dump        string  `toml:"dump,omitempty" json:"dump,omitempty" xml:"dump,omitempty"`
+	Note        string  `toml:"note,omitempty" json:"note,omitempty" xml:"note,omitempty"`
+	Description string  `toml:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"` //by explain mode
+	Reference   string  `toml:"reference,omitempty" json:"reference,omitempty" xml:"reference,omitempty"`       //by explain mode
+	Items       []*Item `toml:"Item,omitempty" json:"Item,omitempty" xml:"Item,omitempty"`
+	//metadata for renderers (not marshaled)
+	Kind   Kind    `toml:"-" json:"-" xml:"-"`
+	Code   int     `toml:"-" json:"-" xml:"-"`
+	Raw    []byte  `toml:"-" json:"-" xml:"-"`
+	Header *Header `toml:"-" json:"-" xml:"-"`
+	Span   *Span   `toml:"-" json:"-" xml:"-"`
+
+	tracker *tracker //records spans of sub-items
 }
 
 //ItemOpt is self-referential function for functional options pattern
@@ -93,6 +103,16 @@ func (i *Item) optins(opts ...ItemOpt) {
 //Add add sub-item in item.
 func (i *Item) Add(a *Item) {
 	if a != nil && i != nil {
+		if i.tracker != nil {
+			if a.Span == nil {
+				a.Span = i.tracker.span()
+			} else {
+				i.tracker.skip(a.Span)
+			}
+			if a.tracker == nil {
+				a.tracker = i.tracker
+			}
+		}
 		i.Items = append(i.Items, a)
 	}
 }
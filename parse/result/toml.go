@@ -0,0 +1,63 @@
+package result
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//TOML returns TOML formated string
+func (i *Info) TOML(indent int) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	if i == nil {
+		return buf, nil
+	}
+	ind := ""
+	if indent > 0 {
+		ind = strings.Repeat(" ", indent)
+	}
+	for n, itm := range i.Packets {
+		if n > 0 {
+			buf.WriteString("\n")
+		}
+		itm.toTOML("Packet", ind, 0, buf)
+	}
+	return buf, nil
+}
+
+func (i *Item) toTOML(key, indent string, lvl int, buf *bytes.Buffer) {
+	if i == nil {
+		return
+	}
+	ind := strings.Repeat(indent, lvl)
+	fmt.Fprintf(buf, "%s[[%s]]\n", ind, key)
+	fmt.Fprintf(buf, "%sname = %s\n", ind, quoteString(i.Name))
+	if len(i.Value) > 0 {
+		fmt.Fprintf(buf, "%svalue = %s\n", ind, quoteString(i.Value))
+	}
+	if len(i.Dump) > 0 {
+		fmt.Fprintf(buf, "%sdump = %s\n", ind, quoteString(i.Dump))
+	}
+	if len(i.Note) > 0 {
+		fmt.Fprintf(buf, "%snote = %s\n", ind, quoteString(i.Note))
+	}
+	for _, itm := range i.Items {
+		itm.toTOML(key+".Item", indent, lvl+1, buf)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,46 @@
+package result
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//XML returns XML formated string
+func (i *Info) XML(indent int) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	if i == nil {
+		i = New()
+	}
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(buf)
+	if indent > 0 {
+		enc.Indent("", strings.Repeat(" ", indent))
+	}
+	if err := enc.Encode(i); err != nil {
+		return buf, errs.Wrap(err)
+	}
+	if err := enc.Flush(); err != nil {
+		return buf, errs.Wrap(err)
+	}
+	buf.WriteString("\n")
+	return buf, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
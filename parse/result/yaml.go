@@ -0,0 +1,64 @@
+package result
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//YAML returns YAML formated string
+func (i *Info) YAML(indent int) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	if i == nil || len(i.Packets) == 0 {
+		buf.WriteString("{}\n")
+		return buf, nil
+	}
+	if indent <= 0 {
+		indent = 2
+	}
+	ind := strings.Repeat(" ", indent)
+	buf.WriteString("Packet:\n")
+	for _, itm := range i.Packets {
+		itm.toYAML(ind, ind, buf)
+	}
+	return buf, nil
+}
+
+func (i *Item) toYAML(prefix, indent string, buf *bytes.Buffer) {
+	if i == nil {
+		return
+	}
+	fmt.Fprintf(buf, "%s- name: %s\n", prefix, quoteString(i.Name))
+	prefix += "  "
+	if len(i.Value) > 0 {
+		fmt.Fprintf(buf, "%svalue: %s\n", prefix, quoteString(i.Value))
+	}
+	if len(i.Dump) > 0 {
+		fmt.Fprintf(buf, "%sdump: %s\n", prefix, quoteString(i.Dump))
+	}
+	if len(i.Note) > 0 {
+		fmt.Fprintf(buf, "%snote: %s\n", prefix, quoteString(i.Note))
+	}
+	if len(i.Items) > 0 {
+		fmt.Fprintf(buf, "%sItem:\n", prefix)
+		for _, itm := range i.Items {
+			itm.toYAML(prefix+indent, indent, buf)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,84 @@
+package s2k
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+
+	"golang.org/x/crypto/openpgp/s2k"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//newHash returns hash.Hash instance of hash algorithm
+func newHash(hashID values.HashID) (hash.Hash, error) {
+	switch hashID {
+	case 1:
+		return md5.New(), nil
+	case 2:
+		return sha1.New(), nil
+	case 3:
+		return ripemd160.New(), nil
+	case 8:
+		return sha256.New(), nil
+	case 9:
+		return sha512.New384(), nil
+	case 10:
+		return sha512.New(), nil
+	case 11:
+		return crypto.SHA224.New(), nil
+	case 12:
+		return sha3.New256(), nil
+	case 14:
+		return sha3.New512(), nil
+	}
+	return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("hashid", hashID.String()))
+}
+
+//Key returns key (size octets) derived from passphrase by parsed S2K specifier
+func (s *S2K) Key(passphrase []byte, size int) ([]byte, error) {
+	if s == nil {
+		return nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	switch s.id {
+	case 0x00, 0x01, 0x03:
+	default:
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("s2kid", s.id.String()))
+	}
+	h, err := newHash(s.hashID)
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, size)
+	switch s.id {
+	case 0x00:
+		s2k.Simple(key, h, passphrase)
+	case 0x01:
+		s2k.Salted(key, h, passphrase, s.salt)
+	case 0x03:
+		s2k.Iterated(key, h, passphrase, s.salt, int(s.count))
+	}
+	return key, nil
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,60 @@
+package s2k
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func TestS2KKey(t *testing.T) {
+	md5sum := md5.Sum([]byte("pass"))
+	testCases := []struct {
+		data []byte
+		size int
+		key  string
+		err  error
+	}{
+		{data: []byte{0x00, 0x01}, size: 16, key: hex.EncodeToString(md5sum[:]), err: nil},
+		{data: []byte{0x03, 0x02, 0x97, 0xce, 0x7d, 0xe9, 0x49, 0x16, 0x4a, 0x9f, 0xff}, size: 16, key: "79debf2265eb299851d31485f7e87db5", err: nil}, //made by GnuPG
+		{data: []byte{0x00, 0x07}, size: 16, key: "", err: ecode.ErrUnsupportedAlg},
+		{data: []byte{0x65, 0x00}, size: 16, key: "", err: ecode.ErrUnsupportedAlg},
+	}
+	for _, tc := range testCases {
+		s2k := New(reader.New(tc.data))
+		if err := s2k.Parse(result.NewItem(), false); err != nil {
+			t.Errorf("S2K err = \"%+v\", want nil.", err)
+			continue
+		}
+		key, err := s2k.Key([]byte("pass"), tc.size)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("S2K.Key() = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+		if k, _ := hex.DecodeString(tc.key); !bytes.Equal(key, k) {
+			t.Errorf("S2K.Key() = %x, want %v.", key, tc.key)
+		}
+	}
+	if _, err := (*S2K)(nil).Key([]byte("pass"), 16); !errs.Is(err, ecode.ErrNullPointer) {
+		t.Errorf("S2K.Key() = \"%+v\", want \"%+v\".", err, ecode.ErrNullPointer)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -15,6 +15,11 @@ import (
 type S2K struct {
 	reader *reader.Reader
 	hasIV  bool
+	id     values.S2KID
+	hashID values.HashID
+	salt   []byte
+	count  uint32
+	gnu    int
 }
 
 //New returns new Pubkey instance
@@ -32,6 +37,7 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 		return errs.New("invalid s2k ID", errs.WithCause(err))
 	}
 	s2kID := values.S2KID(ss)
+	s.id = s2kID
 	itm := s2kID.ToItem(dumpFlag)
 	parent.Add(itm)
 	switch s2kID {
@@ -43,7 +49,8 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 		if err != nil {
 			return errs.New("invalid hash ID", errs.WithCause(err))
 		}
-		itm.Add(values.HashID(hashid).ToItem(dumpFlag))
+		s.hashID = values.HashID(hashid)
+		itm.Add(s.hashID.ToItem(dumpFlag))
 		if s2kID != 0x00 {
 			//0x01: Salted S2K
 			//0x03: Iterated and Salted S2K
@@ -51,6 +58,7 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 			if err != nil {
 				return errs.New("invalid salt ID", errs.WithCause(err))
 			}
+			s.salt = salt
 			itm.Add(values.Salt(salt).ToItem(true))
 		}
 		if s2kID == 0x03 {
@@ -59,12 +67,24 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 			if err != nil {
 				return errs.New("invalid stretch count ID", errs.WithCause(err))
 			}
+			s.count = values.Stretch(ct).Count()
 			itm.Add(values.Stretch(ct).ToItem())
 		}
 	case 101:
 		//Private/Experimental algorithm (s2k 101)
 		//GNU-divert-to-card S2K format
 		//refs https://lists.gnupg.org/pipermail/gnupg-users/2015-February/052769.html
+		if ok, err := s.hasHashOctet(); err != nil {
+			return err
+		} else if ok {
+			//GnuPG writes hash algorithm octet (unused) before "GNU" marker
+			hashid, err := s.reader.ReadByte()
+			if err != nil {
+				return errs.New("invalid hash ID", errs.WithCause(err))
+			}
+			s.hashID = values.HashID(hashid)
+			itm.Add(s.hashID.ToItem(dumpFlag))
+		}
 		if s.reader.Rest() < 4 {
 			return nil //unknown
 		}
@@ -79,6 +99,7 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 				return errs.New("invalid gnu-div num", errs.WithCause(err))
 			}
 			enum := 1000 + int(n)
+			s.gnu = enum
 			gnu := result.NewItem(
 				result.Name("GNU-divert-to-card"),
 				result.Value(fmt.Sprintf("Extension Number %d", enum)),
@@ -105,11 +126,34 @@ func (s *S2K) Parse(parent *result.Item, dumpFlag bool) error {
 	return nil
 }
 
+//hasHashOctet returns true if hash algorithm octet precedes "GNU" marker
+func (s *S2K) hasHashOctet() (bool, error) {
+	if s.reader.Rest() < 5 {
+		return false, nil
+	}
+	b, err := s.reader.ReadBytes(4)
+	if err != nil {
+		return false, errs.New("invalid gnu-div", errs.WithCause(err))
+	}
+	if _, err := s.reader.Seek(-4, io.SeekCurrent); err != nil { //roll back
+		return false, errs.New("roll back error", errs.WithCause(err))
+	}
+	return bytes.Equal(b[1:], []byte("GNU")), nil
+}
+
 //HasIV returns true if it has IV
 func (s *S2K) HasIV() bool {
 	return s.hasIV
 }
 
+//GNUExtension returns extension number of GNU S2K (1001: gnu-dummy, 1002: gnu-divert-to-card, 0: not GNU extension)
+func (s *S2K) GNUExtension() int {
+	if s == nil {
+		return 0
+	}
+	return s.gnu
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
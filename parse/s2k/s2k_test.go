@@ -579,6 +579,44 @@ func TestExpS2Kgnu2(t *testing.T) {
 	}
 }
 
+func TestExpS2KgnuWithHash(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		ext  int
+		hash string
+		rest int64
+	}{
+		{data: []byte{101, 0x00, 'G', 'N', 'U', 0x01}, ext: 1001, hash: "Unknown (hash 0)", rest: 0},
+		{data: []byte{101, 0x00, 'G', 'N', 'U', 0x02, 0x02, 0xab, 0xcd, 0xff}, ext: 1002, hash: "Unknown (hash 0)", rest: 1},
+		{data: []byte{101, 'G', 'N', 'U', 0x01, 0xff, 0xff}, ext: 1001, hash: "", rest: 2},
+		{data: []byte{101, 0xff, 0xff, 0xff, 0xff}, ext: 0, hash: "", rest: 4},
+	}
+	for _, tc := range testCases {
+		parent := result.NewItem()
+		reader := reader.New(tc.data)
+		s2k := New(reader)
+		if err := s2k.Parse(parent, true); err != nil {
+			t.Errorf("S2K err = \"%+v\", want nil.", err)
+			continue
+		}
+		if s2k.GNUExtension() != tc.ext {
+			t.Errorf("S2K.GNUExtension() = %v, want %v.", s2k.GNUExtension(), tc.ext)
+		}
+		hash := ""
+		for _, i := range parent.Items[0].Items {
+			if i.Name == "Hash Algorithm" {
+				hash = i.Value
+			}
+		}
+		if hash != tc.hash {
+			t.Errorf("S2K.Hash = \"%v\", want \"%v\".", hash, tc.hash)
+		}
+		if reader.Rest() != tc.rest {
+			t.Errorf("Rest = %d, want %d.", reader.Rest(), tc.rest)
+		}
+	}
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -5,6 +5,7 @@ import (
 	"compress/bzip2"
 	"compress/flate"
 	"compress/zlib"
+	stdcontext "context"
 	"io"
 	"strings"
 
@@ -81,7 +82,7 @@ func (t *Tag08) extractZip() (io.Reader, error) {
 	}
 	zr := flate.NewReader(bytes.NewReader(zd))
 	defer zr.Close()
-	return copyFrom(zr)
+	return copyFrom(t.cxt.Context(), zr)
 }
 
 func (t *Tag08) extractZLib() (io.Reader, error) {
@@ -94,7 +95,7 @@ func (t *Tag08) extractZLib() (io.Reader, error) {
 		return nil, errs.Wrap(err)
 	}
 	defer zr.Close()
-	return copyFrom(zr)
+	return copyFrom(t.cxt.Context(), zr)
 }
 
 func (t *Tag08) extractBzip2() (io.Reader, error) {
@@ -102,12 +103,12 @@ func (t *Tag08) extractBzip2() (io.Reader, error) {
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
-	return copyFrom(bzip2.NewReader(bytes.NewReader(zd)))
+	return copyFrom(t.cxt.Context(), bzip2.NewReader(bytes.NewReader(zd)))
 }
 
-func copyFrom(r io.Reader) (io.Reader, error) {
+func copyFrom(ctx stdcontext.Context, r io.Reader) (io.Reader, error) {
 	buf := &bytes.Buffer{}
-	if _, err := io.CopyN(buf, r, maxDecompressionDataSize); err != nil {
+	if _, err := io.CopyN(buf, reader.NewCancelReader(ctx, r), maxDecompressionDataSize); err != nil {
 		if errs.Is(err, io.EOF) {
 			return buf, nil
 		}
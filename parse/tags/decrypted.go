@@ -0,0 +1,106 @@
+package tags
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//maskedSessionKey is dump string instead of decrypted session key (shows with --show-secrets option)
+const maskedSessionKey = "(masked session key)"
+
+//decrypted class is plain data of encrypted data packet (tag 9, 18 and 20)
+type decrypted struct {
+	data io.Reader
+}
+
+//Reader returns io.Reader of decrypted data (nil if not decrypted)
+func (d *decrypted) Reader() io.Reader {
+	return d.data
+}
+
+//set sets plain data, and returns result of decryption as result.Item (check is name of checking integrity)
+func (d *decrypted) set(plain []byte, err error, check string) *result.Item {
+	if err != nil {
+		d.data = nil
+		return result.NewItem(
+			result.Name("Decrypted data"),
+			result.Value(decryptionError(err)),
+		)
+	}
+	d.data = bytes.NewReader(plain)
+	return result.NewItem(
+		result.Name("Decrypted data"),
+		result.Value(check+": ok"),
+		result.Note(fmt.Sprintf("%d bytes", len(plain))),
+	)
+}
+
+//decryptedSessionKey returns result of decrypting session key as result.Item, and sets session key to context for following encrypted data packets.
+func decryptedSessionKey(cxt *context.Context, sym values.SymID, key []byte, err error) *result.Item {
+	if err != nil {
+		return result.NewItem(
+			result.Name("Decrypted session key"),
+			result.Value(decryptionError(err)),
+		)
+	}
+	cxt.SetSessionKey(&context.SessionKey{SymID: sym, Key: key})
+	dump := maskedSessionKey
+	if cxt.ShowSecrets() {
+		dump = values.DumpBytes(key, true).String()
+	}
+	if sym == 0 {
+		//version 6 packet has no symmetric algorithm
+		return result.NewItem(
+			result.Name("Decrypted session key"),
+			result.Note("sym alg is specified in sym. encrypted integrity protected data"),
+			result.DumpStr(dump),
+		)
+	}
+	return result.NewItem(
+		result.Name("Decrypted session key"),
+		result.Value(sym.String()),
+		result.DumpStr(dump),
+	)
+}
+
+//decryptionError returns message of decryption error
+func decryptionError(err error) string {
+	for _, e := range []error{
+		ecode.ErrSessionKey,
+		ecode.ErrIntegrity,
+		ecode.ErrUnsupportedAlg,
+		ecode.ErrNoSecretKey,
+		ecode.ErrPassphrase,
+		ecode.ErrNeedPassphrase,
+		ecode.ErrDivertToCard,
+		ecode.ErrDummyKey,
+		ecode.ErrKeyMismatch,
+	} {
+		if errs.Is(err, e) {
+			return e.Error()
+		}
+	}
+	return err.Error()
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,92 @@
+package tags
+
+import (
+	"io"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+const maxHeaderLen = 6
+
+//headerReader class is io.Reader for recording packet header
+type headerReader struct {
+	r      io.Reader
+	offset int64
+	buf    []byte
+}
+
+func newHeaderReader(r io.Reader) *headerReader {
+	return &headerReader{r: r, buf: make([]byte, 0, maxHeaderLen)}
+}
+
+//Read returns []byte data (io.Reader compatible)
+func (hr *headerReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	if rest := maxHeaderLen - len(hr.buf); n > 0 && rest > 0 {
+		if rest > n {
+			rest = n
+		}
+		hr.buf = append(hr.buf, p[:rest]...)
+	}
+	hr.offset += int64(n)
+	return n, err
+}
+
+//mark starts recording of next packet header, and returns offset of it.
+func (hr *headerReader) mark() int64 {
+	hr.buf = hr.buf[:0]
+	return hr.offset
+}
+
+//header returns header information of packet
+func (hr *headerReader) header(offset int64, bodyLen int) *result.Header {
+	if len(hr.buf) == 0 {
+		return nil
+	}
+	h := &result.Header{Offset: offset, CTB: hr.buf[0], BodyLen: int64(bodyLen)}
+	if h.NewFormat() {
+		h.HeaderLen = 2
+		if len(hr.buf) > 1 {
+			switch l := hr.buf[1]; {
+			case l < 192:
+			case l < 224:
+				h.HeaderLen = 3
+			case l == 255:
+				h.HeaderLen = 6
+			default:
+				h.Partial = true
+			}
+		}
+	} else {
+		switch h.CTB & 0x03 {
+		case 0:
+			h.HeaderLen = 2
+		case 1:
+			h.HeaderLen = 3
+		case 2:
+			h.HeaderLen = 5
+		default:
+			h.HeaderLen = 1
+			h.Partial = true //indeterminate length
+		}
+	}
+	if h.HeaderLen <= len(hr.buf) {
+		h.Octets = append([]byte{}, hr.buf[:h.HeaderLen]...)
+	}
+	return h
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -11,32 +11,54 @@ import (
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 )
 
+//nestedPackets is interface of packet containing other packets (compressed or decrypted data)
+type nestedPackets interface {
+	Reader() io.Reader
+}
+
 //Packets class is context data for OpenPGP packets
 type Packets struct {
 	cxt          *context.Context
+	hr           *headerReader
 	opaqueReader *packet.OpaqueReader
 	tag          Tags
+	header       *result.Header
 }
 
 func NewPackets(cxt *context.Context, reader io.Reader) (*Packets, error) {
 	if reader == nil {
 		return nil, errs.Wrap(ecode.ErrNullPointer)
 	}
-	return &Packets{cxt: cxt, opaqueReader: packet.NewOpaqueReader(reader), tag: nil}, nil
+	hr := newHeaderReader(reader)
+	return &Packets{cxt: cxt, hr: hr, opaqueReader: packet.NewOpaqueReader(hr), tag: nil}, nil
 }
 
 func (p *Packets) Next() error {
 	if p == nil {
 		return errs.Wrap(ecode.ErrNullPointer)
 	}
+	if err := p.cxt.Err(); err != nil {
+		return errs.Wrap(err)
+	}
+	offset := p.hr.mark()
 	op, err := p.opaqueReader.Next()
 	if err != nil {
 		return errs.Wrap(err)
 	}
 	p.tag = NewTag(op, p.cxt)
+	p.header = p.hr.header(offset, len(op.Contents))
 	return nil
 }
 
+//parseTag parses current packet, and returns result with packet header.
+func (p *Packets) parseTag() (*result.Item, error) {
+	item, err := p.tag.Parse()
+	if item != nil && item.Header == nil {
+		item.Header = p.header
+	}
+	return item, err
+}
+
 func (p *Packets) Parse() (*result.Item, error) {
 	if p == nil {
 		return nil, errs.Wrap(ecode.ErrNullPointer)
@@ -49,12 +71,12 @@ func (p *Packets) Parse() (*result.Item, error) {
 			return nil, nil
 		}
 	}
-	item, err := p.tag.Parse()
+	item, err := p.parseTag()
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
 	switch t := p.tag.(type) {
-	case *Tag08: //Compressed Data Packet
+	case nestedPackets: //Compressed Data Packet and decrypted data packets
 		if r := t.Reader(); r != nil {
 			sp, err := NewPackets(p.cxt, r)
 			if err != nil {
@@ -67,7 +89,7 @@ func (p *Packets) Parse() (*result.Item, error) {
 					}
 					break
 				}
-				itm, err := sp.tag.Parse()
+				itm, err := sp.Parse()
 				if err != nil {
 					return item, errs.Wrap(err)
 				}
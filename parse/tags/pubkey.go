@@ -3,6 +3,7 @@ package tags
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/spiegel-im-spiegel/errs"
@@ -119,12 +120,22 @@ func (p *pubkeyInfo) parseV5(parent *result.Item) error {
 		return errs.New("illegal key material data size", errs.WithCause(err))
 	}
 	sz64 := int64(binary.BigEndian.Uint32(sz))
-	b, err := p.reader.ReadBytes(sz64)
-	if err != nil {
+	start := p.reader.Size() - p.reader.Rest()
+	if _, err := p.reader.ReadBytes(sz64); err != nil {
 		return errs.New(fmt.Sprintf("illegal key material data (size: %d bytes)", sz64), errs.WithCause(err))
 	}
 	// [10] series of multiprecision integers comprising the key material.
-	return pubkey.New(p.cxt, p.pubID, reader.New(b)).ParsePub(parent) //TODO: new logic for key material
+	//(parsed in place, so spans of items are offsets in packet)
+	r := reader.New(p.reader.GetBody()[:start+sz64])
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return errs.Wrap(err)
+	}
+	material := result.NewItem(result.Track(r))
+	err = pubkey.New(p.cxt, p.pubID, r).ParsePub(material)
+	for _, item := range material.Items {
+		parent.Add(item)
+	}
+	return errs.Wrap(err)
 }
 
 //PubID returns pubID
@@ -0,0 +1,125 @@
+package tags
+
+import (
+	"sync"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//NotationFunc is function value of decoding value in Notation Data Sub-packet (sub 20)
+type NotationFunc func(cxt *context.Context, value []byte, humanReadable bool) (*result.Item, error)
+
+var (
+	registryMutex       sync.RWMutex
+	registeredTags      = FuncMap{}
+	registeredSubs02    = SubFuncMap{}
+	registeredSubs17    = SubFuncMap{}
+	registeredNotations = map[string]NotationFunc{}
+)
+
+//RegisterTag registers NewPacket function for packet tag.
+//Registered function takes precedence over built-in parser (nil function is ignored).
+func RegisterTag(tag int, fn NewPacket) {
+	if fn == nil {
+		return
+	}
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registeredTags[tag] = fn
+}
+
+//UnregisterTag removes NewPacket function of packet tag.
+func UnregisterTag(tag int) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	delete(registeredTags, tag)
+}
+
+//RegisterSubpacket registers NewSubpacket function for sub-packet type in Signature Packet (tag 2).
+//Registered function takes precedence over built-in parser (nil function is ignored).
+func RegisterSubpacket(sub int, fn NewSubpacket) {
+	registerSubpacket(registeredSubs02, sub, fn)
+}
+
+//UnregisterSubpacket removes NewSubpacket function of sub-packet type in Signature Packet (tag 2).
+func UnregisterSubpacket(sub int) {
+	unregisterSubpacket(registeredSubs02, sub)
+}
+
+//RegisterAttrSubpacket registers NewSubpacket function for sub-packet type in User Attribute Packet (tag 17).
+//Registered function takes precedence over built-in parser (nil function is ignored).
+func RegisterAttrSubpacket(sub int, fn NewSubpacket) {
+	registerSubpacket(registeredSubs17, sub, fn)
+}
+
+//UnregisterAttrSubpacket removes NewSubpacket function of sub-packet type in User Attribute Packet (tag 17).
+func UnregisterAttrSubpacket(sub int) {
+	unregisterSubpacket(registeredSubs17, sub)
+}
+
+func registerSubpacket(fm SubFuncMap, sub int, fn NewSubpacket) {
+	if fn == nil {
+		return
+	}
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	fm[sub] = fn
+}
+
+func unregisterSubpacket(fm SubFuncMap, sub int) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	delete(fm, sub)
+}
+
+//RegisterNotation registers NotationFunc function for name of notation (ex. "name@example.com").
+//nil function is ignored.
+func RegisterNotation(name string, fn NotationFunc) {
+	if fn == nil {
+		return
+	}
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registeredNotations[name] = fn
+}
+
+//UnregisterNotation removes NotationFunc function of name of notation.
+func UnregisterNotation(name string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	delete(registeredNotations, name)
+}
+
+func registeredTag(tag int) NewPacket {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return registeredTags.Get(tag, nil)
+}
+
+func registeredSubpacket(fm SubFuncMap, sub int) NewSubpacket {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return fm.Get(sub, nil)
+}
+
+func registeredNotation(name string) NotationFunc {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return registeredNotations[name]
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
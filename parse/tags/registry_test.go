@@ -0,0 +1,149 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+//tagTest class for registry test
+type tagTest struct {
+	tagInfo
+}
+
+func newTagTest(cxt *context.Context, tag values.TagID, body []byte) Tags {
+	return &tagTest{tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
+}
+
+func (t *tagTest) Parse() (*result.Item, error) {
+	rootInfo := t.ToItem()
+	b, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, err
+	}
+	rootInfo.Add(result.NewItem(result.Name("Test Value"), result.Value(values.DumpByteString(b, true))))
+	return rootInfo, nil
+}
+
+//subTest class for registry test
+type subTest struct {
+	subInfo
+}
+
+func newSubTest(cxt *context.Context, subID values.SuboacketID, body []byte) Subs {
+	return &subTest{subInfo{cxt: cxt, subID: subID, reader: reader.New(body)}}
+}
+
+func (s *subTest) Parse() (*result.Item, error) {
+	rootInfo := s.ToItem()
+	rootInfo.Value = string(s.reader.GetBody())
+	return rootInfo, nil
+}
+
+var sub20BodyTest = []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x02, 0x74, 0x65, 0x73, 0x74, 0x40, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x01, 0x02}
+
+func TestRegistry(t *testing.T) {
+	values.RegisterTagName(61, "Test Packet")
+	RegisterTag(61, newTagTest)
+	values.RegisterSubpacketName(101, "Test Sub-packet")
+	RegisterSubpacket(101, newSubTest)
+	RegisterNotation("test@example.com", func(cxt *context.Context, value []byte, humanReadable bool) (*result.Item, error) {
+		return result.NewItem(result.Name("Test Notation"), result.Value(values.DumpBytes(value, true).String())), nil
+	})
+	defer func() {
+		values.RegisterTagName(61, "Private or Experimental Values")
+		UnregisterTag(61)
+		UnregisterSubpacket(101)
+		UnregisterNotation("test@example.com")
+	}()
+	cxt := context.New()
+
+	i, err := NewTag(&packet.OpaquePacket{Tag: 61, Contents: []byte{0x01, 0x02}}, cxt).Parse()
+	if err != nil {
+		t.Errorf("NewTag() = %v, want nil error.", err)
+		return
+	}
+	res := "Test Packet (tag 61) (2 bytes)\n\tTest Value: 01\n"
+	if str := i.String(); str != res {
+		t.Errorf("NewTag() = \"%v\", want \"%v\".", str, res)
+	}
+
+	i, err = NewSubs(cxt, &packet.OpaqueSubpacket{SubType: 101, Contents: []byte("foo")}, 2).Parse()
+	if err != nil {
+		t.Errorf("NewSubs() = %v, want nil error.", err)
+		return
+	}
+	res = "Test Sub-packet (sub 101): foo (3 bytes)\n"
+	if str := i.String(); str != res {
+		t.Errorf("NewSubs() = \"%v\", want \"%v\".", str, res)
+	}
+
+	i, err = NewSubs(cxt, &packet.OpaqueSubpacket{SubType: 20, Contents: sub20BodyTest}, 2).Parse()
+	if err != nil {
+		t.Errorf("NewSubs() = %v, want nil error.", err)
+		return
+	}
+	res = "Notation Data (sub 20) (26 bytes)\n\tFlag: Human-readable\n\tName: test@example.com\n\tTest Notation: 01 02\n"
+	if str := i.String(); str != res {
+		t.Errorf("NewSubs() = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	RegisterTag(61, newTagTest)
+	RegisterSubpacket(101, newSubTest)
+	RegisterAttrSubpacket(101, newSubTest)
+	RegisterNotation("test@example.com", func(cxt *context.Context, value []byte, humanReadable bool) (*result.Item, error) {
+		return result.NewItem(result.Name("Test Notation")), nil
+	})
+	UnregisterTag(61)
+	UnregisterSubpacket(101)
+	UnregisterAttrSubpacket(101)
+	UnregisterNotation("test@example.com")
+	RegisterTag(61, nil)
+	RegisterSubpacket(101, nil)
+	RegisterAttrSubpacket(101, nil)
+	RegisterNotation("test@example.com", nil)
+	if fn := registeredTag(61); fn != nil {
+		t.Error("registeredTag() = function, want nil.")
+	}
+	if fn := registeredSubpacket(registeredSubs02, 101); fn != nil {
+		t.Error("registeredSubpacket() = function, want nil.")
+	}
+	if fn := registeredSubpacket(registeredSubs17, 101); fn != nil {
+		t.Error("registeredSubpacket() = function, want nil.")
+	}
+	if fn := registeredNotation("test@example.com"); fn != nil {
+		t.Error("registeredNotation() = function, want nil.")
+	}
+	cxt := context.New()
+
+	i, err := NewTag(&packet.OpaquePacket{Tag: 61, Contents: []byte{0x01, 0x02}}, cxt).Parse()
+	if err != nil {
+		t.Errorf("NewTag() = %v, want nil error.", err)
+		return
+	}
+	res := "Private or Experimental Values (tag 61) (2 bytes)\n"
+	if str := i.String(); str != res {
+		t.Errorf("NewTag() = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/keyring"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/pubkey"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
@@ -19,8 +20,14 @@ type seckeyInfo struct {
 	reader *reader.Reader
 	pubVer *values.Version
 	pubID  values.PubID
+	usage  byte
+	//range of plain secret-key material in packet body
+	secStart, secEnd int64
 }
 
+//maskedDump is dump string of masked secret-key material
+const maskedDump = "(masked secret-key material)"
+
 //newSeckey returns seckeyInfo instance
 func newSeckey(cxt *context.Context, reader *reader.Reader, pubVer *values.Version, pubID values.PubID) *seckeyInfo {
 	return &seckeyInfo{cxt: cxt, reader: reader, pubVer: pubVer, pubID: pubID}
@@ -32,12 +39,14 @@ func (p *seckeyInfo) Parse(parent *result.Item) error {
 	if err != nil {
 		return errs.New("illegal s2k usage", errs.WithCause(err))
 	}
+	p.usage = usage
 
 	if rOpt, err := p.getField1(); err != nil {
 		return err
 	} else if rOpt != nil {
 		//[Optional] If string-to-key usage octet was 255, 254, or 253, a one-octet symmetric encryption algorithm.
 		var symid values.SymID
+		var aeadid values.AEADID
 		if usage != 0 { //encrypted secret-key
 			alg, err := rOpt.ReadByte()
 			if err != nil {
@@ -60,7 +69,8 @@ func (p *seckeyInfo) Parse(parent *result.Item) error {
 					errs.WithCause(err),
 				)
 			}
-			parent.Add(values.AEADID(alg).ToItem(p.cxt.Debug()))
+			aeadid = values.AEADID(alg)
+			parent.Add(aeadid.ToItem(p.cxt.Debug()))
 		}
 		//[Optional] If string-to-key usage octet was 255, 254, or 253, a string-to-key specifier.
 		hasIV := false
@@ -81,7 +91,7 @@ func (p *seckeyInfo) Parse(parent *result.Item) error {
 		}
 		//[Optional] If secret data is encrypted (string-to-key usage octet not zero), an Initial Vector (IV) of the same length as the cipher's block size.
 		if usage != 0 && hasIV {
-			iv, err := p.iv(values.SymID(symid), usage == 253)
+			iv, err := p.iv(symid, aeadid, usage == 253)
 			if err != nil {
 				return err
 			}
@@ -101,10 +111,21 @@ func (p *seckeyInfo) Parse(parent *result.Item) error {
 		switch usage {
 		case 0:
 			parent.Note = "s2k usage 0; plain secret-key material"
+			base := int64(0)
+			if p.pubVer.Number() == 5 {
+				base = p.reader.Size() - p.reader.Rest() - rOpt.Size()
+			}
+			p.secStart, p.secEnd = base+rOpt.Size()-rOpt.Rest(), base+rOpt.Size()
 			//parse plain key material
-			if err := pubkey.New(p.cxt, p.pubID, rOpt).ParseSecPlain(parent); err != nil {
+			n := len(parent.Items)
+			err := pubkey.New(p.cxt, p.pubID, rOpt).ParseSecPlain(parent)
+			if !p.cxt.ShowSecrets() {
+				maskItems(parent.Items[n:])
+			}
+			if err != nil {
 				return errs.Wrap(err, errs.WithContext("s2k_usage", usage))
 			}
+			p.secEnd = base + rOpt.Size() - rOpt.Rest()
 			//checksum
 			chk, err := p.reader.ReadBytes(2)
 			if err != nil {
@@ -152,6 +173,106 @@ func (p *seckeyInfo) Parse(parent *result.Item) error {
 	return nil
 }
 
+//Encrypted returns true if secret-key material is encrypted
+func (p *seckeyInfo) Encrypted() bool {
+	return p.usage != 0
+}
+
+//Decrypt unlocks encrypted secret-key material in packet body by passphrase, and returns plain secret-key material and result of checking key pair as result.Item
+func (p *seckeyInfo) Decrypt(tag values.TagID) *result.Item {
+	key, err := keyring.ParseSecretKey(tag, p.reader.GetBody())
+	if err == nil {
+		err = key.Unlock(p.cxt.Passphrase())
+	}
+	if err != nil {
+		return result.NewItem(
+			result.Name("Decrypted secret-key material"),
+			result.Value(decryptionError(err)),
+		)
+	}
+	check := "checksum"
+	switch p.usage {
+	case 253:
+		check = "authentication tag"
+	case 254:
+		check = "SHA-1"
+	}
+	secret := key.Secret()
+	item := result.NewItem(
+		result.Name("Decrypted secret-key material"),
+		result.Value(check+": ok"),
+		result.Note(fmt.Sprintf("%d bytes", len(secret))),
+	)
+	err = pubkey.New(p.cxt, p.pubID, reader.New(secret)).ParseSecPlain(item)
+	if !p.cxt.ShowSecrets() {
+		maskItems(item.Items)
+	}
+	if err == nil {
+		err = key.Verify()
+	}
+	res := "ok"
+	if err != nil {
+		res = decryptionError(err)
+	}
+	item.Add(result.NewItem(
+		result.Name("Key pair check"),
+		result.Value(res),
+	))
+	return item
+}
+
+//Mask replaces plain secret-key material in raw data and dump of packet item by zero.
+//Length prefixes of multi-precision integers and notes ("n bits") of items are kept, so renderers take bit lengths from notes of items, not from masked values.
+func (p *seckeyInfo) Mask(item *result.Item) {
+	if item == nil || p.secStart >= p.secEnd || p.secEnd > int64(len(item.Raw)) {
+		return
+	}
+	raw := make([]byte, len(item.Raw))
+	copy(raw, item.Raw)
+	maskMPIs(raw[p.secStart:p.secEnd])
+	item.Raw = raw
+	if len(item.Dump) > 0 {
+		item.Dump = values.DumpBytes(raw, true).String()
+	}
+}
+
+//maskMPIs sets zero to values of multi-precision integers in data (all octets are set zero if data is not list of multi-precision integers)
+func maskMPIs(data []byte) {
+	for pos := 0; pos < len(data); {
+		if pos+2 > len(data) {
+			zeroBytes(data)
+			return
+		}
+		l := (int(binary.BigEndian.Uint16(data[pos:])) + 7) / 8
+		if pos+2+l > len(data) {
+			zeroBytes(data)
+			return
+		}
+		zeroBytes(data[pos+2 : pos+2+l])
+		pos += 2 + l
+	}
+}
+
+//zeroBytes sets zero to all octets in data
+func zeroBytes(data []byte) {
+	for i := range data {
+		data[i] = 0
+	}
+}
+
+//maskItems replaces dumps of secret-key material in items
+func maskItems(items []*result.Item) {
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if len(item.Dump) > 0 {
+			item.Dump = maskedDump
+		}
+		maskItems(item.Items)
+	}
+}
+
 //getField1 returns reader.Reader for optional fields
 func (p *seckeyInfo) getField1() (*reader.Reader, error) {
 	if p.pubVer.Number() == 5 {
@@ -192,8 +313,11 @@ func (p *seckeyInfo) getField2() (*reader.Reader, error) {
 }
 
 //iv returns context.Item for Initialization Vector
-func (p *seckeyInfo) iv(symid values.SymID, isAEAD bool) (*result.Item, error) {
+func (p *seckeyInfo) iv(symid values.SymID, aeadid values.AEADID, isAEAD bool) (*result.Item, error) {
 	sz64 := int64(symid.IVLen())
+	if isAEAD {
+		sz64 = int64(aeadid.IVLen())
+	}
 	iv, err := p.reader.ReadBytes(sz64)
 	if err != nil {
 		return nil, errs.New(fmt.Sprintf("illegal s2k iv (length: %d bytes)", sz64), errs.WithCause(err))
@@ -52,7 +52,14 @@ func (s *sub20) Parse() (*result.Item, error) {
 	if err != nil {
 		return rootInfo, errs.New(fmt.Sprintf("illegal value (length: %d bytes)", valueLength), errs.WithCause(err))
 	}
-	if human != 0x00 {
+	if f := registeredNotation(string(name)); f != nil {
+		//registered decoder
+		itm, err := f(s.cxt, value, human != 0x00)
+		if err != nil {
+			return rootInfo, errs.New(fmt.Sprintf("illegal value of notation %q", name), errs.WithCause(err))
+		}
+		rootInfo.Add(itm)
+	} else if human != 0x00 {
 		//human readable data (text)
 		rootInfo.Add(values.NewText(value, "Value").ToItem(s.cxt.Debug()))
 	} else {
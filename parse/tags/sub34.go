@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
@@ -20,7 +21,11 @@ func newSub34(cxt *context.Context, subID values.SuboacketID, body []byte) Subs
 // Parse parsing Preferred AEAD Algorithms Sub-packet
 func (s *sub34) Parse() (*result.Item, error) {
 	rootInfo := s.ToItem()
-	for _, alg := range s.reader.GetBody() {
+	for s.reader.Rest() > 0 {
+		alg, err := s.reader.ReadByte()
+		if err != nil {
+			return rootInfo, errs.Wrap(err)
+		}
 		rootInfo.Add(values.AEADID(alg).ToItem(s.cxt.Debug()))
 	}
 	return rootInfo, nil
@@ -58,7 +58,7 @@ func (s *sub38) parseKeyData(rootInfo *result.Item) error {
 			}
 			break
 		}
-		itm, err := sp.tag.Parse()
+		itm, err := sp.parseTag()
 		if err != nil {
 			return errs.Wrap(err)
 		}
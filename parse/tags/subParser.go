@@ -20,11 +20,13 @@ type subParser struct {
 }
 
 //newSubparser returns subParser for parsing packet
-func newSubparser(cxt *context.Context, tagID values.TagID, name string, body []byte) (*subParser, error) {
+func newSubparser(cxt *context.Context, tagID values.TagID, name string, kind result.Kind, body []byte) (*subParser, error) {
 	item := result.NewItem(
 		result.Name(name),
 		result.Note(fmt.Sprintf("%d bytes", len(body))),
 		result.DumpStr(values.DumpBytes(body, cxt.Debug()).String()),
+		result.Meta(kind, 0),
+		result.Raw(body),
 	)
 	osps, err := packet.OpaqueSubpackets(body)
 	return &subParser{cxt: cxt, tagID: tagID, opaqueSubpacke: osps, item: item}, errs.Wrap(err)
@@ -39,6 +41,9 @@ func (sp *subParser) Parse() (*result.Item, error) {
 			lastErr = err
 			break
 		}
+		if item != nil && item.Kind == result.KindNone {
+			item.Kind, item.Code, item.Raw = result.KindSubpacket, int(osp.SubType), osp.Contents
+		}
 		sp.item.Add(item)
 	}
 	return sp.item, lastErr
@@ -79,6 +79,9 @@ var newFunctionsSub17 = SubFuncMap{
 func NewSubs(cxt *context.Context, osp *packet.OpaqueSubpacket, tagID values.TagID) Subs {
 	st := osp.SubType & 0x7f
 	if tagID == 2 {
+		if f := registeredSubpacket(registeredSubs02, int(st)); f != nil {
+			return f(cxt, values.SuboacketID(osp.SubType), osp.Contents)
+		}
 		switch st {
 		case 32:
 			// recursive call in sub32.Parse()
@@ -90,6 +93,9 @@ func NewSubs(cxt *context.Context, osp *packet.OpaqueSubpacket, tagID values.Tag
 			return newFunctionsSub02.Get(int(st), newSubReserved)(cxt, values.SuboacketID(osp.SubType), osp.Contents)
 		}
 	} else if tagID == 17 {
+		if f := registeredSubpacket(registeredSubs17, int(st)); f != nil {
+			return f(cxt, values.SuboacketID(osp.SubType), osp.Contents)
+		}
 		return newFunctionsSub17.Get(int(st), newSubReserved)(cxt, values.SuboacketID(osp.SubType), osp.Contents)
 	}
 	return nil
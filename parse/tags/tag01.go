@@ -1,8 +1,14 @@
 package tags
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
 	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/keyring"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/pubkey"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
@@ -30,12 +36,18 @@ func (t *tag01) Parse() (*result.Item, error) {
 		return rootInfo, errs.New("illegal version", errs.WithCause(err))
 	}
 	rootInfo.Add(values.PubSessKeyVer(ver).ToItem(t.cxt.Debug()))
-	// [01] eight-octet number that gives the Key ID of the public key to which the session key is encrypted.
-	keyid, err := t.reader.ReadBytes(8)
-	if err != nil {
-		return rootInfo, errs.New("illegal keyid", errs.WithCause(err))
+	var id []byte //key ID (version 3) or fingerprint (version 6) of recipient
+	if ver == 6 {
+		if id, err = t.recipient(rootInfo); err != nil {
+			return rootInfo, err
+		}
+	} else {
+		// [01] eight-octet number that gives the Key ID of the public key to which the session key is encrypted.
+		if id, err = t.reader.ReadBytes(8); err != nil {
+			return rootInfo, errs.New("illegal keyid", errs.WithCause(err))
+		}
+		rootInfo.Add(values.NewKeyID(id).ToItem())
 	}
-	rootInfo.Add(values.NewKeyID(keyid).ToItem())
 	// [09] one-octet number giving the public-key algorithm used.
 	pubid, err := t.reader.ReadByte()
 	if err != nil {
@@ -43,9 +55,14 @@ func (t *tag01) Parse() (*result.Item, error) {
 	}
 	rootInfo.Add(values.PubID(pubid).ToItem(t.cxt.Debug()))
 	// [10] string of octets that is the encrypted session key.
-	if err := pubkey.New(t.cxt, values.PubID(pubid), t.reader).ParseSes(rootInfo); err != nil {
+	start := t.reader.Size() - t.reader.Rest()
+	if err := pubkey.New(t.cxt, values.PubID(pubid), t.reader).ParseSes(rootInfo, ver); err != nil {
 		return rootInfo, errs.Wrap(err)
 	}
+	if keys := t.cxt.SecretKeys(); len(keys) > 0 {
+		sym, key, err := t.sessionKey(keys, ver, id, t.reader.GetBody()[start:t.reader.Size()-t.reader.Rest()])
+		rootInfo.Add(decryptedSessionKey(t.cxt, sym, key, err))
+	}
 
 	if t.reader.Rest() > 0 {
 		rootInfo.Add(values.RawData(t.reader, "Unknown data", t.cxt.Debug()))
@@ -53,6 +70,77 @@ func (t *tag01) Parse() (*result.Item, error) {
 	return rootInfo, nil
 }
 
+//recipient parses key version and fingerprint of recipient in version 6 packet, and returns fingerprint (empty if anonymous recipient)
+func (t *tag01) recipient(rootInfo *result.Item) ([]byte, error) {
+	// one-octet size of the following two fields (zero for anonymous recipient)
+	size, err := t.reader.ReadByte()
+	if err != nil {
+		return nil, errs.New("illegal size of recipient", errs.WithCause(err))
+	}
+	if size == 0 {
+		rootInfo.Add(result.NewItem(
+			result.Name("Fingerprint"),
+			result.Value("anonymous recipient"),
+			result.DumpStr(values.DumpByteString(size, t.cxt.Debug())),
+		))
+		return []byte{}, nil
+	}
+	// one-octet key version number
+	kver, err := t.reader.ReadByte()
+	if err != nil {
+		return nil, errs.New("illegal key version", errs.WithCause(err))
+	}
+	itm := result.NewItem(
+		result.Name("Key version"),
+		result.Value(strconv.Itoa(int(kver))),
+		result.DumpStr(values.DumpByteString(kver, t.cxt.Debug())),
+	)
+	switch kver {
+	case 4:
+		itm.Note = "need 20 octets length"
+	case 6:
+		itm.Note = "need 32 octets length"
+	default:
+		itm.Note = values.Unknown
+	}
+	rootInfo.Add(itm)
+	// fingerprint of the key to which the session key is encrypted
+	fp, err := t.reader.ReadBytes(int64(size) - 1)
+	if err != nil {
+		return nil, errs.New("illegal fingerprint", errs.WithCause(err))
+	}
+	rootInfo.Add(result.NewItem(
+		result.Name("Fingerprint"),
+		result.Note(fmt.Sprintf("%d bytes", len(fp))),
+		result.DumpStr(values.DumpBytes(fp, true).String()),
+	))
+	return fp, nil
+}
+
+//sessionKey returns session key decrypted by secret key matching key ID or fingerprint (wildcard key ID and anonymous recipient match all secret keys)
+func (t *tag01) sessionKey(keys []*keyring.SecretKey, ver byte, id, data []byte) (values.SymID, []byte, error) {
+	var err error
+	if ver == 6 {
+		err = errs.Wrap(ecode.ErrNoSecretKey, errs.WithContext("fingerprint", hex.EncodeToString(id)))
+	} else {
+		err = errs.Wrap(ecode.ErrNoSecretKey, errs.WithContext("keyid", values.NewKeyID(id).String()))
+	}
+	for _, key := range keys {
+		if !key.Match(id) {
+			continue
+		}
+		if err = key.Unlock(t.cxt.Passphrase()); err != nil {
+			continue
+		}
+		sym, sk, e := key.DecryptSessionKey(ver, data)
+		if e == nil {
+			return sym, sk, nil
+		}
+		err = e
+	}
+	return 0, nil, err
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -156,7 +156,7 @@ func (t *tag02) parseV4(rootInfo *result.Item) (*result.Item, error) {
 		if err != nil {
 			return rootInfo, errs.New(fmt.Sprintf("illegal hashed subpacket (size: %d bytes)", int64(sizeHS)), errs.WithCause(err))
 		}
-		subpcket, err := newSubparser(t.cxt, t.tag, "Hashed Subpacket", sp)
+		subpcket, err := newSubparser(t.cxt, t.tag, "Hashed Subpacket", result.KindHashedArea, sp)
 		if err != nil {
 			return rootInfo, errs.New("illegal subpacket", errs.WithCause(err))
 		}
@@ -178,7 +178,7 @@ func (t *tag02) parseV4(rootInfo *result.Item) (*result.Item, error) {
 		if err != nil {
 			return rootInfo, errs.New(fmt.Sprintf("illegal unhashed subpacket (size: %d bytes)", int64(sizeUS)), errs.WithCause(err))
 		}
-		subpcket, err := newSubparser(t.cxt, t.tag, "Unhashed Subpacket", sp)
+		subpcket, err := newSubparser(t.cxt, t.tag, "Unhashed Subpacket", result.KindUnhashedArea, sp)
 		if err != nil {
 			return rootInfo, errs.New("illegal subpacket", errs.WithCause(err))
 		}
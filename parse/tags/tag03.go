@@ -1,8 +1,12 @@
 package tags
 
 import (
+	"fmt"
+
 	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/s2k"
@@ -56,26 +60,101 @@ func (t *tag03) parseV4(rootInfo *result.Item) (*result.Item, error) {
 	if err != nil {
 		return rootInfo, errs.New("illegal symid", errs.WithCause(err))
 	}
-	rootInfo.Add(values.SymID(symid).ToItem(t.cxt.Debug()))
+	sym := values.SymID(symid)
+	rootInfo.Add(sym.ToItem(t.cxt.Debug()))
 	// [02] string-to-key (S2K) specifier
 	s2k := s2k.New(t.reader)
 	if err := s2k.Parse(rootInfo, t.cxt.Debug()); err != nil {
 		return rootInfo, errs.New("illegal s2k", errs.WithCause(err))
 	}
+	// [NN] optionally, the encrypted session key itself, which is decrypted with the string-to-key object.
+	var esk []byte
+	if t.reader.Rest() > 0 {
+		itm := values.RawData(t.reader, "Encrypted session key", t.cxt.Debug())
+		esk, err = t.reader.Read2EOF()
+		if err != nil {
+			return rootInfo, errs.New("illegal encrypted session key", errs.WithCause(err))
+		}
+		rootInfo.Add(itm)
+	}
+	if passphrase := t.cxt.Passphrase(); passphrase != nil {
+		kek, err := t.kek(s2k, sym, passphrase)
+		if err == nil && esk == nil {
+			//S2K-derived key is session key
+			rootInfo.Add(decryptedSessionKey(t.cxt, sym, kek, nil))
+		} else if err == nil {
+			symid, key, err := decrypt.SKESK4(sym, kek, esk)
+			rootInfo.Add(decryptedSessionKey(t.cxt, symid, key, err))
+		} else {
+			rootInfo.Add(decryptedSessionKey(t.cxt, 0, nil, err))
+		}
+	}
 	return rootInfo, nil
 }
 
 func (t *tag03) parseV5(rootInfo *result.Item) (*result.Item, error) {
 	// [00] one-octet version number
 	// [01] one-octet cipher algorithm.
+	symid, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal symid", errs.WithCause(err))
+	}
+	sym := values.SymID(symid)
+	rootInfo.Add(sym.ToItem(t.cxt.Debug()))
 	// [02] one-octet AEAD algorithm.
+	aeadid, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal aeadid", errs.WithCause(err))
+	}
+	aead := values.AEADID(aeadid)
+	rootInfo.Add(aead.ToItem(t.cxt.Debug()))
 	// [03] string-to-key (S2K) specifier
+	s2k := s2k.New(t.reader)
+	if err := s2k.Parse(rootInfo, t.cxt.Debug()); err != nil {
+		return rootInfo, errs.New("illegal s2k", errs.WithCause(err))
+	}
 	// [NN] A starting initialization vector of size specified by the AEAD algorithm.
+	sz64 := int64(aead.IVLen())
+	iv, err := t.reader.ReadBytes(sz64)
+	if err != nil {
+		return rootInfo, errs.New(fmt.Sprintf("illegal initialization vector (length: %d bytes)", sz64), errs.WithCause(err))
+	}
+	rootInfo.Add(result.NewItem(
+		result.Name("IV"),
+		result.DumpStr(values.DumpBytes(iv, true).String()),
+	))
 	// [NN] The encrypted session key itself, which is decrypted with the string-to-key object using the given cipher and AEAD mode.
 	// [NN] An authentication tag for the AEAD mode.
+	if t.reader.Rest() > 0 {
+		itm := values.RawData(t.reader, "Encrypted session key and authentication tag", t.cxt.Debug())
+		data, err := t.reader.Read2EOF()
+		if err != nil {
+			return rootInfo, errs.New("illegal encrypted session key", errs.WithCause(err))
+		}
+		rootInfo.Add(itm)
+		if passphrase := t.cxt.Passphrase(); passphrase != nil {
+			kek, err := t.kek(s2k, sym, passphrase)
+			if err == nil {
+				var key []byte
+				key, err = decrypt.SKESK5(sym, aead, iv, kek, data)
+				rootInfo.Add(decryptedSessionKey(t.cxt, sym, key, err))
+			} else {
+				rootInfo.Add(decryptedSessionKey(t.cxt, 0, nil, err))
+			}
+		}
+	}
 	return rootInfo, nil
 }
 
+//kek returns key derived from passphrase by S2K for symmetric algorithm
+func (t *tag03) kek(s2k *s2k.S2K, sym values.SymID, passphrase []byte) ([]byte, error) {
+	l := decrypt.KeyLen(sym)
+	if l == 0 {
+		return nil, errs.Wrap(ecode.ErrUnsupportedAlg, errs.WithContext("symid", sym.String()))
+	}
+	return s2k.Key(passphrase, l)
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
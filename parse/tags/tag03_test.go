@@ -12,6 +12,8 @@ import (
 var (
 	tag03Body1 = []byte{0x04, 0x03, 0x00, 0x01}
 	tag03Body2 = []byte{0x04, 0x04, 0x01, 0x03, 0xab, 0x2b, 0xb0, 0x87, 0xb4, 0x1d, 0x43, 0x48}
+	tag03Body3 = []byte{0x04, 0x03, 0x03, 0x02, 0x97, 0xce, 0x7d, 0xe9, 0x49, 0x16, 0x4a, 0x9f, 0xff}
+	tag03Body4 = []byte{0x05, 0x09, 0x01, 0x03, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x60, 0xa0, 0xa1, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7, 0xa8, 0xa9, 0xaa, 0xab, 0xac, 0xad, 0xae, 0xaf, 0xad, 0xaf, 0x98, 0x36, 0x5e, 0x26, 0xc1, 0x6e, 0xd0, 0x9a, 0xcb, 0x06, 0x0d, 0x0c, 0xb7, 0x13, 0x53, 0x9d, 0x68, 0x9e, 0xfc, 0xec, 0xb3, 0x90, 0x95, 0x10, 0xca, 0x8b, 0xae, 0xe9, 0xc5, 0x3d, 0xea, 0x3e, 0x7c, 0xb3, 0x49, 0x0f, 0xd3, 0xaa, 0x8b, 0xc5, 0x39, 0x7f, 0xed, 0x55, 0x5c, 0xf2}
 )
 
 const (
@@ -38,6 +40,49 @@ const (
 			03
 		Salt
 			ab 2b b0 87 b4 1d 43 48
+`
+	tag03Result3 = `Symmetric-Key Encrypted Session Key Packet (tag 3) (13 bytes)
+	Version: 4 (current)
+	Symmetric Algorithm: CAST5 (128 bit key, as per) (sym 3)
+	String-to-Key (S2K) Algorithm: Iterated and Salted S2K (s2k 3)
+		Hash Algorithm: SHA-1 (hash 2)
+		Salt
+			97 ce 7d e9 49 16 4a 9f
+		Count: 65011712
+			ff
+	Decrypted session key: CAST5 (128 bit key, as per) (sym 3)
+		(masked session key)
+`
+	tag03Result4 = `Symmetric-Key Encrypted Session Key Packet (tag 3) (78 bytes)
+	Version: 5 (draft)
+	Symmetric Algorithm: AES with 256-bit key (sym 9)
+	AEAD Algorithm: EAX mode (aead 1)
+	String-to-Key (S2K) Algorithm: Iterated and Salted S2K (s2k 3)
+		Hash Algorithm: SHA2-256 (hash 8)
+		Salt
+			01 02 03 04 05 06 07 08
+		Count: 65536
+			60
+	IV
+		a0 a1 a2 a3 a4 a5 a6 a7 a8 a9 aa ab ac ad ae af
+	Encrypted session key and authentication tag (48 bytes)
+	Decrypted session key: AES with 256-bit key (sym 9)
+		00 03 06 09 0c 0f 12 15 18 1b 1e 21 24 27 2a 2d 30 33 36 39 3c 3f 42 45 48 4b 4e 51 54 57 5a 5d
+`
+	tag03Result5 = `Symmetric-Key Encrypted Session Key Packet (tag 3) (78 bytes)
+	Version: 5 (draft)
+	Symmetric Algorithm: AES with 256-bit key (sym 9)
+	AEAD Algorithm: EAX mode (aead 1)
+	String-to-Key (S2K) Algorithm: Iterated and Salted S2K (s2k 3)
+		Hash Algorithm: SHA2-256 (hash 8)
+		Salt
+			01 02 03 04 05 06 07 08
+		Count: 65536
+			60
+	IV
+		a0 a1 a2 a3 a4 a5 a6 a7 a8 a9 aa ab ac ad ae af
+	Encrypted session key and authentication tag (48 bytes)
+	Decrypted session key: invalid session key
 `
 )
 
@@ -82,6 +127,38 @@ func TestTag03(t *testing.T) {
 	}
 }
 
+func TestTag03Passphrase(t *testing.T) {
+	testCases := []struct {
+		content    []byte
+		passphrase string
+		secrets    bool
+		res        string
+		sk         string
+	}{
+		{content: tag03Body3, passphrase: "pass", secrets: false, res: tag03Result3, sk: "3:79DEBF2265EB299851D31485F7E87DB5"},
+		{content: tag03Body4, passphrase: "pass", secrets: true, res: tag03Result4, sk: "9:000306090C0F1215181B1E2124272A2D303336393C3F4245484B4E5154575A5D"},
+		{content: tag03Body4, passphrase: "wrong", secrets: true, res: tag03Result5, sk: ""},
+	}
+	for _, tc := range testCases {
+		op := &packet.OpaquePacket{Tag: 3, Contents: tc.content}
+		cxt := context.New(
+			context.Set(context.SECRETS, tc.secrets),
+			context.WithPassphrase([]byte(tc.passphrase)),
+		)
+		i, err := NewTag(op, cxt).Parse()
+		if err != nil {
+			t.Errorf("NewTag() = %v, want nil error.", err)
+			return
+		}
+		if res := i.String(); res != tc.res {
+			t.Errorf("Tag.String = \"%s\", want \"%s\".", res, tc.res)
+		}
+		if sk := cxt.SessionKey().String(); sk != tc.sk {
+			t.Errorf("Context.SessionKey() = \"%s\", want \"%s\".", sk, tc.sk)
+		}
+	}
+}
+
 /* Copyright 2017,2018 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
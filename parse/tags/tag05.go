@@ -38,7 +38,15 @@ func (t *tag05) Parse() (*result.Item, error) {
 
 	sec := result.NewItem(result.Name("Secret-Key"))
 	rootInfo.Add(sec)
-	if err := newSeckey(t.cxt, t.reader, version, pubkey.PubID()).Parse(sec); err != nil {
+	seckey := newSeckey(t.cxt, t.reader, version, pubkey.PubID())
+	err = seckey.Parse(sec)
+	if err == nil && seckey.Encrypted() && t.cxt.Passphrase() != nil {
+		sec.Add(seckey.Decrypt(t.tag))
+	}
+	if !t.cxt.ShowSecrets() {
+		seckey.Mask(rootInfo)
+	}
+	if err != nil {
 		return rootInfo, errs.Wrap(err)
 	}
 
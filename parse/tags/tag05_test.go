@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
@@ -106,6 +107,44 @@ const (
 			87 67 54 a7 49 49 96 ab 11 2c a0 8e 9f 69 c2 15 65 0b ba 9a 98 77 70 11 73 cd 3b dc 9b 99 40 36
 		2-octet checksum
 			0e 5c
+`
+	tag05Masked3 = `Secret-Key Packet (tag 5) (88 bytes)
+	04 5b 1a 4e 1d 16 09 2b 06 01 04 01 da 47 0f 01 01 07 40 c6 ae d8 56 62 34 73 e7 f1 86 ff 5f 09 dd d2 c2 b5 48 bd 78 94 90 a8 d2 fd 9c fc c6 69 15 fb 86 00 00 ff 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 10 55
+	Version: 4 (current)
+		04
+	Public-Key
+		Public key creation time: 2018-06-08T09:36:29Z
+			5b 1a 4e 1d
+		Public-key Algorithm: EdDSA (pub 22)
+			16
+		ECC Curve OID: ed25519 (256bits key size)
+			2b 06 01 04 01 da 47 0f 01
+		EdDSA EC point (Native point format of the curve follows) (263 bits)
+			40 c6 ae d8 56 62 34 73 e7 f1 86 ff 5f 09 dd d2 c2 b5 48 bd 78 94 90 a8 d2 fd 9c fc c6 69 15 fb 86
+	Secret-Key (s2k usage 0; plain secret-key material)
+		EdDSA secret key (255 bits)
+			(masked secret-key material)
+		2-octet checksum
+			10 55
+`
+	tag05Masked4 = `Secret-Key Packet (tag 5) (97 bytes)
+	05 5c 91 f4 e4 16 00 00 00 2d 09 2b 06 01 04 01 da 47 0f 01 01 07 40 58 59 95 57 15 56 dc 1f fb 6d 71 35 03 d7 f9 e7 0c 24 90 4b d0 c3 dd 7e 3e f9 8a ec 7e 9b 2f 10 00 00 00 00 00 22 01 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 0e 5c
+	Version: 5 (draft)
+		05
+	Public-Key
+		Public key creation time: 2019-03-20T08:08:04Z
+			5c 91 f4 e4
+		Public-key Algorithm: EdDSA (pub 22)
+			16
+		ECC Curve OID: ed25519 (256bits key size)
+			2b 06 01 04 01 da 47 0f 01
+		EdDSA EC point (Native point format of the curve follows) (263 bits)
+			40 58 59 95 57 15 56 dc 1f fb 6d 71 35 03 d7 f9 e7 0c 24 90 4b d0 c3 dd 7e 3e f9 8a ec 7e 9b 2f 10
+	Secret-Key (s2k usage 0; plain secret-key material)
+		EdDSA secret key (256 bits)
+			(masked secret-key material)
+		2-octet checksum
+			0e 5c
 `
 )
 
@@ -132,6 +171,7 @@ func TestTag05(t *testing.T) {
 			context.Set(context.MARKER, true),
 			context.Set(context.PRIVATE, true),
 			context.Set(context.UTC, true),
+			context.Set(context.SECRETS, true),
 		)
 		if tc.ktm != nil {
 			tm, _ := values.NewDateTime(reader.New(tc.ktm), cxt.UTC())
@@ -152,6 +192,38 @@ func TestTag05(t *testing.T) {
 	}
 }
 
+func TestTag05Masked(t *testing.T) {
+	testCases := []struct {
+		content []byte
+		res     string
+	}{
+		{content: tag05Body3, res: tag05Masked3},
+		{content: tag05Body4, res: tag05Masked4},
+	}
+	for _, tc := range testCases {
+		op := &packet.OpaquePacket{Tag: 5, Contents: tc.content}
+		cxt := context.New(
+			context.Set(context.DEBUG, true),
+			context.Set(context.UTC, true),
+		)
+		i, err := NewTag(op, cxt).Parse()
+		if err != nil {
+			t.Errorf("NewTag() = %v, want nil error.", err)
+			return
+		}
+		res := i.String()
+		if res != tc.res {
+			t.Errorf("Tag.String = \"%s\", want \"%s\".", res, tc.res)
+		}
+		if bytes.Equal(i.Raw, tc.content) {
+			t.Errorf("Tag.Raw = %x, want masked data.", i.Raw)
+		}
+		if len(i.Raw) != len(tc.content) {
+			t.Errorf("len(Tag.Raw) = %v, want %v.", len(i.Raw), len(tc.content))
+		}
+	}
+}
+
 /* Copyright 2017-2019 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
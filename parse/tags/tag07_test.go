@@ -11,6 +11,7 @@ import (
 
 var (
 	tag07Body1 = []byte{0x04, 0x5b, 0x1a, 0x4e, 0x1d, 0x12, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01, 0x01, 0x07, 0x40, 0x4a, 0xfb, 0x95, 0xcb, 0x33, 0xb2, 0xd9, 0xd5, 0x76, 0x19, 0x13, 0x39, 0x81, 0x9f, 0x64, 0x9b, 0x98, 0x43, 0x39, 0xc6, 0xa5, 0xfc, 0xf6, 0xfc, 0x9c, 0x9d, 0xba, 0x0d, 0xcc, 0x9a, 0x7d, 0x7d, 0x03, 0x01, 0x0a, 0x09, 0x00, 0x00, 0xff, 0x78, 0xd6, 0x1d, 0x85, 0xa4, 0xdd, 0x46, 0x38, 0x2f, 0xd6, 0xaa, 0x70, 0x7c, 0x09, 0x8f, 0xd5, 0x5d, 0x2b, 0x1a, 0xe3, 0x3f, 0x9b, 0x28, 0xc9, 0x4c, 0x75, 0x51, 0xec, 0xbf, 0xe1, 0xd5, 0x18, 0x10, 0xd1}
+	tag07Body3 = []byte{0x04, 0x6a, 0xd6, 0x2f, 0xbf, 0x12, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01, 0x01, 0x07, 0x40, 0x37, 0x80, 0x78, 0x1d, 0x7b, 0x6a, 0x7c, 0x1e, 0x5f, 0x3d, 0x19, 0x8e, 0x38, 0x20, 0xe3, 0x9f, 0xc0, 0x30, 0xf4, 0x26, 0x29, 0x5c, 0x83, 0x00, 0x73, 0xda, 0x5d, 0xf2, 0x87, 0xcf, 0xb7, 0x03, 0x03, 0x01, 0x08, 0x07, 0xfe, 0x07, 0x03, 0x02, 0xf8, 0x45, 0x30, 0xa2, 0xa5, 0x65, 0x4a, 0x73, 0xff, 0xbc, 0xab, 0xad, 0xf4, 0x0c, 0x3a, 0x25, 0x98, 0xba, 0x99, 0xc8, 0xae, 0x47, 0x9f, 0x71, 0x3a, 0x11, 0x1c, 0xa4, 0xed, 0xfd, 0x5c, 0x13, 0xf2, 0x3c, 0x83, 0x2d, 0xeb, 0x75, 0xcf, 0xed, 0x66, 0xfa, 0xc5, 0x8d, 0xbf, 0x5f, 0x2a, 0x56, 0x28, 0x38, 0xc3, 0x5c, 0x3c, 0xfd, 0x83, 0x59, 0x17, 0x46, 0x7c, 0xeb, 0x86, 0x5b, 0x36, 0x1f, 0x4e, 0x4f, 0x54, 0x89, 0x74, 0xe2, 0x64, 0x63, 0x37, 0x09, 0x56, 0xac, 0x70, 0x3b, 0x1b}
 	tag07Body2 = []byte{0x05, 0x5c, 0x91, 0xf4, 0xe4, 0x12, 0x00, 0x00, 0x00, 0x32, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01, 0x01, 0x07, 0x40, 0xfa, 0x7c, 0xac, 0xaf, 0x39, 0xa5, 0xd9, 0x40, 0xb0, 0x78, 0x0a, 0xad, 0xa4, 0x3b, 0xa7, 0x71, 0x23, 0xe5, 0xbe, 0xb7, 0x01, 0x58, 0xa5, 0x34, 0xc9, 0xf5, 0x34, 0x62, 0xf6, 0x16, 0x58, 0x0a, 0x03, 0x01, 0x08, 0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x22, 0x00, 0xff, 0x4e, 0x74, 0x03, 0xe9, 0xa6, 0x35, 0x35, 0x5b, 0x0a, 0x6a, 0x8c, 0x82, 0x2d, 0x93, 0x1a, 0xfe, 0x54, 0xf4, 0x11, 0x4f, 0xc6, 0x66, 0xfd, 0x18, 0xb0, 0xed, 0x4d, 0xc5, 0xea, 0xfd, 0xce, 0x88, 0x11, 0x47}
 )
 
@@ -88,6 +89,7 @@ func TestTag07(t *testing.T) {
 			context.Set(context.MARKER, true),
 			context.Set(context.PRIVATE, true),
 			context.Set(context.UTC, true),
+			context.Set(context.SECRETS, true),
 		)
 		if tc.ktm != nil {
 			tm, _ := values.NewDateTime(reader.New(tc.ktm), cxt.UTC())
@@ -108,6 +110,40 @@ func TestTag07(t *testing.T) {
 	}
 }
 
+func TestTag07Decrypt(t *testing.T) {
+	testCases := []struct {
+		passphrase  []byte
+		showSecrets bool
+		res         string
+	}{
+		{passphrase: []byte("pass"), showSecrets: false, res: "Decrypted secret-key material: SHA-1: ok (34 bytes)\n\tECDH secret key (255 bits)\n\t\t(masked secret-key material)\n\tKey pair check: ok\n"},
+		{passphrase: []byte("pass"), showSecrets: true, res: "Decrypted secret-key material: SHA-1: ok (34 bytes)\n\tECDH secret key (255 bits)\n\t\t4c 50 b5 1b a7 88 92 9c 1e 0d 6f ad ce 7e 0d fc c4 9c 7b 20 b8 6e 8c af 2b 9d 31 aa 5b fd c2 70\n\tKey pair check: ok\n"},
+		{passphrase: []byte("wrong"), showSecrets: false, res: "Decrypted secret-key material: invalid passphrase\n"},
+	}
+	for _, tc := range testCases {
+		op := &packet.OpaquePacket{Tag: 7, Contents: tag07Body3}
+		cxt := context.New(
+			context.Set(context.INTEGER, true),
+			context.Set(context.SECRETS, tc.showSecrets),
+			context.WithPassphrase(tc.passphrase),
+		)
+		i, err := NewTag(op, cxt).Parse()
+		if err != nil {
+			t.Errorf("NewTag() = %v, want nil error.", err)
+			return
+		}
+		if len(i.Items) < 3 {
+			t.Errorf("Tag.Items = %d items, want Secret-Key item.", len(i.Items))
+			continue
+		}
+		sec := i.Items[2].Items
+		res := sec[len(sec)-1].String()
+		if res != tc.res {
+			t.Errorf("Tag.String = \"%s\", want \"%s\".", res, tc.res)
+		}
+	}
+}
+
 /* Copyright 2018,2019 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -1,7 +1,9 @@
 package tags
 
 import (
+	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
@@ -10,11 +12,12 @@ import (
 // tag09 class for Symmetrically Encrypted Data Packet
 type tag09 struct {
 	tagInfo
+	decrypted
 }
 
 //newTag09 return Tag01 instance
 func newTag09(cxt *context.Context, tag values.TagID, body []byte) Tags {
-	return &tag09{tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
+	return &tag09{tagInfo: tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
 }
 
 // Parse parsing Symmetrically Encrypted Data Packet
@@ -29,6 +32,15 @@ func (t *tag09) Parse() (*result.Item, error) {
 	default:
 		itm.Value = "sym alg is IDEA, simple string-to-key"
 	}
+	if sk := t.cxt.SessionKey(); sk != nil {
+		body, err := t.reader.Read2EOF()
+		if err != nil {
+			rootInfo.Add(itm)
+			return rootInfo, errs.New("illegal encrypted data", errs.WithCause(err))
+		}
+		plain, err := decrypt.SED(sk.SymID, sk.Key, body)
+		itm.Add(t.set(plain, err, "quick check"))
+	}
 	rootInfo.Add(itm)
 
 	t.cxt.ResetAlg()
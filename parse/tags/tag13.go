@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
@@ -20,7 +21,11 @@ func newTag13(cxt *context.Context, tag values.TagID, body []byte) Tags {
 // Parse parsing User ID Packet
 func (t *tag13) Parse() (*result.Item, error) {
 	rootInfo := t.ToItem()
-	rootInfo.Add(values.NewText(t.reader.GetBody(), "User ID").ToItem(t.cxt.Debug()))
+	uid, err := t.reader.ReadBytes(t.reader.Rest())
+	if err != nil {
+		return rootInfo, errs.New("illegal User ID", errs.WithCause(err))
+	}
+	rootInfo.Add(values.NewText(uid, "User ID").ToItem(t.cxt.Debug()))
 	return rootInfo, nil
 }
 
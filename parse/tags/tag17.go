@@ -21,7 +21,7 @@ func newTag17(cxt *context.Context, tag values.TagID, body []byte) Tags {
 // Parse parsing User Attribute Packet
 func (t *tag17) Parse() (*result.Item, error) {
 	rootInfo := t.ToItem()
-	subpcket, err := newSubparser(t.cxt, t.tag, "Subpacket", t.reader.GetBody())
+	subpcket, err := newSubparser(t.cxt, t.tag, "Subpacket", result.KindSubpacketArea, t.reader.GetBody())
 	if err != nil {
 		return rootInfo, errs.New("illegal subpacket", errs.WithCause(err))
 	}
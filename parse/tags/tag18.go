@@ -1,7 +1,11 @@
 package tags
 
 import (
+	"strconv"
+
+	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
@@ -10,15 +14,19 @@ import (
 // tag18 class for TSym. Encrypted Integrity Protected Data Packet
 type tag18 struct {
 	tagInfo
+	decrypted
 }
 
 //NewTag18 return tag18 instance
 func newTag18(cxt *context.Context, tag values.TagID, body []byte) Tags {
-	return &tag18{tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
+	return &tag18{tagInfo: tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
 }
 
 // Parse parsing Sym. Encrypted Integrity Protected Data Packet
 func (t *tag18) Parse() (*result.Item, error) {
+	if body := t.reader.GetBody(); len(body) > 0 && body[0] == 2 {
+		return t.parseV2()
+	}
 	rootInfo := t.ToItem()
 	itm := values.RawData(t.reader, "Encrypted data", t.cxt.Debug())
 	switch true {
@@ -28,12 +36,81 @@ func (t *tag18) Parse() (*result.Item, error) {
 		itm.Note = "plain text + MDC SHA1(20 bytes); sym alg is specified in pub-key encrypted session key"
 	default:
 	}
+	if sk := t.cxt.SessionKey(); sk != nil {
+		body, err := t.reader.Read2EOF()
+		if err != nil || len(body) == 0 {
+			rootInfo.Add(itm)
+			return rootInfo, errs.New("illegal encrypted data", errs.WithCause(err))
+		}
+		plain, err := decrypt.SEIPD1(sk.SymID, sk.Key, body[1:])
+		itm.Add(t.set(plain, err, "MDC"))
+	}
 	rootInfo.Add(itm)
 
 	t.cxt.ResetAlg()
 	return rootInfo, nil
 }
 
+//parseV2 parses Sym. Encrypted Integrity Protected Data Packet version 2
+func (t *tag18) parseV2() (*result.Item, error) {
+	rootInfo := t.ToItem()
+	//A one-octet version number with value 2.
+	v, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal version", errs.WithCause(err))
+	}
+	rootInfo.Add(values.SEIPDVer(v).ToItem(t.cxt.Debug()))
+	//A one-octet cipher algorithm.
+	alg, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal symid", errs.WithCause(err))
+	}
+	symid := values.SymID(alg)
+	rootInfo.Add(symid.ToItem(t.cxt.Debug()))
+	//A one-octet AEAD algorithm.
+	alg, err = t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal aeadid", errs.WithCause(err))
+	}
+	aeadid := values.AEADID(alg)
+	rootInfo.Add(aeadid.ToItem(t.cxt.Debug()))
+	//A one-octet chunk size.
+	c, err := t.reader.ReadByte()
+	if err != nil {
+		return rootInfo, errs.New("illegal chunk size", errs.WithCause(err))
+	}
+	rootInfo.Add(result.NewItem(
+		result.Name("Chunk size"),
+		result.Value(strconv.FormatUint(uint64(1)<<(c+6), 10)),
+		result.DumpStr(values.DumpByteString(c, true)),
+	))
+	//Thirty-two octets of salt.
+	salt, err := t.reader.ReadBytes(32)
+	if err != nil {
+		return rootInfo, errs.New("illegal salt", errs.WithCause(err))
+	}
+	rootInfo.Add(result.NewItem(
+		result.Name("Salt"),
+		result.DumpStr(values.DumpBytes(salt, true).String()),
+	))
+
+	if t.reader.Rest() > 0 {
+		itm := values.RawData(t.reader, "Encrypted data and authentication tag", t.cxt.Debug())
+		if sk := t.cxt.SessionKey(); sk != nil {
+			data, err := t.reader.Read2EOF()
+			if err != nil {
+				rootInfo.Add(itm)
+				return rootInfo, errs.New("illegal encrypted data", errs.WithCause(err))
+			}
+			plain, err := decrypt.SEIPD2(symid, aeadid, c, salt, sk.Key, data)
+			itm.Add(t.set(plain, err, "authentication tags"))
+		}
+		rootInfo.Add(itm)
+	}
+	t.cxt.ResetAlg()
+	return rootInfo, nil
+}
+
 /* Copyright 2016-2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spiegel-im-spiegel/errs"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/decrypt"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/reader"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
 	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
@@ -14,11 +15,12 @@ import (
 // tag20 class for AEAD Encrypted Data Packet Packet
 type tag20 struct {
 	tagInfo
+	decrypted
 }
 
 //newTag20 return tag20 instance
 func newTag20(cxt *context.Context, tag values.TagID, body []byte) Tags {
-	return &tag20{tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
+	return &tag20{tagInfo: tagInfo{cxt: cxt, tag: tag, reader: reader.New(body)}}
 }
 
 // Parse parsing AEAD Encrypted Data Packet Packet
@@ -57,25 +59,35 @@ func (t *tag20) Parse() (*result.Item, error) {
 		result.DumpStr(values.DumpByteString(byte(c), true)),
 	))
 	//A starting initialization vector of size specified by the AEAD algorithm.
-	iv, err := t.iv(aeadid)
+	iv, ivItem, err := t.iv(aeadid)
 	if err != nil {
 		return rootInfo, nil
 	}
-	rootInfo.Add(iv)
+	rootInfo.Add(ivItem)
 
 	if t.reader.Rest() > 0 {
-		rootInfo.Add(values.RawData(t.reader, "Encrypted data and authentication tag", t.cxt.Debug()))
+		itm := values.RawData(t.reader, "Encrypted data and authentication tag", t.cxt.Debug())
+		if sk := t.cxt.SessionKey(); sk != nil {
+			data, err := t.reader.Read2EOF()
+			if err != nil {
+				rootInfo.Add(itm)
+				return rootInfo, errs.New("illegal encrypted data", errs.WithCause(err))
+			}
+			plain, err := decrypt.AEAD(symid, aeadid, c, iv, sk.Key, data)
+			itm.Add(t.set(plain, err, "authentication tags"))
+		}
+		rootInfo.Add(itm)
 	}
 	return rootInfo, nil
 }
 
-func (t *tag20) iv(aeadid values.AEADID) (*result.Item, error) {
+func (t *tag20) iv(aeadid values.AEADID) ([]byte, *result.Item, error) {
 	sz64 := int64(aeadid.IVLen())
 	iv, err := t.reader.ReadBytes(sz64)
 	if err != nil {
-		return nil, errs.New(fmt.Sprintf("illegal initialization vector (length: %d bytes)", sz64), errs.WithCause(err))
+		return nil, nil, errs.New(fmt.Sprintf("illegal initialization vector (length: %d bytes)", sz64), errs.WithCause(err))
 	}
-	return result.NewItem(
+	return iv, result.NewItem(
 		result.Name("IV"),
 		result.DumpStr(values.DumpBytes(iv, true).String()),
 	), nil
@@ -66,6 +66,9 @@ var newFunctions = FuncMap{
 
 //NewTag returns Tags instance for pasing
 func NewTag(op *packet.OpaquePacket, cxt *context.Context) Tags {
+	if f := registeredTag(int(op.Tag)); f != nil {
+		return f(cxt, values.TagID(op.Tag), op.Contents)
+	}
 	if op.Tag == 2 {
 		// recursive call in tag02.Parse() -> sub32.Parse()
 		return newTag02(cxt, values.TagID(op.Tag), op.Contents)
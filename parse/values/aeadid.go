@@ -9,16 +9,19 @@ import (
 var aeadIDNames = Msgs{
 	1: "EAX mode",
 	2: "OCB mode <RFC7253>",
+	3: "GCM mode",
 }
 
-var aeadIDIVLen = map[int]int{
+var aeadIDIVLen = Octets{
 	1: 16, //EAX mode
-	2: 16, //OCB mode
+	2: 15, //OCB mode
+	3: 12, //GCM mode
 }
 
-var aeadIDTagLen = map[int]int{
+var aeadIDTagLen = Octets{
 	1: 16, //EAX mode
 	2: 16, //OCB mode
+	3: 16, //GCM mode
 }
 
 //AEADID is AEAD Algorithm ID
@@ -36,7 +39,7 @@ func (aa AEADID) ToItem(dumpFlag bool) *result.Item {
 func (aa AEADID) String() string {
 	var name string
 	if 100 <= aa && aa <= 110 {
-		name = PrivateAlgName
+		name = aeadIDNames.Get(int(aa), PrivateAlgName)
 	} else {
 		name = aeadIDNames.Get(int(aa), Unknown)
 	}
@@ -45,18 +48,12 @@ func (aa AEADID) String() string {
 
 // IVLen returns length of IV
 func (aa AEADID) IVLen() int {
-	if v, ok := aeadIDIVLen[int(aa)]; ok {
-		return v
-	}
-	return 0
+	return aeadIDIVLen.Get(int(aa), 0)
 }
 
 // IVLen returns length of authentication tag
 func (aa AEADID) TagLen() int {
-	if v, ok := aeadIDTagLen[int(aa)]; ok {
-		return v
-	}
-	return 0
+	return aeadIDTagLen.Get(int(aa), 0)
 }
 
 /* Copyright 2019 Spiegel
@@ -9,7 +9,8 @@ var testAEADIDNames = []string{
 	"Unknown (aead 0)",
 	"EAX mode (aead 1)",
 	"OCB mode <RFC7253> (aead 2)",
-	"Unknown (aead 3)",
+	"GCM mode (aead 3)",
+	"Unknown (aead 4)",
 }
 
 func TestAEADID(t *testing.T) {
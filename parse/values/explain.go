@@ -0,0 +1,222 @@
+package values
+
+import (
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//references of specifications
+const (
+	rfc4880bis = "draft-ietf-openpgp-rfc4880bis"
+	rfc9580    = "RFC 9580"
+	librePGP   = "LibrePGP"
+)
+
+//Explanation class is plain-language description of item and reference to specification
+type Explanation struct {
+	Description string
+	Reference   string
+}
+
+//explain returns Explanation instance (section is optional)
+func explain(desc, doc, section string) Explanation {
+	ref := doc
+	if len(section) > 0 {
+		ref += " Section " + section
+	}
+	return Explanation{Description: desc, Reference: ref}
+}
+
+//Explanations is type of explanation list.
+type Explanations map[int]Explanation
+
+//Get returns explanation.
+func (e Explanations) Get(i int) (Explanation, bool) {
+	msgsMutex.RLock()
+	defer msgsMutex.RUnlock()
+	exp, ok := e[i]
+	return exp, ok
+}
+
+//Set sets explanation.
+func (e Explanations) Set(i int, exp Explanation) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	e[i] = exp
+}
+
+//Reset restores explanation from built-in list (removes if not built-in).
+func (e Explanations) Reset(i int, builtin Explanations) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	if exp, ok := builtin[i]; ok {
+		e[i] = exp
+		return
+	}
+	delete(e, i)
+}
+
+//clone returns copy of explanation list.
+func (e Explanations) clone() Explanations {
+	c := Explanations{}
+	for i, exp := range e {
+		c[i] = exp
+	}
+	return c
+}
+
+var tagExplanations = Explanations{
+	1:  explain("Session key encrypted to a recipient's public key; decrypts the following encrypted data.", rfc9580, "5.1"),
+	2:  explain("Signature over data or a key component, made with the issuer's key.", rfc9580, "5.2"),
+	3:  explain("Session key protected by a passphrase (or the passphrase-derived key itself).", rfc9580, "5.3"),
+	4:  explain("Announces a signature that follows the signed data, so that it can be verified in one pass.", rfc9580, "5.4"),
+	5:  explain("Primary key with its secret part; the s2k usage octet tells whether and how the secret part is protected.", rfc9580, "5.5.1.3"),
+	6:  explain("Primary public key of a certificate (transferable public key).", rfc9580, "5.5.1.1"),
+	7:  explain("Subkey with its secret part; protected in the same way as the primary secret key.", rfc9580, "5.5.1.4"),
+	8:  explain("Compressed container of other OpenPGP packets.", rfc9580, "5.6"),
+	9:  explain("Encrypted data without integrity protection (obsolete and unsafe).", rfc9580, "5.7"),
+	10: explain("Marker with the fixed content \"PGP\"; ignored on receipt.", rfc9580, "5.8"),
+	11: explain("The message body: data with its format, file name and date.", rfc9580, "5.9"),
+	12: explain("Trust information used by keyring implementations; not exchanged between users.", rfc9580, "5.10"),
+	13: explain("Identity (usually name and email address) bound to the primary key by self-signatures.", rfc9580, "5.11"),
+	14: explain("Subkey of a certificate, bound to the primary key by a subkey binding signature.", rfc9580, "5.5.1.2"),
+	17: explain("Identity in a non-text form (e.g. a photo ID).", rfc9580, "5.12"),
+	18: explain("Encrypted data with integrity protection (MDC or AEAD).", rfc9580, "5.13"),
+	19: explain("SHA-1 hash of the decrypted data, used as integrity check of version 1 encrypted data.", rfc9580, "5.13.1"),
+	20: explain("Data encrypted with an AEAD mode (OCB) in chunks.", librePGP, "5.16"),
+	60: explain("Packet tag reserved for private or experimental use.", rfc9580, "5.15"),
+	61: explain("Packet tag reserved for private or experimental use.", rfc9580, "5.15"),
+	62: explain("Packet tag reserved for private or experimental use.", rfc9580, "5.15"),
+	63: explain("Packet tag reserved for private or experimental use.", rfc9580, "5.15"),
+}
+
+var subpacketExplanations = Explanations{
+	2:  explain("Time at which the signature was made.", rfc9580, "5.2.3.11"),
+	3:  explain("Period after the creation time after which the signature is no longer valid.", rfc9580, "5.2.3.18"),
+	4:  explain("Whether the certification may be exported to other users (0 means local only).", rfc9580, "5.2.3.19"),
+	5:  explain("Makes the certified key a trusted introducer (level and amount of trust).", rfc9580, "5.2.3.21"),
+	6:  explain("Limits the scope of a trust signature to User IDs matching the expression.", rfc9580, "5.2.3.22"),
+	7:  explain("Whether the signature may be revoked later.", rfc9580, "5.2.3.20"),
+	9:  explain("Period after the key creation time after which the key expires.", rfc9580, "5.2.3.13"),
+	11: explain("Symmetric algorithms the key holder supports, in order of preference.", rfc9580, "5.2.3.14"),
+	12: explain("Key authorized to issue revocations for this key (deprecated).", rfc9580, "5.2.3.23"),
+	16: explain("Key ID of the key that made the signature.", rfc9580, "5.2.3.12"),
+	20: explain("Name-value annotation attached to the signature.", rfc9580, "5.2.3.24"),
+	21: explain("Hash algorithms the key holder supports, in order of preference.", rfc9580, "5.2.3.16"),
+	22: explain("Compression algorithms the key holder supports, in order of preference.", rfc9580, "5.2.3.17"),
+	23: explain("Requests to key servers (e.g. no-modify: only the key holder may update the key).", rfc9580, "5.2.3.25"),
+	24: explain("URI of the key server from which the key holder wants updates to be fetched.", rfc9580, "5.2.3.26"),
+	25: explain("Marks the User ID as the main identity of the key holder.", rfc9580, "5.2.3.27"),
+	26: explain("URI of the policy under which the signature was issued.", rfc9580, "5.2.3.28"),
+	27: explain("Usages of the key: certify, sign, encrypt, authenticate and so on.", rfc9580, "5.2.3.29"),
+	28: explain("User ID of the signer that made the signature.", rfc9580, "5.2.3.30"),
+	29: explain("Why the key or certification was revoked.", rfc9580, "5.2.3.31"),
+	30: explain("Optional OpenPGP features supported by the key holder's software.", rfc9580, "5.2.3.32"),
+	31: explain("Identifies the signature that this signature refers to.", rfc9580, "5.2.3.33"),
+	32: explain("Complete signature packet embedded in this one (e.g. primary key binding signature).", rfc9580, "5.2.3.34"),
+	33: explain("Fingerprint of the key that made the signature.", rfc9580, "5.2.3.35"),
+	34: explain("AEAD algorithms the key holder supports, in order of preference.", librePGP, "5.2.3.8"),
+	35: explain("Fingerprint of a key to which the signed message was encrypted.", rfc9580, "5.2.3.36"),
+	37: explain("Third-party certifications approved by the key holder for distribution.", rfc4880bis, "5.2.3.30"),
+	38: explain("Key data of the signer embedded in the signature.", librePGP, ""),
+}
+
+var attributeExplanations = Explanations{
+	1: explain("Image (usually JPEG) of the key holder.", rfc9580, "5.12.1"),
+}
+
+//inPacket returns true if parent is packet (including signature embedded in sub-packet)
+func inPacket(parent *result.Item) bool {
+	return parent != nil && parent.Kind == result.KindPacket
+}
+
+//inSubpacket returns function for checking that parent is sub-packet of type sub
+func inSubpacket(sub int) func(*result.Item) bool {
+	return func(parent *result.Item) bool {
+		return parent != nil && parent.Kind == result.KindSubpacket && parent.Code&0x7f == sub
+	}
+}
+
+//fieldExplanations is table of explanations for fields in packet (key is prefix of item name and parent item; nil matches any parent)
+var fieldExplanations = []struct {
+	prefix string
+	parent func(*result.Item) bool
+	exp    Explanation
+}{
+	{"Version", inPacket, explain("Version of packet format.", rfc9580, "5")},
+	{"Version", inSubpacket(33), explain("Version of the key that made the signature; determines the length of the fingerprint.", rfc9580, "5.2.3.35")},
+	{"Version", inSubpacket(35), explain("Version of the recipient key; determines the length of the fingerprint.", rfc9580, "5.2.3.36")},
+	{"Public-key Algorithm", nil, explain("Public-key algorithm of the key or signature.", rfc9580, "9.1")},
+	{"Symmetric Algorithm", nil, explain("Symmetric cipher.", rfc9580, "9.3")},
+	{"Compression Algorithm", nil, explain("Compression algorithm.", rfc9580, "9.4")},
+	{"Hash Algorithm", nil, explain("Hash algorithm.", rfc9580, "9.5")},
+	{"AEAD Algorithm", nil, explain("AEAD (authenticated encryption) mode.", rfc9580, "9.6")},
+	{"Signiture Type", nil, explain("What the signature is made over and what it means (e.g. 0x13: positive certification of a User ID).", rfc9580, "5.2.1")},
+	{"String-to-Key", nil, explain("How a passphrase is turned into a key.", rfc9580, "3.7")},
+	{"Salt", nil, explain("Random value mixed into the passphrase hash.", rfc9580, "3.7.1")},
+	{"Count", nil, explain("Number of octets hashed by iterated and salted S2K.", rfc9580, "3.7.1.3")},
+	{"Key ID", nil, explain("Short identifier of a key (the low 64 bits of the version 4 fingerprint).", rfc9580, "5.5.4")},
+	{"Hashed material", nil, explain("Data included in the hash of a version 3 signature.", rfc9580, "5.2.2")},
+	{"Hashed Subpacket", nil, explain("Sub-packets protected by the signature.", rfc9580, "5.2.3.7")},
+	{"Unhashed Subpacket", nil, explain("Sub-packets NOT protected by the signature; anyone can change them.", rfc9580, "5.2.3.7")},
+	{"Hash left 2 bytes", nil, explain("Left 16 bits of the signed hash, for quick rejection of wrong signatures.", rfc9580, "5.2.4")},
+	{"ECC Curve OID", nil, explain("Object identifier of the elliptic curve.", rfc9580, "9.2")},
+	{"KDF parameters", nil, explain("Key derivation function and key wrap algorithm of ECDH.", rfc9580, "11.5")},
+	{"Literal data format", nil, explain("Format of literal data: binary, text or UTF-8 text.", rfc9580, "5.9")},
+	{"File name", nil, explain("File name hint of literal data (not signed).", rfc9580, "5.9")},
+	{"IV", nil, explain("Initial vector of the symmetric cipher.", rfc9580, "5.5.3")},
+	{"2-octet checksum", nil, explain("Sum of the octets of the secret key material modulo 65536.", rfc9580, "5.5.3")},
+	{"Public key creation time", nil, explain("Time at which the key was created; part of the fingerprint.", rfc9580, "5.5.2")},
+	{"Creation time", nil, explain("Time at which the data was created.", rfc9580, "3.5")},
+	{"Signature creation time", nil, explain("Time at which the signature was made.", rfc9580, "5.2.2")},
+	{"Multi-precision integer", nil, explain("Big integers of the public-key algorithm.", rfc9580, "3.2")},
+	{"Encrypted session key", nil, explain("Session key encrypted with the passphrase-derived key.", rfc9580, "5.3")},
+	{"Encrypted data", nil, explain("Encrypted payload (decryptable with the session key).", rfc9580, "5.13")},
+	{"wrapped session key", nil, explain("Session key wrapped with AES key wrap by the key derived from X25519 or X448 key agreement.", rfc9580, "5.1.6")},
+	{"Decrypted session key", nil, explain("Session key recovered from the encrypted session key packet; used to decrypt the following data.", rfc9580, "5.3")},
+	{"Decrypted secret-key material", nil, explain("Secret key material decrypted with the passphrase-derived key, followed by the check against the public key.", rfc9580, "5.5.3")},
+	{"Decrypted data", nil, explain("Payload decrypted with the given session key; the inner packets follow.", rfc9580, "5.13")},
+	{"Modification Detection Code", nil, explain("SHA-1 hash of the plaintext.", rfc9580, "5.13.1")},
+	{"Chunk size", nil, explain("Size of each AEAD chunk (2^(c+6) octets).", rfc9580, "5.13.2")},
+}
+
+//ExplainTag returns explanation of packet tag.
+func ExplainTag(tag int) (Explanation, bool) {
+	return tagExplanations.Get(tag)
+}
+
+//ExplainSubpacket returns explanation of sub-packet type in signature packet.
+func ExplainSubpacket(sub int) (Explanation, bool) {
+	return subpacketExplanations.Get(sub)
+}
+
+//ExplainAttribute returns explanation of sub-packet type in user attribute packet.
+func ExplainAttribute(sub int) (Explanation, bool) {
+	return attributeExplanations.Get(sub)
+}
+
+//ExplainField returns explanation of field in packet by item name (parent is item including the field).
+func ExplainField(name string, parent *result.Item) (Explanation, bool) {
+	for _, f := range fieldExplanations {
+		if strings.HasPrefix(name, f.prefix) && (f.parent == nil || f.parent(parent)) {
+			return f.exp, true
+		}
+	}
+	return Explanation{}, false
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,80 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func TestExplain(t *testing.T) {
+	packet := result.NewItem(result.Meta(result.KindPacket, 2))
+	sub33 := result.NewItem(result.Meta(result.KindSubpacket, 33))
+	sub35 := result.NewItem(result.Meta(result.KindSubpacket, 35|0x80)) //critical
+	testCases := []struct {
+		name string
+		fn   func() (Explanation, bool)
+		desc string
+		ref  string
+	}{
+		{name: "ExplainTag(2)", fn: func() (Explanation, bool) { return ExplainTag(2) }, desc: "Signature over data or a key component, made with the issuer's key.", ref: "RFC 9580 Section 5.2"},
+		{name: "ExplainSubpacket(23)", fn: func() (Explanation, bool) { return ExplainSubpacket(23) }, desc: "Requests to key servers (e.g. no-modify: only the key holder may update the key).", ref: "RFC 9580 Section 5.2.3.25"},
+		{name: "ExplainSubpacket(38)", fn: func() (Explanation, bool) { return ExplainSubpacket(38) }, desc: "Key data of the signer embedded in the signature.", ref: "LibrePGP"},
+		{name: "ExplainAttribute(1)", fn: func() (Explanation, bool) { return ExplainAttribute(1) }, desc: "Image (usually JPEG) of the key holder.", ref: "RFC 9580 Section 5.12.1"},
+		{name: "ExplainField(Hash Algorithm)", fn: func() (Explanation, bool) { return ExplainField("Hash Algorithm", nil) }, desc: "Hash algorithm.", ref: "RFC 9580 Section 9.5"},
+		{name: "ExplainField(Version in packet)", fn: func() (Explanation, bool) { return ExplainField("Version", packet) }, desc: "Version of packet format.", ref: "RFC 9580 Section 5"},
+		{name: "ExplainField(Version in sub 33)", fn: func() (Explanation, bool) { return ExplainField("Version", sub33) }, desc: "Version of the key that made the signature; determines the length of the fingerprint.", ref: "RFC 9580 Section 5.2.3.35"},
+		{name: "ExplainField(Version in sub 35)", fn: func() (Explanation, bool) { return ExplainField("Version", sub35) }, desc: "Version of the recipient key; determines the length of the fingerprint.", ref: "RFC 9580 Section 5.2.3.36"},
+	}
+	for _, tc := range testCases {
+		exp, ok := tc.fn()
+		if !ok {
+			t.Errorf("%v is not found, want \"%v\".", tc.name, tc.desc)
+			continue
+		}
+		if exp.Description != tc.desc {
+			t.Errorf("%v.Description is \"%v\", want \"%v\".", tc.name, exp.Description, tc.desc)
+		}
+		if exp.Reference != tc.ref {
+			t.Errorf("%v.Reference is \"%v\", want \"%v\".", tc.name, exp.Reference, tc.ref)
+		}
+	}
+	if _, ok := ExplainTag(15); ok {
+		t.Error("ExplainTag(15) is found, want not found.")
+	}
+	if _, ok := ExplainField("User ID", nil); ok {
+		t.Error("ExplainField(\"User ID\") is found, want not found.")
+	}
+	if _, ok := ExplainField("Version", result.NewItem(result.Meta(result.KindSubpacket, 1))); ok {
+		t.Error("ExplainField(\"Version\") in sub-packet 1 is found, want not found.")
+	}
+}
+
+func TestRegisterExplanation(t *testing.T) {
+	RegisterTagExplanation(61, "Private test packet.", "Test Spec")
+	RegisterSubpacketExplanation(101, "Private test sub-packet.", "Test Spec")
+	defer func() {
+		UnregisterTagExplanation(61)
+		UnregisterSubpacketExplanation(101)
+	}()
+	if exp, _ := ExplainTag(61); exp.Description != "Private test packet." || exp.Reference != "Test Spec" {
+		t.Errorf("ExplainTag(61) is \"%v\", want \"%v\".", exp, Explanation{"Private test packet.", "Test Spec"})
+	}
+	if exp, _ := ExplainSubpacket(101); exp.Description != "Private test sub-packet." || exp.Reference != "Test Spec" {
+		t.Errorf("ExplainSubpacket(101) is \"%v\", want \"%v\".", exp, Explanation{"Private test sub-packet.", "Test Spec"})
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
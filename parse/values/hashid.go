@@ -40,7 +40,7 @@ func (ha HashID) ToItem(dumpFlag bool) *result.Item {
 func (ha HashID) String() string {
 	var name string
 	if 100 <= ha && ha <= 110 {
-		name = PrivateAlgName
+		name = hashIDNames.Get(int(ha), PrivateAlgName)
 	} else {
 		name = hashIDNames.Get(int(ha), "Unknown")
 	}
@@ -103,6 +103,7 @@ func RawData(r *reader.Reader, name string, dumpFlag bool) *result.Item {
 		result.Name(name),
 		result.Note(fmt.Sprintf("%d bytes", rst)),
 		result.DumpStr(Dump(r, dumpFlag).String()),
+		result.Range(r.Size()-rst, r.Size()),
 	)
 }
 
@@ -1,16 +1,90 @@
 package values
 
+import "sync"
+
+//msgsMutex guards name tables against registration while parsing
+var msgsMutex sync.RWMutex
+
 //Msgs is type of message list.
 type Msgs map[int]string
 
 //Get returns message.
 func (m Msgs) Get(i int, def string) string {
+	msgsMutex.RLock()
+	defer msgsMutex.RUnlock()
 	if msg, ok := m[i]; ok {
 		return msg
 	}
 	return def
 }
 
+//Set sets message.
+func (m Msgs) Set(i int, msg string) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	m[i] = msg
+}
+
+//Reset restores message from built-in list (removes if not built-in).
+func (m Msgs) Reset(i int, builtin Msgs) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	if msg, ok := builtin[i]; ok {
+		m[i] = msg
+		return
+	}
+	delete(m, i)
+}
+
+//clone returns copy of message list.
+func (m Msgs) clone() Msgs {
+	c := Msgs{}
+	for i, msg := range m {
+		c[i] = msg
+	}
+	return c
+}
+
+//Octets is type of length (octets) list.
+type Octets map[int]int
+
+//Get returns length.
+func (o Octets) Get(i int, def int) int {
+	msgsMutex.RLock()
+	defer msgsMutex.RUnlock()
+	if l, ok := o[i]; ok {
+		return l
+	}
+	return def
+}
+
+//Set sets length.
+func (o Octets) Set(i int, l int) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	o[i] = l
+}
+
+//Reset restores length from built-in list (removes if not built-in).
+func (o Octets) Reset(i int, builtin Octets) {
+	msgsMutex.Lock()
+	defer msgsMutex.Unlock()
+	if l, ok := builtin[i]; ok {
+		o[i] = l
+		return
+	}
+	delete(o, i)
+}
+
+//clone returns copy of length list.
+func (o Octets) clone() Octets {
+	c := Octets{}
+	for i, l := range o {
+		c[i] = l
+	}
+	return c
+}
+
 /* Copyright 2016 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
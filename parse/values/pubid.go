@@ -17,6 +17,10 @@ var pubIDNames = Msgs{
 	20: "Reserved (formerly Elgamal Encrypt or Sign)",
 	21: "Reserved for Diffie-Hellman",
 	22: "EdDSA",
+	25: "X25519",
+	26: "X448",
+	27: "Ed25519",
+	28: "Ed448",
 }
 
 //PubID is Public-Key Algorithm ID
@@ -35,7 +39,7 @@ func (pi PubID) ToItem(dumpFlag bool) *result.Item {
 func (pi PubID) String() string {
 	var name string
 	if 100 <= pi && pi <= 110 {
-		name = PrivateAlgName
+		name = pubIDNames.Get(int(pi), PrivateAlgName)
 	} else {
 		name = pubIDNames.Get(int(pi), Unknown)
 	}
@@ -72,6 +76,16 @@ func (pi PubID) IsEdDSA() bool {
 	return (pi == 22)
 }
 
+//IsX25519 returns if X25519 algorithm.
+func (pi PubID) IsX25519() bool {
+	return (pi == 25)
+}
+
+//IsX448 returns if X448 algorithm.
+func (pi PubID) IsX448() bool {
+	return (pi == 26)
+}
+
 /* Copyright 2016 Spiegel
  *
  * Licensed under the Apiche License, Version 2.0 (the "License");
@@ -30,10 +30,16 @@ var testPubIDNames = []string{
 	"Reserved for Diffie-Hellman (pub 21)",
 	"EdDSA (pub 22)",
 	"Unknown (pub 23)",
+	"Unknown (pub 24)",
+	"X25519 (pub 25)",
+	"X448 (pub 26)",
+	"Ed25519 (pub 27)",
+	"Ed448 (pub 28)",
+	"Unknown (pub 29)",
 }
 
 func TestPubID(t *testing.T) {
-	for tag := 0; tag <= 23; tag++ {
+	for tag := 0; tag <= 29; tag++ {
 		i := PubID(tag).ToItem(false)
 		if i.Name != "Public-key Algorithm" {
 			t.Errorf("PubID.Name = \"%s\", want \"Public-key Algorithm\".", i.Name)
@@ -153,6 +159,38 @@ func TestPubIDEdDSA(t *testing.T) {
 	}
 }
 
+func TestPubIDX25519(t *testing.T) {
+	for tag := 0; tag <= 29; tag++ {
+		pub := PubID(tag)
+		switch tag {
+		case 25:
+			if !pub.IsX25519() {
+				t.Errorf("PubID.IsX25519(%d) = %v, want true.", tag, pub.IsX25519())
+			}
+		default:
+			if pub.IsX25519() {
+				t.Errorf("PubID.IsX25519(%d) = %v, want false.", tag, pub.IsX25519())
+			}
+		}
+	}
+}
+
+func TestPubIDX448(t *testing.T) {
+	for tag := 0; tag <= 29; tag++ {
+		pub := PubID(tag)
+		switch tag {
+		case 26:
+			if !pub.IsX448() {
+				t.Errorf("PubID.IsX448(%d) = %v, want true.", tag, pub.IsX448())
+			}
+		default:
+			if pub.IsX448() {
+				t.Errorf("PubID.IsX448(%d) = %v, want false.", tag, pub.IsX448())
+			}
+		}
+	}
+}
+
 /* Copyright 2016 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -0,0 +1,139 @@
+package values
+
+//built-in tables restored by Unregister* functions
+var (
+	builtinTagNames              = tagNames.clone()
+	builtinSubpacketNames        = subpacketNames.clone()
+	builtinPubIDNames            = pubIDNames.clone()
+	builtinSymIDNames            = symIDNames.clone()
+	builtinSymIDIVLen            = symIDIVLen.clone()
+	builtinHashIDNames           = hashIDNames.clone()
+	builtinCompIDNames           = compIDNames.clone()
+	builtinAEADIDNames           = aeadIDNames.clone()
+	builtinAEADIDIVLen           = aeadIDIVLen.clone()
+	builtinAEADIDTagLen          = aeadIDTagLen.clone()
+	builtinS2KIDNames            = s2kIDNames.clone()
+	builtinTagExplanations       = tagExplanations.clone()
+	builtinSubpacketExplanations = subpacketExplanations.clone()
+)
+
+//RegisterTagName registers name of packet tag (ex. private or experimental tag 60-63).
+func RegisterTagName(tag int, name string) {
+	tagNames.Set(tag, name)
+}
+
+//UnregisterTagName removes name of packet tag registered by RegisterTagName (built-in name is restored).
+func UnregisterTagName(tag int) {
+	tagNames.Reset(tag, builtinTagNames)
+}
+
+//RegisterSubpacketName registers name of sub-packet type (ex. private or experimental type 100-110).
+func RegisterSubpacketName(sub int, name string) {
+	subpacketNames.Set(sub, name)
+}
+
+//UnregisterSubpacketName removes name of sub-packet type registered by RegisterSubpacketName (built-in name is restored).
+func UnregisterSubpacketName(sub int) {
+	subpacketNames.Reset(sub, builtinSubpacketNames)
+}
+
+//RegisterPubAlgorithm registers name of public-key algorithm.
+func RegisterPubAlgorithm(id int, name string) {
+	pubIDNames.Set(id, name)
+}
+
+//UnregisterPubAlgorithm removes name of public-key algorithm registered by RegisterPubAlgorithm (built-in name is restored).
+func UnregisterPubAlgorithm(id int) {
+	pubIDNames.Reset(id, builtinPubIDNames)
+}
+
+//RegisterSymAlgorithm registers name of symmetric-key algorithm and length of its IV (block size).
+func RegisterSymAlgorithm(id int, name string, ivLen int) {
+	symIDNames.Set(id, name)
+	symIDIVLen.Set(id, ivLen)
+}
+
+//UnregisterSymAlgorithm removes name and IV length of symmetric-key algorithm registered by RegisterSymAlgorithm (built-in values are restored).
+func UnregisterSymAlgorithm(id int) {
+	symIDNames.Reset(id, builtinSymIDNames)
+	symIDIVLen.Reset(id, builtinSymIDIVLen)
+}
+
+//RegisterHashAlgorithm registers name of hash algorithm.
+func RegisterHashAlgorithm(id int, name string) {
+	hashIDNames.Set(id, name)
+}
+
+//UnregisterHashAlgorithm removes name of hash algorithm registered by RegisterHashAlgorithm (built-in name is restored).
+func UnregisterHashAlgorithm(id int) {
+	hashIDNames.Reset(id, builtinHashIDNames)
+}
+
+//RegisterCompAlgorithm registers name of compression algorithm.
+func RegisterCompAlgorithm(id int, name string) {
+	compIDNames.Set(id, name)
+}
+
+//UnregisterCompAlgorithm removes name of compression algorithm registered by RegisterCompAlgorithm (built-in name is restored).
+func UnregisterCompAlgorithm(id int) {
+	compIDNames.Reset(id, builtinCompIDNames)
+}
+
+//RegisterAEADAlgorithm registers name of AEAD algorithm and length of its IV and authentication tag.
+func RegisterAEADAlgorithm(id int, name string, ivLen, tagLen int) {
+	aeadIDNames.Set(id, name)
+	aeadIDIVLen.Set(id, ivLen)
+	aeadIDTagLen.Set(id, tagLen)
+}
+
+//UnregisterAEADAlgorithm removes name, IV length and tag length of AEAD algorithm registered by RegisterAEADAlgorithm (built-in values are restored).
+func UnregisterAEADAlgorithm(id int) {
+	aeadIDNames.Reset(id, builtinAEADIDNames)
+	aeadIDIVLen.Reset(id, builtinAEADIDIVLen)
+	aeadIDTagLen.Reset(id, builtinAEADIDTagLen)
+}
+
+//RegisterS2KAlgorithm registers name of S2K algorithm.
+func RegisterS2KAlgorithm(id int, name string) {
+	s2kIDNames.Set(id, name)
+}
+
+//UnregisterS2KAlgorithm removes name of S2K algorithm registered by RegisterS2KAlgorithm (built-in name is restored).
+func UnregisterS2KAlgorithm(id int) {
+	s2kIDNames.Reset(id, builtinS2KIDNames)
+}
+
+//RegisterTagExplanation registers description and reference of packet tag.
+func RegisterTagExplanation(tag int, desc, ref string) {
+	tagExplanations.Set(tag, Explanation{Description: desc, Reference: ref})
+}
+
+//UnregisterTagExplanation removes explanation of packet tag registered by RegisterTagExplanation (built-in explanation is restored).
+func UnregisterTagExplanation(tag int) {
+	tagExplanations.Reset(tag, builtinTagExplanations)
+}
+
+//RegisterSubpacketExplanation registers description and reference of sub-packet type.
+func RegisterSubpacketExplanation(sub int, desc, ref string) {
+	subpacketExplanations.Set(sub, Explanation{Description: desc, Reference: ref})
+}
+
+//UnregisterSubpacketExplanation removes explanation of sub-packet type registered by RegisterSubpacketExplanation (built-in explanation is restored).
+func UnregisterSubpacketExplanation(sub int) {
+	subpacketExplanations.Reset(sub, builtinSubpacketExplanations)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,94 @@
+package values
+
+import "testing"
+
+func TestRegisterName(t *testing.T) {
+	RegisterTagName(60, "Private Test Packet")
+	RegisterSubpacketName(101, "Private Test Sub-packet")
+	RegisterPubAlgorithm(100, "Private Test Pubkey")
+	RegisterSymAlgorithm(100, "Private Test Cipher", 16)
+	RegisterHashAlgorithm(100, "Private Test Hash")
+	RegisterCompAlgorithm(100, "Private Test Compression")
+	RegisterAEADAlgorithm(100, "Private Test AEAD", 12, 16)
+	RegisterS2KAlgorithm(100, "Private Test S2K")
+	defer func() {
+		UnregisterTagName(60)
+		UnregisterSubpacketName(101)
+		UnregisterPubAlgorithm(100)
+		UnregisterSymAlgorithm(100)
+		UnregisterHashAlgorithm(100)
+		UnregisterCompAlgorithm(100)
+		UnregisterAEADAlgorithm(100)
+		UnregisterS2KAlgorithm(100)
+	}()
+
+	testCases := []struct {
+		res  string
+		want string
+	}{
+		{res: TagID(60).String(), want: "Private Test Packet (tag 60)"},
+		{res: SuboacketID(101).String(), want: "Private Test Sub-packet (sub 101)"},
+		{res: SuboacketID(102).String(), want: "Private or experimental (sub 102)"},
+		{res: PubID(100).String(), want: "Private Test Pubkey (pub 100)"},
+		{res: PubID(101).String(), want: "Private/Experimental algorithm (pub 101)"},
+		{res: SymID(100).String(), want: "Private Test Cipher (sym 100)"},
+		{res: HashID(100).String(), want: "Private Test Hash (hash 100)"},
+		{res: CompID(100).String(), want: "Private Test Compression (comp 100)"},
+		{res: AEADID(100).String(), want: "Private Test AEAD (aead 100)"},
+		{res: S2KID(100).String(), want: "Private Test S2K (s2k 100)"},
+	}
+	for _, tc := range testCases {
+		if tc.res != tc.want {
+			t.Errorf("String() = \"%v\", want \"%v\".", tc.res, tc.want)
+		}
+	}
+	if l := SymID(100).IVLen(); l != 16 {
+		t.Errorf("SymID.IVLen() = %v, want %v.", l, 16)
+	}
+	if l := AEADID(100).IVLen(); l != 12 {
+		t.Errorf("AEADID.IVLen() = %v, want %v.", l, 12)
+	}
+	if l := AEADID(100).TagLen(); l != 16 {
+		t.Errorf("AEADID.TagLen() = %v, want %v.", l, 16)
+	}
+}
+
+func TestUnregisterName(t *testing.T) {
+	RegisterTagName(60, "Private Test Packet")
+	RegisterPubAlgorithm(100, "Private Test Pubkey")
+	RegisterAEADAlgorithm(100, "Private Test AEAD", 12, 16)
+	UnregisterTagName(60)
+	UnregisterPubAlgorithm(100)
+	UnregisterAEADAlgorithm(100)
+
+	testCases := []struct {
+		res  string
+		want string
+	}{
+		{res: TagID(60).String(), want: "Private or Experimental Values (tag 60)"},
+		{res: PubID(100).String(), want: "Private/Experimental algorithm (pub 100)"},
+	}
+	for _, tc := range testCases {
+		if tc.res != tc.want {
+			t.Errorf("Unregister* functions: \"%v\", want \"%v\".", tc.res, tc.want)
+		}
+	}
+	if l := aeadIDIVLen.Get(100, 0); l != 0 {
+		t.Errorf("UnregisterAEADAlgorithm(100): IV length is %v, want 0.", l)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -29,7 +29,7 @@ func (sa S2KID) ToItem(dumpFlag bool) *result.Item {
 func (sa S2KID) String() string {
 	var name string
 	if 100 <= sa && sa <= 110 {
-		name = PrivateAlgName
+		name = s2kIDNames.Get(int(sa), PrivateAlgName)
 	} else {
 		name = s2kIDNames.Get(int(sa), Unknown)
 	}
@@ -55,14 +55,18 @@ type Stretch byte
 
 // ToItem returns Item instance
 func (c Stretch) ToItem() *result.Item {
-	count := (uint32(16) + (uint32(c) & 0x0f)) << ((uint32(c) >> 4) + S2KEXPBIAS)
 	return result.NewItem(
 		result.Name("Count"),
-		result.Value(strconv.Itoa(int(count))),
+		result.Value(strconv.Itoa(int(c.Count()))),
 		result.DumpStr(DumpByteString(byte(c), true)),
 	)
 }
 
+//Count returns number of octets to be hashed
+func (c Stretch) Count() uint32 {
+	return (uint32(16) + (uint32(c) & 0x0f)) << ((uint32(c) >> 4) + S2KEXPBIAS)
+}
+
 /* Copyright 2016 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -58,6 +58,9 @@ func (s SuboacketID) ToItem(r *reader.Reader, dumpFlag bool) *result.Item {
 		result.Name(s.String()),
 		result.Note(fmt.Sprintf("%d bytes", r.Len())),
 		result.DumpStr(Dump(r, dumpFlag).String()),
+		result.Meta(result.KindSubpacket, int(s)),
+		result.Raw(r.GetBody()),
+		result.Track(r),
 	)
 }
 
@@ -69,7 +72,7 @@ func (s SuboacketID) String() string {
 	s &= 0x7f
 	var name string
 	if 100 <= s && s <= 110 {
-		name = "Private or experimental"
+		name = subpacketNames.Get(int(s), "Private or experimental")
 	} else {
 		name = subpacketNames.Get(int(s), Unknown)
 	}
@@ -23,7 +23,7 @@ var symIDNames = Msgs{
 	13: "Camellia with 256-bit key",
 }
 
-var symIDIVLen = map[int]int{
+var symIDIVLen = Octets{
 	0:  8,  //Plaintext or unencrypted data
 	1:  8,  //IDEA
 	2:  8,  //TripleDES (168 bit key derived from 192)
@@ -54,17 +54,14 @@ func (s SymID) ToItem(dumpFlag bool) *result.Item {
 
 // IVLen returns length of IV
 func (s SymID) IVLen() int {
-	if v, ok := symIDIVLen[int(s)]; ok {
-		return v
-	}
-	return 0
+	return symIDIVLen.Get(int(s), 0)
 }
 
 //Stringer for SymID
 func (s SymID) String() string {
 	var name string
 	if 100 <= s && s <= 110 {
-		name = PrivateAlgName
+		name = symIDNames.Get(int(s), PrivateAlgName)
 	} else {
 		name = symIDNames.Get(int(s), Unknown)
 	}
@@ -42,6 +42,9 @@ func (t TagID) ToItem(r *reader.Reader, dumpFlag bool) *result.Item {
 		result.Name(t.String()),
 		result.Note(fmt.Sprintf("%d bytes", r.Len())),
 		result.DumpStr(Dump(r, dumpFlag).String()),
+		result.Meta(result.KindPacket, int(t)),
+		result.Raw(r.GetBody()),
+		result.Track(r),
 	)
 }
 
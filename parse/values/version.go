@@ -103,7 +103,7 @@ func OneSigVer(ver byte) *Version {
 
 // PubSessKeyVer is Public-Key Encrypted Session Key Packet Version
 func PubSessKeyVer(ver byte) *Version {
-	return NewVersion(ver, 3, 0)
+	return NewVersion(ver, 3, 6)
 }
 
 // SymSessKeyVer is Symmetric-Key Encrypted Session Key Packet Version
@@ -111,6 +111,11 @@ func SymSessKeyVer(ver byte) *Version {
 	return NewVersion(ver, 4, 5)
 }
 
+// SEIPDVer is Sym. Encrypted Integrity Protected Data Packet Version
+func SEIPDVer(ver byte) *Version {
+	return NewVersion(ver, 1, 2)
+}
+
 // AEADPacketVer is AEAD Encrypted Data Packet Version
 func AEADVer(ver byte) *Version {
 	return NewVersion(ver, 1, 0)
@@ -225,6 +225,14 @@ func TestPubSessKeyVer3(t *testing.T) {
 	}
 }
 
+func TestPubSessKeyVer6(t *testing.T) {
+	i := PubSessKeyVer(6).ToItem(true)
+
+	if i.Note != "draft" {
+		t.Errorf("Version.Note = \"%v\", want \"draft\"", i.Note)
+	}
+}
+
 func TestSymSessKeyVer4(t *testing.T) {
 	i := SymSessKeyVer(4).ToItem(true)
 
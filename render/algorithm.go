@@ -0,0 +1,139 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//numPubKey returns number of public key values
+func numPubKey(algo int) int {
+	switch algo {
+	case 1, 2, 3: //RSA
+		return 2
+	case 16, 20: //Elgamal
+		return 3
+	case 17: //DSA
+		return 4
+	case 18: //ECDH
+		return 3
+	case 19, 22: //ECDSA, EdDSA
+		return 2
+	default:
+		return 0
+	}
+}
+
+//numSecKey returns number of public and secret key values
+func numSecKey(algo int) int {
+	switch algo {
+	case 1, 2, 3: //RSA
+		return 6
+	case 16, 20: //Elgamal
+		return 4
+	case 17: //DSA
+		return 5
+	case 18: //ECDH
+		return 4
+	case 19, 22: //ECDSA, EdDSA
+		return 3
+	default:
+		return 0
+	}
+}
+
+//numSig returns number of signature values
+func numSig(algo int) int {
+	switch algo {
+	case 1, 3: //RSA
+		return 1
+	case 17, 19, 20, 22: //DSA, ECDSA, Elgamal, EdDSA
+		return 2
+	default:
+		return 0
+	}
+}
+
+//numEnc returns number of encrypted session key values
+func numEnc(algo int) int {
+	switch algo {
+	case 1, 2: //RSA
+		return 1
+	case 16, 20, 18: //Elgamal, ECDH
+		return 2
+	default:
+		return 0
+	}
+}
+
+//isECC returns true if ECC algorithm
+func isECC(algo int) bool {
+	return algo == 18 || algo == 19 || algo == 22
+}
+
+//isSized returns true if i-th public key value is one-octet length-prefixed data (ECC OID and KDF parameters)
+func isSized(algo, i int) bool {
+	switch algo {
+	case 19, 22:
+		return i == 0
+	case 18:
+		return i == 0 || i == 2
+	default:
+		return false
+	}
+}
+
+//oidString returns dotted string of OID
+func oidString(oid []byte) string {
+	if len(oid) == 0 {
+		return ""
+	}
+	elms := []string{strconv.Itoa(int(oid[0]) / 40), strconv.Itoa(int(oid[0]) % 40)}
+	v := uint64(0)
+	for _, b := range oid[1:] {
+		v = v<<7 | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			elms = append(elms, strconv.FormatUint(v, 10))
+			v = 0
+		}
+	}
+	return strings.Join(elms, ".")
+}
+
+//curveName returns name of ECC curve (empty if unknown curve)
+func curveName(oid []byte) string {
+	name, _ := curveInfo(oid)
+	return name
+}
+
+//curveInfo returns name and key size of ECC curve
+func curveInfo(oid []byte) (string, int) {
+	var name string
+	var bits int
+	if _, err := fmt.Sscanf(values.OID(oid).String(), "%s (%dbits key size)", &name, &bits); err != nil {
+		return "", 0
+	}
+	return name, bits
+}
+
+//hexString returns upper-case hex string
+func hexString(b []byte) string {
+	return fmt.Sprintf("%X", b)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,465 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//curveBits is table of key length of ECC curves (same as GnuPG)
+var curveBits = map[string]int{
+	"nistp256":        256,
+	"nistp384":        384,
+	"nistp521":        521,
+	"secp256k1":       256,
+	"brainpoolP256r1": 256,
+	"brainpoolP384r1": 384,
+	"brainpoolP512r1": 512,
+	"ed25519":         255,
+	"cv25519":         255,
+	"ed448":           448,
+	"cv448":           448,
+}
+
+//colonsKey class is key (primary key or subkey) in certificate
+type colonsKey struct {
+	secret  bool
+	version int
+	algo    int
+	bits    int
+	curve   string
+	created uint32
+	expires uint32 //0 is no expiration
+	usage   string //capabilities (e, s, c, a)
+	revoked bool
+	keyid   []byte
+	fpr     []byte
+	sigs    []*signature
+	latest  uint32 //creation time of latest self-signature
+}
+
+//colonsUID class is user ID or user attribute in certificate
+type colonsUID struct {
+	attr    bool
+	name    string
+	count   int //number of attribute sub-packets
+	size    int //total size of attribute sub-packets
+	created uint32
+	primary bool
+	revoked bool
+	sigs    []*signature
+	latest  uint32 //creation time of latest self-signature
+}
+
+//colonsCert class is certificate (transferable public or secret key)
+type colonsCert struct {
+	primary *colonsKey
+	uids    []*colonsUID
+	subs    []*colonsKey
+}
+
+//colonizer class is renderer for colon-delimited records
+type colonizer struct {
+	w        *bytes.Buffer
+	now      time.Time
+	sigList  bool
+	certs    []*colonsCert
+	lastUID  *colonsUID
+	lastSub  *colonsKey
+	lastCert *colonsCert
+}
+
+//ColonsOpt is self-referential function for functional options pattern
+type ColonsOpt func(*colonizer)
+
+//WithNow returns function for setting current time (for validity of keys)
+func WithNow(t time.Time) ColonsOpt {
+	return func(c *colonizer) {
+		c.now = t
+	}
+}
+
+//WithSigList returns function for setting output of sig and rev records
+func WithSigList(flag bool) ColonsOpt {
+	return func(c *colonizer) {
+		c.sigList = flag
+	}
+}
+
+//Colons returns colon-delimited records of keys compatible with "gpg --with-colons" (validity is computed without signature verification)
+func Colons(info *result.Info, opts ...ColonsOpt) (io.Reader, error) {
+	c := &colonizer{w: &bytes.Buffer{}, now: time.Now()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if info == nil {
+		return c.w, nil
+	}
+	walkPackets(info.Packets, c.packet)
+	for _, cert := range c.certs {
+		c.cert(cert)
+	}
+	return c.w, nil
+}
+
+//packet collects keys, user IDs and signatures
+func (c *colonizer) packet(item *result.Item) {
+	switch item.Code {
+	case 5, 6:
+		cert := &colonsCert{primary: newColonsKey(item)}
+		c.certs = append(c.certs, cert)
+		c.lastCert, c.lastUID, c.lastSub = cert, nil, nil
+	case 7, 14:
+		if c.lastCert == nil {
+			return
+		}
+		sub := newColonsKey(item)
+		c.lastCert.subs = append(c.lastCert.subs, sub)
+		c.lastUID, c.lastSub = nil, sub
+	case 13, 17:
+		if c.lastCert == nil {
+			return
+		}
+		uid := &colonsUID{attr: item.Code == 17, name: string(item.Raw)}
+		if uid.attr {
+			uid.name = ""
+			for _, area := range item.Items {
+				if area == nil || area.Kind != result.KindSubpacketArea {
+					continue
+				}
+				for _, sub := range area.Items {
+					if sub != nil && sub.Kind == result.KindSubpacket {
+						uid.count++
+						uid.size += len(sub.Raw)
+					}
+				}
+			}
+		}
+		c.lastCert.uids = append(c.lastCert.uids, uid)
+		c.lastUID, c.lastSub = uid, nil
+	case 2:
+		if c.lastCert == nil {
+			return
+		}
+		sig := newSignature(item)
+		if sig == nil {
+			return
+		}
+		c.signature(sig)
+	}
+}
+
+//signature applies signature to last key or user ID
+func (c *colonizer) signature(sig *signature) {
+	primary := c.lastCert.primary
+	self := len(primary.keyid) > 0 && bytes.Equal(sig.keyID, primary.keyid)
+	switch {
+	case c.lastSub != nil:
+		c.lastSub.sigs = append(c.lastSub.sigs, sig)
+		if !self {
+			return
+		}
+		switch sig.sigType {
+		case 0x18:
+			if sig.created >= c.lastSub.latest {
+				c.lastSub.latest = sig.created
+				c.lastSub.applySelfSig(sig)
+			}
+		case 0x28:
+			c.lastSub.revoked = true
+		}
+	case c.lastUID != nil:
+		c.lastUID.sigs = append(c.lastUID.sigs, sig)
+		if !self {
+			return
+		}
+		switch sig.sigType {
+		case 0x10, 0x11, 0x12, 0x13:
+			if sig.created >= c.lastUID.latest {
+				c.lastUID.latest = sig.created
+				c.lastUID.created = sig.created
+				c.lastUID.primary = false
+				if b := sig.hashedSubpacket(25); len(b) > 0 && b[0] != 0 {
+					c.lastUID.primary = true
+				}
+			}
+			if sig.created >= primary.latest {
+				primary.latest = sig.created
+				primary.applySelfSig(sig)
+			}
+		case 0x30:
+			c.lastUID.revoked = true
+		}
+	default:
+		primary.sigs = append(primary.sigs, sig)
+		if !self {
+			return
+		}
+		switch sig.sigType {
+		case 0x1f:
+			if sig.created >= primary.latest {
+				primary.latest = sig.created
+				primary.applySelfSig(sig)
+			}
+		case 0x20:
+			primary.revoked = true
+		}
+	}
+}
+
+//cert outputs records of certificate
+func (c *colonizer) cert(cert *colonsCert) {
+	primary := cert.primary
+	validity := primary.validity(c.now)
+	typ := "pub"
+	if primary.secret {
+		typ = "sec"
+	}
+	usage := primary.usage
+	if len(validity) == 0 {
+		//capabilities of entire key
+		all := ""
+		for _, k := range append([]*colonsKey{primary}, cert.subs...) {
+			if len(k.validity(c.now)) == 0 {
+				all += k.usage
+			}
+		}
+		for _, u := range "esca" {
+			if strings.ContainsRune(all, u) {
+				usage += strings.ToUpper(string(u))
+			}
+		}
+	}
+	c.record(typ, validity, itoa(primary.bits), itoa(primary.algo), keyIDString(primary.keyid), utoa(primary.created), utoa(primary.expires), "", "", "", "", usage, "", "", "", "", primary.curve, "", "", "0")
+	c.fpr(primary)
+	name := "[User ID not found]"
+	for n, uid := range cert.uids {
+		if !uid.attr && (n == 0 || uid.primary) {
+			name = uid.name
+		}
+	}
+	c.sigs(primary, primary.sigs, name)
+	for _, uid := range cert.uids {
+		v := validity
+		if uid.revoked {
+			v = "r"
+		}
+		if uid.attr {
+			c.record("uat", v, "", "", "", utoa(uid.created), "", "", "", fmt.Sprintf("%d %d", uid.count, uid.size), "", "", "", "", "", "", "", "", "", "0")
+		} else {
+			c.record("uid", v, "", "", "", utoa(uid.created), "", "", "", colonsEscape(uid.name), "", "", "", "", "", "", "", "", "", "0")
+		}
+		c.sigs(primary, uid.sigs, name)
+	}
+	for _, sub := range cert.subs {
+		v := sub.validity(c.now)
+		if len(v) == 0 {
+			v = validity
+		}
+		typ := "sub"
+		if sub.secret {
+			typ = "ssb"
+		}
+		c.record(typ, v, itoa(sub.bits), itoa(sub.algo), keyIDString(sub.keyid), utoa(sub.created), utoa(sub.expires), "", "", "", "", sub.usage, "", "", "", "", sub.curve, "")
+		c.fpr(sub)
+		c.sigs(primary, sub.sigs, name)
+	}
+}
+
+//fpr outputs fpr record
+func (c *colonizer) fpr(k *colonsKey) {
+	if len(k.fpr) > 0 {
+		c.record("fpr", "", "", "", "", "", "", "", "", hexString(k.fpr))
+	}
+}
+
+//sigs outputs sig and rev records (name is user ID of primary key)
+func (c *colonizer) sigs(primary *colonsKey, sigs []*signature, name string) {
+	if !c.sigList {
+		return
+	}
+	for _, sig := range sigs {
+		typ := "sig"
+		switch sig.sigType {
+		case 0x20, 0x28, 0x30:
+			typ = "rev"
+		}
+		expires := uint32(0)
+		if b := sig.hashedSubpacket(3); len(b) >= 4 {
+			if d := binary.BigEndian.Uint32(b); d > 0 {
+				expires = sig.created + d
+			}
+		}
+		issuer := "[User ID not found]"
+		if len(primary.keyid) > 0 && bytes.Equal(sig.keyID, primary.keyid) {
+			issuer = name
+		}
+		class := fmt.Sprintf("%02x", sig.sigType)
+		if b := sig.hashedSubpacket(4); len(b) > 0 && b[0] == 0 {
+			class += "l"
+		} else {
+			class += "x"
+		}
+		fpr := ""
+		for _, sub := range append(append([]subpacket{}, sig.hashed...), sig.unhashed...) {
+			if sub.typ == 33 && len(sub.body) > 1 {
+				fpr = hexString(sub.body[1:])
+				break
+			}
+		}
+		c.record(typ, "", "", itoa(sig.pubAlg), keyIDString(sig.keyID), utoa(sig.created), utoa(expires), "", "", colonsEscape(issuer), class, "", fpr, "", "", itoa(sig.hashAlg))
+	}
+}
+
+//record outputs colon-delimited record
+func (c *colonizer) record(fields ...string) {
+	for _, f := range fields {
+		c.w.WriteString(f)
+		c.w.WriteString(":")
+	}
+	c.w.WriteString("\n")
+}
+
+//newColonsKey returns colonsKey instance decoded from key packet
+func newColonsKey(item *result.Item) *colonsKey {
+	k := &colonsKey{secret: item.Code == 5 || item.Code == 7}
+	kp := newKeyPacket(item)
+	if kp == nil {
+		return k
+	}
+	k.version = kp.version
+	if kp.version < 2 || 5 < kp.version {
+		return k
+	}
+	k.created = kp.created
+	if kp.version < 4 && kp.days > 0 {
+		k.expires = k.created + uint32(kp.days)*86400
+	}
+	k.algo = kp.pubAlg
+	if oid := kp.curve(); oid != nil {
+		k.curve = curveName(oid)
+		k.bits = curveBits[k.curve]
+	} else if numPubKey(k.algo) > 0 && len(kp.pkeys) > 0 {
+		k.bits = kp.pkeys[0].bits
+	}
+	k.keyid = kp.keyID()
+	k.fpr = kp.fingerprint()
+	k.usage = algoUsage(k.algo, item.Code == 5 || item.Code == 6)
+	return k
+}
+
+//applySelfSig applies key flags and key expiration time in self-signature
+func (k *colonsKey) applySelfSig(sig *signature) {
+	if b := sig.hashedSubpacket(27); len(b) > 0 {
+		k.usage = flagsUsage(b[0])
+	}
+	if b := sig.hashedSubpacket(9); len(b) >= 4 && k.version >= 4 {
+		k.expires = 0
+		if d := binary.BigEndian.Uint32(b); d > 0 {
+			k.expires = k.created + d
+		}
+	}
+}
+
+//validity returns validity of key derived without signature verification ("r", "e" or empty)
+func (k *colonsKey) validity(now time.Time) string {
+	switch {
+	case k.revoked:
+		return "r"
+	case k.expires > 0 && int64(k.expires) <= now.Unix():
+		return "e"
+	}
+	return ""
+}
+
+//hashedSubpacket returns body of sub-packet in hashed area
+func (sig *signature) hashedSubpacket(typ int) []byte {
+	for _, sub := range sig.hashed {
+		if sub.typ == typ {
+			return sub.body
+		}
+	}
+	return nil
+}
+
+//flagsUsage returns capabilities from key flags
+func flagsUsage(flags byte) string {
+	usage := ""
+	if flags&0x0c != 0 {
+		usage += "e"
+	}
+	if flags&0x02 != 0 {
+		usage += "s"
+	}
+	if flags&0x01 != 0 {
+		usage += "c"
+	}
+	if flags&0x20 != 0 {
+		usage += "a"
+	}
+	return usage
+}
+
+//algoUsage returns capabilities from public-key algorithm (when key flags are not present)
+func algoUsage(algo int, primary bool) string {
+	usage := ""
+	switch algo {
+	case 1, 2, 16, 18, 20: //RSA, Elgamal, ECDH
+		usage += "e"
+	}
+	switch algo {
+	case 1, 3, 17, 19, 20, 22: //RSA, DSA, ECDSA, EdDSA
+		usage += "s"
+		if primary {
+			usage += "c"
+		}
+	}
+	return usage
+}
+
+//colonsEscape returns string escaped for colon-delimited record
+func colonsEscape(s string) string {
+	buf := &strings.Builder{}
+	for _, b := range []byte(s) {
+		if b < 0x20 || b == ':' || b == '\\' || b == 0x7f {
+			fmt.Fprintf(buf, "\\x%02x", b)
+			continue
+		}
+		buf.WriteByte(b)
+	}
+	return buf.String()
+}
+
+func itoa(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", i)
+}
+
+func utoa(u uint32) string {
+	if u == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", u)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"testing"
+	"time"
+)
+
+func TestColons(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		opts    []ColonsOpt
+		content string
+	}{
+		{name: "../testdata/eccpub.asc", armored: true, opts: []ColonsOpt{}, content: `pub:e:256:19:31FBFDA95FBBFA18:1422066111:1422670911:::::sc:::::nistp256:::0:
+fpr:::::::::8B2019D433A7729E5BFE577931FBFDA95FBBFA18:
+uid:e::::1422066111::::John Doe (forECC) <john@examle.com>::::::::::0:
+sub:e:256:18:EE066BFE252C4D79:1422066111:1422670911:::::e:::::nistp256::
+fpr:::::::::8F2FD10A4D10BF691D1D8086EE066BFE252C4D79:
+`},
+		{name: "../testdata/eccpub.asc", armored: true, opts: []ColonsOpt{WithNow(time.Unix(1422100000, 0)), WithSigList(true)}, content: `pub::256:19:31FBFDA95FBBFA18:1422066111:1422670911:::::scESC:::::nistp256:::0:
+fpr:::::::::8B2019D433A7729E5BFE577931FBFDA95FBBFA18:
+uid:::::1422066111::::John Doe (forECC) <john@examle.com>::::::::::0:
+sig:::19:31FBFDA95FBBFA18:1422066111::::John Doe (forECC) <john@examle.com>:13x:::::8:
+sub::256:18:EE066BFE252C4D79:1422066111:1422670911:::::e:::::nistp256::
+fpr:::::::::8F2FD10A4D10BF691D1D8086EE066BFE252C4D79:
+sig:::19:31FBFDA95FBBFA18:1422066111::::John Doe (forECC) <john@examle.com>:18x:::::8:
+`},
+		{name: "../testdata/eccsig.asc", armored: true, opts: []ColonsOpt{}, content: ""},
+	}
+
+	for _, tc := range testCases {
+		r, err := Colons(parseFile(t, tc.name, tc.armored), tc.opts...)
+		if err != nil {
+			t.Fatalf("Colons() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("Colons(%s) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestColonsEscape(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{s: "John Doe <john@example.com>", want: "John Doe <john@example.com>"},
+		{s: "foo:bar\\baz\n", want: "foo\\x3abar\\x5cbaz\\x0a"},
+		{s: "日本語", want: "日本語"},
+	}
+	for _, tc := range testCases {
+		if s := colonsEscape(tc.s); s != tc.want {
+			t.Errorf("colonsEscape(%v) = \"%v\", want \"%v\".", tc.s, s, tc.want)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
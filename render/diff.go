@@ -0,0 +1,325 @@
+package render
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//Operations of changes in Diff
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+)
+
+//Diff class is structural difference between two OpenPGP inputs
+type Diff struct {
+	Changes []*DiffChange `json:"Change,omitempty"`
+}
+
+//DiffChange class is added, removed or modified packet (path is labels of certificate, component and signature)
+type DiffChange struct {
+	Op     string       `json:"op"`
+	Path   []string     `json:"path"`
+	Note   string       `json:"note,omitempty"`
+	Fields []*DiffField `json:"Field,omitempty"`
+}
+
+//DiffField class is added, removed or modified field in packet
+type DiffField struct {
+	Op   string `json:"op"`
+	Name string `json:"name"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+//diffNode class is packet aligned by label (key, user ID, subkey and signature)
+type diffNode struct {
+	label    string //key for alignment (e.g. "subkey 0x...")
+	pair     string //key for pairing unmatched signatures (type and issuer)
+	note     string
+	item     *result.Item
+	children []*diffNode
+}
+
+//Compare returns structural difference from a to b (packets are aligned by fingerprint, user ID and signature issuer/time)
+func Compare(a, b *result.Info) *Diff {
+	d := &Diff{}
+	d.compare([]string{}, diffNodes(packetsOf(a)), diffNodes(packetsOf(b)))
+	return d
+}
+
+//packetsOf returns packets in result.Info (nil safe)
+func packetsOf(info *result.Info) []*result.Item {
+	if info == nil {
+		return nil
+	}
+	return info.Packets
+}
+
+//compare appends changes between lists of nodes
+func (d *Diff) compare(path []string, as, bs []*diffNode) {
+	as, bs = labelled(as), labelled(bs)
+	matched := map[*diffNode]*diffNode{}
+	used := map[*diffNode]bool{}
+	index := map[string]*diffNode{}
+	for _, b := range bs {
+		index[b.label] = b
+	}
+	for _, a := range as {
+		if b, ok := index[a.label]; ok {
+			matched[a] = b
+			used[b] = true
+		}
+	}
+	for _, a := range as {
+		if _, ok := matched[a]; ok || len(a.pair) == 0 {
+			continue
+		}
+		for _, b := range bs {
+			if !used[b] && b.pair == a.pair { //e.g. refreshed self-signature
+				matched[a] = b
+				used[b] = true
+				break
+			}
+		}
+	}
+	for _, a := range as {
+		b, ok := matched[a]
+		if !ok {
+			d.Changes = append(d.Changes, &DiffChange{Op: DiffRemoved, Path: appendPath(path, a.label), Note: a.note})
+			continue
+		}
+		p := appendPath(path, b.label)
+		if fields := diffFields(a.item, b.item); len(fields) > 0 {
+			d.Changes = append(d.Changes, &DiffChange{Op: DiffModified, Path: p, Note: b.note, Fields: fields})
+		}
+		d.compare(p, a.children, b.children)
+	}
+	for _, b := range bs {
+		if !used[b] {
+			d.Changes = append(d.Changes, &DiffChange{Op: DiffAdded, Path: appendPath(path, b.label), Note: b.note})
+		}
+	}
+}
+
+//Text returns difference in text format
+func (d *Diff) Text() io.Reader {
+	buf := &bytes.Buffer{}
+	if d == nil {
+		return buf
+	}
+	marks := map[string]string{DiffAdded: "+", DiffRemoved: "-", DiffModified: "~"}
+	for _, c := range d.Changes {
+		fmt.Fprintf(buf, "%s %s", marks[c.Op], strings.Join(c.Path, " / "))
+		if len(c.Note) > 0 {
+			fmt.Fprintf(buf, " (%s)", c.Note)
+		}
+		buf.WriteString("\n")
+		for _, f := range c.Fields {
+			switch f.Op {
+			case DiffAdded:
+				fmt.Fprintf(buf, "\t+ %s: %s\n", f.Name, f.New)
+			case DiffRemoved:
+				fmt.Fprintf(buf, "\t- %s: %s\n", f.Name, f.Old)
+			default:
+				fmt.Fprintf(buf, "\t~ %s: %s -> %s\n", f.Name, f.Old, f.New)
+			}
+		}
+	}
+	return buf
+}
+
+//JSON returns difference in JSON format
+func (d *Diff) JSON(indent int) (io.Reader, error) {
+	if d == nil {
+		return bytes.NewReader([]byte{}), nil
+	}
+	if indent > 0 {
+		b, err := json.MarshalIndent(d, "", strings.Repeat(" ", indent))
+		return bytes.NewReader(b), errs.Wrap(err)
+	}
+	b, err := json.Marshal(d)
+	return bytes.NewReader(b), errs.Wrap(err)
+}
+
+//diffNodes returns nodes of packets (user IDs, subkeys and signatures are children of certificate)
+func diffNodes(items []*result.Item) []*diffNode {
+	nodes := []*diffNode{}
+	var cert, comp *diffNode
+	for _, itm := range items {
+		if itm == nil || itm.Kind != result.KindPacket {
+			continue
+		}
+		node := newDiffNode(itm)
+		switch {
+		case itm.Code == 5 || itm.Code == 6:
+			cert, comp = node, node
+		case cert != nil && (itm.Code == 7 || itm.Code == 14 || itm.Code == 13 || itm.Code == 17):
+			comp = node
+			cert.children = append(cert.children, node)
+			continue
+		case cert != nil && (itm.Code == 2 || itm.Code == 12):
+			comp.children = append(comp.children, node)
+			continue
+		default:
+			cert, comp = nil, nil
+			node.children = diffNodes(itm.Items) //nested packets (compressed data packet etc.)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+//newDiffNode returns node of packet
+func newDiffNode(item *result.Item) *diffNode {
+	node := &diffNode{label: packetName(item), item: item}
+	switch item.Code {
+	case 5, 6, 7, 14:
+		node.label = "key "
+		if item.Code == 7 || item.Code == 14 {
+			node.label = "subkey "
+		}
+		if fp := itemFingerprint(item); len(fp) > 0 {
+			node.label += fp
+		} else {
+			node.label += itemKeyID(item)
+		}
+		node.note = findValue("Public-key Algorithm", item)
+	case 13:
+		node.label = "user-id " + strconv.Quote(findValue("User ID", item))
+	case 17:
+		sum := sha256.Sum256(item.Raw)
+		node.label = fmt.Sprintf("user-attribute %x", sum[:8])
+	case 2:
+		sig := newSignature(item)
+		if sig == nil {
+			break
+		}
+		issuer := "unknown"
+		if len(sig.keyID) > 0 {
+			issuer = fmt.Sprintf("0x%x", sig.keyID)
+		}
+		node.pair = fmt.Sprintf("sig 0x%02x by %s", sig.sigType, issuer)
+		node.label = node.pair + " at " + time.Unix(int64(sig.created), 0).UTC().Format(time.RFC3339)
+		node.note = findValue("Signiture Type", item)
+	}
+	return node
+}
+
+//packetSizePattern is pattern of size in name of item (e.g. "Signature Packet (tag 2) (150 bytes)")
+var packetSizePattern = regexp.MustCompile(`\s*\(\d+ bytes\)$`)
+
+//packetName returns name of item without size
+func packetName(item *result.Item) string {
+	return packetSizePattern.ReplaceAllString(item.Name, "")
+}
+
+//labelled returns nodes with unique labels (duplicated labels are numbered)
+func labelled(nodes []*diffNode) []*diffNode {
+	count := map[string]int{}
+	for _, node := range nodes {
+		count[node.label]++
+		if n := count[node.label]; n > 1 {
+			node.label += " #" + strconv.Itoa(n)
+		}
+	}
+	return nodes
+}
+
+//appendPath returns copy of path with label
+func appendPath(path []string, label string) []string {
+	return append(append([]string{}, path...), label)
+}
+
+//diffLine class is field in packet flattened for comparison
+type diffLine struct {
+	name  string
+	value string
+}
+
+//diffFields returns modified fields between packets
+func diffFields(a, b *result.Item) []*DiffField {
+	as, bs := flattenFields(a.Items, ""), flattenFields(b.Items, "")
+	index := map[string]string{}
+	for _, l := range bs {
+		index[l.name] = l.value
+	}
+	found := map[string]bool{}
+	fields := []*DiffField{}
+	for _, l := range as {
+		found[l.name] = true
+		v, ok := index[l.name]
+		switch {
+		case !ok:
+			fields = append(fields, &DiffField{Op: DiffRemoved, Name: l.name, Old: l.value})
+		case v != l.value:
+			fields = append(fields, &DiffField{Op: DiffModified, Name: l.name, Old: l.value, New: v})
+		}
+	}
+	for _, l := range bs {
+		if !found[l.name] {
+			fields = append(fields, &DiffField{Op: DiffAdded, Name: l.name, New: l.value})
+		}
+	}
+	return fields
+}
+
+//flattenFields returns fields with path names (nested packets are not fields but children of node)
+func flattenFields(items []*result.Item, prefix string) []diffLine {
+	lines := []diffLine{}
+	count := map[string]int{}
+	for _, itm := range items {
+		if itm == nil || (len(prefix) == 0 && itm.Kind == result.KindPacket) {
+			continue
+		}
+		name := prefix + packetName(itm)
+		count[name]++
+		if n := count[name]; n > 1 {
+			name += " #" + strconv.Itoa(n)
+		}
+		lines = append(lines, diffLine{name: name, value: fieldValue(itm)})
+		lines = append(lines, flattenFields(itm.Items, name+"/")...)
+	}
+	return lines
+}
+
+//fieldValue returns value, note and dump of item
+func fieldValue(item *result.Item) string {
+	values := []string{}
+	if len(item.Value) > 0 {
+		values = append(values, item.Value)
+	}
+	if len(item.Note) > 0 {
+		values = append(values, "("+item.Note+")")
+	}
+	if len(item.Dump) > 0 {
+		values = append(values, "["+item.Dump+"]")
+	}
+	return strings.Join(values, " ")
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := parseFile(t, "../testdata/diff/alice-1.asc", true)
+	b := parseFile(t, "../testdata/diff/alice-2.asc", true)
+	testCases := []struct {
+		d    *Diff
+		want []string
+	}{
+		{d: Compare(a, a), want: []string{}},
+		{d: Compare(a, b), want: []string{
+			"~ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / user-id \"Alice <alice@example.com>\" / sig 0x13 by 0x72d816a3a545c23c at 2026-10-19T14:14:37Z (Positive certification of a User ID and Public-Key packet (0x13))",
+			"\t~ Hashed Subpacket/Signature Creation Time (sub 2): 2026-10-19T14:14:35Z -> 2026-10-19T14:14:37Z",
+			"+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / user-id \"Alice <alice@example.com>\" / sig 0x10 by 0x15e5deff0c263b49 at 2026-10-19T14:14:37Z (Generic certification of a User ID and Public-Key packet (0x10))",
+			"+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / subkey 0xe26bec31f930e7fe644655693d9df6c39ff8807f / sig 0x28 by 0x72d816a3a545c23c at 2026-10-19T14:14:41Z (Subkey revocation signature (0x28))",
+			"+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / user-id \"Alice <alice@example.org>\"",
+			"+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / subkey 0x27c8bf296e440c24ca2a357ff642d07fb53db493 (EdDSA (pub 22))",
+		}},
+		{d: Compare(b, a), want: []string{
+			"- key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / user-id \"Alice <alice@example.com>\" / sig 0x10 by 0x15e5deff0c263b49 at 2026-10-19T14:14:37Z (Generic certification of a User ID and Public-Key packet (0x10))",
+			"- key 0xf90549bbb3a4149bde56c11072d816a3a545c23c / user-id \"Alice <alice@example.org>\"",
+		}},
+		{d: Compare(nil, a), want: []string{"+ key 0xf90549bbb3a4149bde56c11072d816a3a545c23c (EdDSA (pub 22))"}},
+	}
+	for _, tc := range testCases {
+		str := readAll(t, tc.d.Text())
+		if len(tc.want) == 0 && len(str) > 0 {
+			t.Errorf("Diff.Text() = \"%v\", want empty.", str)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(str, want+"\n") {
+				t.Errorf("Diff.Text() = \"%v\", want \"%v\".", str, want)
+			}
+		}
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	d := Compare(parseFile(t, "../testdata/diff/alice-1.asc", true), parseFile(t, "../testdata/diff/alice-2.asc", true))
+	r, err := d.JSON(0)
+	if err != nil {
+		t.Fatalf("Diff.JSON() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	for _, want := range []string{
+		`{"Change":[{"op":"modified","path":["key 0xf90549bbb3a4149bde56c11072d816a3a545c23c",`,
+		`{"op":"modified","name":"Hashed Subpacket/Signature Creation Time (sub 2)","old":"2026-10-19T14:14:35Z","new":"2026-10-19T14:14:37Z"}`,
+		`{"op":"added","path":["key 0xf90549bbb3a4149bde56c11072d816a3a545c23c","subkey 0x27c8bf296e440c24ca2a357ff642d07fb53db493"],"note":"EdDSA (pub 22)"}`,
+	} {
+		if !strings.Contains(str, want) {
+			t.Errorf("Diff.JSON() = \"%v\", want \"%v\".", str, want)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,303 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//DOTConfig class is styling of Graphviz DOT output (same format as dot-config.toml)
+type DOTConfig struct {
+	Graph map[string]string
+	Node  map[string]string
+	Edge  map[string]string
+}
+
+//NewDOTConfig returns DOTConfig instance decoded from TOML text
+func NewDOTConfig(r io.Reader) (*DOTConfig, error) {
+	cfg := &DOTConfig{Graph: map[string]string{}, Node: map[string]string{}, Edge: map[string]string{}}
+	var table map[string]string
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			switch strings.TrimSpace(line[1 : len(line)-1]) {
+			case "graph":
+				table = cfg.Graph
+			case "node":
+				table = cfg.Node
+			case "edge":
+				table = cfg.Edge
+			default:
+				return nil, errs.Wrap(ecode.ErrDOTConfig, errs.WithContext("line", n), errs.WithContext("table", line))
+			}
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || table == nil {
+			return nil, errs.Wrap(ecode.ErrDOTConfig, errs.WithContext("line", n))
+		}
+		value := strings.TrimSpace(kv[1])
+		if strings.HasPrefix(value, "\"") {
+			v, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, errs.Wrap(ecode.ErrDOTConfig, errs.WithCause(err), errs.WithContext("line", n))
+			}
+			value = v
+		}
+		table[strings.TrimSpace(kv[0])] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return cfg, nil
+}
+
+//write outputs default attributes of graph, node and edge
+func (cfg *DOTConfig) write(w *bytes.Buffer) {
+	if cfg == nil {
+		return
+	}
+	for _, t := range []struct {
+		name  string
+		attrs map[string]string
+	}{
+		{"graph", cfg.Graph},
+		{"node", cfg.Node},
+		{"edge", cfg.Edge},
+	} {
+		if len(t.attrs) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(t.attrs))
+		for k := range t.attrs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		attrs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			attrs = append(attrs, fmt.Sprintf("%s=%s", k, dotQuote(t.attrs[k])))
+		}
+		fmt.Fprintf(w, "\t%s [%s];\n", t.name, strings.Join(attrs, ", "))
+	}
+}
+
+//dotCert class is state of certificate (transferable public/secret key) in packet sequence
+type dotCert struct {
+	primary   string
+	keyID     []byte
+	component string
+	edge      string
+}
+
+//dotBuilder class is renderer for Graphviz DOT output
+type dotBuilder struct {
+	w        *bytes.Buffer
+	nodes    int
+	clusters int
+}
+
+//DOT returns graph of packets in Graphviz DOT language
+func DOT(info *result.Info, cfg *DOTConfig) (io.Reader, error) {
+	d := &dotBuilder{w: &bytes.Buffer{}}
+	d.w.WriteString("digraph gpgpdump {\n")
+	cfg.write(d.w)
+	if info != nil {
+		d.sequence("\t", "", info.Packets)
+	}
+	d.w.WriteString("}\n")
+	return d.w, nil
+}
+
+//sequence outputs sequence of packets (parent is node ID of container packet)
+func (d *dotBuilder) sequence(indent, parent string, items []*result.Item) {
+	cert := &dotCert{}
+	ind := indent
+	for _, item := range items {
+		if item == nil || item.Kind != result.KindPacket {
+			continue
+		}
+		switch item.Code {
+		case 5, 6:
+			if cert.primary != "" {
+				fmt.Fprintf(d.w, "%s}\n", indent)
+			}
+			keyid, _ := keyPacketID(item)
+			fmt.Fprintf(d.w, "%ssubgraph cluster_%d {\n", indent, d.clusters)
+			fmt.Fprintf(d.w, "%s\tlabel=%s;\n", indent, dotQuote("Certificate 0x"+keyIDString(keyid)))
+			d.clusters++
+			ind = indent + "\t"
+			id := d.packet(ind, item, nil)
+			cert = &dotCert{primary: id, keyID: keyid, component: id}
+			if parent != "" {
+				d.edge(ind, parent, id, "contains")
+			}
+			continue
+		case 13, 17, 7, 14:
+			if cert.primary != "" {
+				id := d.packet(ind, item, cert)
+				d.edge(ind, cert.primary, id, map[int]string{13: "user ID", 17: "user attribute", 7: "subkey", 14: "subkey"}[item.Code])
+				cert.component = id
+				continue
+			}
+		case 2, 12:
+			if cert.primary != "" {
+				id := d.packet(ind, item, cert)
+				label := ""
+				if item.Code == 2 {
+					sig := newSignature(item)
+					label = sigRole(sig, cert.keyID)
+				}
+				d.edge(ind, cert.component, id, label)
+				continue
+			}
+		}
+		if cert.primary != "" {
+			fmt.Fprintf(d.w, "%s}\n", indent)
+			cert = &dotCert{}
+			ind = indent
+		}
+		id := d.packet(ind, item, nil)
+		if parent != "" {
+			d.edge(ind, parent, id, "contains")
+		}
+	}
+	if cert.primary != "" {
+		fmt.Fprintf(d.w, "%s}\n", indent)
+	}
+}
+
+//packet outputs node of packet and packets nested in it, and returns its node ID
+func (d *dotBuilder) packet(indent string, item *result.Item, cert *dotCert) string {
+	id := fmt.Sprintf("p%d", d.nodes)
+	d.nodes++
+	lines := []string{item.Name}
+	attrs := []string{}
+	switch item.Code {
+	case 5, 6, 7, 14:
+		keyid, algo := keyPacketID(item)
+		lines = append(lines, fmt.Sprintf("%s, key ID 0x%s", sqPubAlg(algo), keyIDString(keyid)))
+		attrs = append(attrs, "shape=box")
+		if item.Code == 5 || item.Code == 6 {
+			attrs = append(attrs, "style=bold")
+		}
+	case 13:
+		lines = append(lines, sanitize(item.Raw, ""))
+		attrs = append(attrs, "shape=ellipse")
+	case 17:
+		lines = append(lines, attributeName(item))
+		attrs = append(attrs, "shape=ellipse")
+	case 2:
+		if sig := newSignature(item); sig != nil {
+			lines = append(lines, pgpName(pgpSigTypeNames, sig.sigType, fmt.Sprintf("Unknown signature type (0x%02x)", sig.sigType)))
+			if keyid := sig.keyID; keyid != nil {
+				lines = append(lines, "issuer 0x"+keyIDString(keyid))
+			}
+		}
+		attrs = append(attrs, "shape=note")
+	case 11:
+		if name, ok := newFields(item).sized("File name"); ok && len(name) > 0 {
+			lines = append(lines, sanitize(name, ""))
+		}
+		attrs = append(attrs, "shape=box", "style=rounded")
+	default:
+		attrs = append(attrs, "shape=box", "style=rounded")
+	}
+	fmt.Fprintf(d.w, "%s%s [label=%s, %s];\n", indent, id, dotQuote(strings.Join(lines, "\n")), strings.Join(attrs, ", "))
+	d.sequence(indent, id, children(item))
+	if item.Code == 2 {
+		d.embedded(indent, id, item, cert)
+	}
+	return id
+}
+
+//embedded outputs embedded signatures in signature packet
+func (d *dotBuilder) embedded(indent, id string, item *result.Item, cert *dotCert) {
+	subs := append(subpackets(item, result.KindHashedArea), subpackets(item, result.KindUnhashedArea)...)
+	for _, sub := range subs {
+		if sub.Code&0x7f != 32 {
+			continue
+		}
+		for _, itm := range sub.Items {
+			if itm == nil || itm.Kind != result.KindPacket {
+				continue
+			}
+			eid := d.packet(indent, itm, cert)
+			d.edge(indent, id, eid, "embedded")
+			if sig := newSignature(itm); sig != nil && sig.sigType == 0x19 && cert != nil {
+				d.edge(indent, eid, cert.primary, "primary key binding")
+			}
+		}
+	}
+}
+
+//edge outputs edge between nodes
+func (d *dotBuilder) edge(indent, from, to, label string) {
+	if label == "" {
+		fmt.Fprintf(d.w, "%s%s -> %s;\n", indent, from, to)
+		return
+	}
+	fmt.Fprintf(d.w, "%s%s -> %s [label=%s];\n", indent, from, to, dotQuote(label))
+}
+
+//sigRole returns role of signature in certificate
+func sigRole(sig *signature, keyid []byte) string {
+	if sig == nil {
+		return ""
+	}
+	switch sig.sigType {
+	case 0x10, 0x11, 0x12, 0x13:
+		if sig.keyID != nil && !bytes.Equal(sig.keyID, keyid) {
+			return "third-party certification"
+		}
+		return "self-signature"
+	case 0x18:
+		return "binding signature"
+	case 0x1f:
+		return "direct key signature"
+	case 0x20, 0x28, 0x30:
+		return "revocation"
+	}
+	return ""
+}
+
+//keyPacketID returns key ID and public-key algorithm of key packet (tag 5, 6, 7 and 14)
+func keyPacketID(item *result.Item) ([]byte, int) {
+	k := newKeyPacket(item)
+	if k == nil || k.version < 2 || 5 < k.version {
+		return nil, 0
+	}
+	return k.keyID(), k.pubAlg
+}
+
+//dotQuote returns quoted string in DOT language
+func dotQuote(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+	return "\"" + r.Replace(s) + "\""
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+func TestDOT(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		content string
+	}{
+		{name: "../testdata/eccpub.asc", armored: true, content: `digraph gpgpdump {
+	subgraph cluster_0 {
+		label="Certificate 0x31FBFDA95FBBFA18";
+		p0 [label="Public-Key Packet (tag 6)\nECDSA, key ID 0x31FBFDA95FBBFA18", shape=box, style=bold];
+		p1 [label="User ID Packet (tag 13)\nJohn Doe (forECC) <john@examle.com>", shape=ellipse];
+		p0 -> p1 [label="user ID"];
+		p2 [label="Signature Packet (tag 2)\nPositive certification of a User ID and Public Key packet\nissuer 0x31FBFDA95FBBFA18", shape=note];
+		p1 -> p2 [label="self-signature"];
+		p3 [label="Public-Subkey Packet (tag 14)\nECDH, key ID 0xEE066BFE252C4D79", shape=box];
+		p0 -> p3 [label="subkey"];
+		p4 [label="Signature Packet (tag 2)\nSubkey Binding Signature\nissuer 0x31FBFDA95FBBFA18", shape=note];
+		p3 -> p4 [label="binding signature"];
+	}
+}
+`},
+		{name: "../testdata/comp-sig.asc", armored: true, content: `digraph gpgpdump {
+	p0 [label="Compressed Data Packet (tag 8)", shape=box, style=rounded];
+	p1 [label="One-Pass Signature Packet (tag 4)", shape=box, style=rounded];
+	p0 -> p1 [label="contains"];
+	p2 [label="Literal Data Packet (tag 11)", shape=box, style=rounded];
+	p0 -> p2 [label="contains"];
+	p3 [label="Signature Packet (tag 2)\nSignature of a binary document\nissuer 0xB4DA3BAE7E20B81C", shape=note];
+	p0 -> p3 [label="contains"];
+}
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := DOT(parseFile(t, tc.name, tc.armored), nil)
+		if err != nil {
+			t.Fatalf("DOT() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("DOT(%s) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestDOTConfig(t *testing.T) {
+	cfg, err := NewDOTConfig(strings.NewReader("# comment\n[node]\n  fontname = \"Inconsolata\"\n[edge]\n  color = \"red\"\n  penwidth = 2\n"))
+	if err != nil {
+		t.Fatalf("NewDOTConfig() = \"%+v\", want nil error.", err)
+	}
+	r, err := DOT(nil, cfg)
+	if err != nil {
+		t.Fatalf("DOT() = \"%+v\", want nil error.", err)
+	}
+	res := "digraph gpgpdump {\n\tnode [fontname=\"Inconsolata\"];\n\tedge [color=\"red\", penwidth=\"2\"];\n}\n"
+	if str := readAll(t, r); str != res {
+		t.Errorf("DOT() = \"%v\", want \"%v\".", str, res)
+	}
+
+	for _, s := range []string{"[foo]\n", "fontname = \"Inconsolata\"\n", "[node]\nfontname\n", "[node]\nfontname = \"foo\n"} {
+		if _, err := NewDOTConfig(strings.NewReader(s)); !errors.Is(err, ecode.ErrDOTConfig) {
+			t.Errorf("NewDOTConfig(%q) = \"%+v\", want \"%+v\".", s, err, ecode.ErrDOTConfig)
+		}
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
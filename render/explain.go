@@ -0,0 +1,79 @@
+package render
+
+import (
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//Explain returns copy of result.Info with plain-language descriptions and references to specifications
+func Explain(info *result.Info) *result.Info {
+	if info == nil {
+		return nil
+	}
+	res := result.New()
+	for _, itm := range info.Packets {
+		if itm != nil {
+			res.Add(explainItem(itm, nil))
+		}
+	}
+	return res
+}
+
+//explainItem returns copy of item with description and reference (parent is item including it)
+func explainItem(item, parent *result.Item) *result.Item {
+	itm := *item
+	if exp, ok := Explanation(item, parent); ok {
+		itm.Description = exp.Description
+		itm.Reference = exp.Reference
+	}
+	itm.Items = nil
+	for _, child := range item.Items {
+		if child != nil {
+			itm.Items = append(itm.Items, explainItem(child, item))
+		}
+	}
+	return &itm
+}
+
+//Explanation returns values.Explanation of item (parent is item including it)
+func Explanation(item, parent *result.Item) (values.Explanation, bool) {
+	switch item.Kind {
+	case result.KindPacket:
+		return values.ExplainTag(item.Code)
+	case result.KindSubpacket:
+		if parent != nil && parent.Kind == result.KindSubpacketArea {
+			return values.ExplainAttribute(item.Code & 0x7f)
+		}
+		return values.ExplainSubpacket(item.Code & 0x7f)
+	case result.KindSubpacketArea:
+		return values.ExplainTag(17)
+	}
+	return values.ExplainField(item.Name, parent)
+}
+
+//explanationTitle returns reference and description of item for tooltip (parent is item including it)
+func explanationTitle(item, parent *result.Item) string {
+	exp, ok := Explanation(item, parent)
+	if !ok {
+		return ""
+	}
+	if len(exp.Reference) == 0 {
+		return exp.Description
+	}
+	return exp.Reference + ": " + exp.Description
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
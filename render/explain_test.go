@@ -0,0 +1,90 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	info := parseFile(t, "../testdata/eccsig.asc", true)
+	res := Explain(info)
+	if len(info.Packets[0].Description) > 0 {
+		t.Errorf("Explain() changes original result: \"%v\".", info.Packets[0].Description)
+	}
+	sig := res.Packets[0]
+	if want := "RFC 9580 Section 5.2"; sig.Reference != want {
+		t.Errorf("Explain().Reference = \"%v\", want \"%v\".", sig.Reference, want)
+	}
+	issuer := sig.Items[5].Items[0]
+	if want := "Key ID of the key that made the signature."; issuer.Description != want {
+		t.Errorf("Explain().Description of %v = \"%v\", want \"%v\".", issuer.Name, issuer.Description, want)
+	}
+	if mpi := sig.Items[8]; len(mpi.Description) > 0 {
+		t.Errorf("Explain().Description of %v = \"%v\", want empty.", mpi.Name, mpi.Description)
+	}
+	if Explain(nil) != nil {
+		t.Error("Explain(nil) is not nil.")
+	}
+}
+
+func TestTextExplain(t *testing.T) {
+	r, err := Text(Explain(parseFile(t, "../testdata/eccsig.asc", true)))
+	if err != nil {
+		t.Fatalf("Text() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	testCases := []string{
+		"Signature Packet (tag 2) (94 bytes) [1]\n\tVersion: 4 (current) [2]\n",
+		"\tUnhashed Subpacket (10 bytes) [8]\n\t\tIssuer (sub 16): 0x31fbfda95fbbfa18 [9]\n",
+		"\tECDSA value s (252 bits)\n\t[1] Signature over data or a key component, made with the issuer's key. (RFC 9580 Section 5.2)\n",
+		"\t[10] Left 16 bits of the signed hash, for quick rejection of wrong signatures. (RFC 9580 Section 5.2.4)\n",
+	}
+	for _, tc := range testCases {
+		if !strings.Contains(str, tc) {
+			t.Errorf("Text() = \"%v\", want to contain \"%v\".", str, tc)
+		}
+	}
+}
+
+func TestTextExplainNumbers(t *testing.T) {
+	r, err := Text(Explain(parseFile(t, "../testdata/eccpub.asc", true)))
+	if err != nil {
+		t.Fatalf("Text() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	if n := strings.Count(str, "\t[17] Symmetric cipher. (RFC 9580 Section 9.3)\n"); n != 1 {
+		t.Errorf("Text() has %d footnotes of symmetric algorithms, want 1.", n)
+	}
+	if n := strings.Count(str, "(sym 9) [17]\n"); n != 1 {
+		t.Errorf("Text() has %d references to footnote, want 1.", n)
+	}
+	if !strings.Contains(str, "User ID Packet (tag 13) (35 bytes) [6]\n") {
+		t.Errorf("Text() = \"%v\", want footnote numbers across packets.", str)
+	}
+}
+
+func TestHTMLExplain(t *testing.T) {
+	r, err := HTML(Explain(parseFile(t, "../testdata/eccsig.asc", true)))
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	want := `Issuer (sub 16): 0x31fbfda95fbbfa18 <span class="explain">Key ID of the key that made the signature. (RFC 9580 Section 5.2.3.12)</span></div>`
+	if str := readAll(t, r); !strings.Contains(str, want) {
+		t.Errorf("HTML() does not contain \"%v\".", want)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
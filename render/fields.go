@@ -0,0 +1,202 @@
+package render
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//fields class is view of field items in parsed packet (values of fields are taken from raw data of packet by spans of items)
+type fields struct {
+	raw   []byte
+	items []*result.Item
+}
+
+//newFields returns fields instance of packet (or sub-packet) item
+func newFields(item *result.Item) *fields {
+	if item == nil {
+		return &fields{}
+	}
+	return &fields{raw: item.Raw, items: item.Items}
+}
+
+//in returns fields in container item of name (e.g. "Public-Key" and "Secret-Key")
+func (f *fields) in(name string) (*fields, bool) {
+	itm := f.find(name)
+	if itm == nil {
+		return &fields{raw: f.raw}, false
+	}
+	return &fields{raw: f.raw, items: itm.Items}, true
+}
+
+//find returns first field item of name (sub-packet areas and nested packets are not searched)
+func (f *fields) find(name string) *result.Item {
+	return findField(f.items, name)
+}
+
+func findField(items []*result.Item, name string) *result.Item {
+	for _, itm := range items {
+		if itm == nil || itm.Kind != result.KindNone {
+			continue
+		}
+		if itm.Name == name {
+			return itm
+		}
+		if found := findField(itm.Items, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+//after returns field items following item of name in same level
+func (f *fields) after(name string) []*result.Item {
+	for i, itm := range f.items {
+		if itm != nil && itm.Name == name {
+			return f.items[i+1:]
+		}
+	}
+	return nil
+}
+
+//end returns end of spans of field items
+func (f *fields) end() int {
+	end := 0
+	for _, itm := range f.items {
+		if itm != nil && itm.Span != nil {
+			if e := clamp(itm.Span.End, len(f.raw)); e > end {
+				end = e
+			}
+		}
+	}
+	return end
+}
+
+//octets returns octets in span of field item
+func (f *fields) octets(itm *result.Item) []byte {
+	if itm == nil || itm.Span == nil {
+		return nil
+	}
+	start, end := clamp(itm.Span.Start, len(f.raw)), clamp(itm.Span.End, len(f.raw))
+	if start >= end {
+		return nil
+	}
+	return f.raw[start:end]
+}
+
+//tail returns last n octets in span of field item of name (span of item includes octets read before it but not itemized, e.g. length octets)
+func (f *fields) tail(name string, n int) ([]byte, bool) {
+	b := f.octets(f.find(name))
+	if len(b) < n {
+		return nil, false
+	}
+	return b[len(b)-n:], true
+}
+
+//byte returns one-octet value of field item of name
+func (f *fields) byte(name string) (byte, bool) {
+	b, ok := f.tail(name, 1)
+	if !ok {
+		return 0, false
+	}
+	return b[0], true
+}
+
+//uint16 returns two-octet value of field item of name
+func (f *fields) uint16(name string) (int, bool) {
+	b, ok := f.tail(name, 2)
+	if !ok {
+		return 0, false
+	}
+	return int(binary.BigEndian.Uint16(b)), true
+}
+
+//uint32 returns four-octet value of field item of name
+func (f *fields) uint32(name string) (uint32, bool) {
+	b, ok := f.tail(name, 4)
+	if !ok {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(b), true
+}
+
+//sized returns one-octet length-prefixed data of field item of name (ECC curve OID, file name, etc.)
+func (f *fields) sized(name string) ([]byte, bool) {
+	return sizedData(f.octets(f.find(name)))
+}
+
+//sizedData returns data in span with one-octet length prefix
+func sizedData(b []byte) ([]byte, bool) {
+	if len(b) == 0 || int(b[0]) > len(b)-1 {
+		return nil, false
+	}
+	return b[len(b)-int(b[0]):], true
+}
+
+//value returns multi-precision integer (or octet string) of field item.
+//Bit length is taken from note of item, so that it is kept in masked secret-key material.
+func (f *fields) value(itm *result.Item) mpiValue {
+	b := f.octets(itm)
+	if itm == nil {
+		return mpiValue{}
+	}
+	var n int
+	if _, err := fmt.Sscanf(itm.Note, "%d bits", &n); err == nil {
+		if l := (n + 7) / 8; l <= len(b) {
+			b = b[len(b)-l:]
+		}
+		return mpiValue{data: b, bits: n}
+	}
+	if _, err := fmt.Sscanf(itm.Note, "%d bytes", &n); err == nil && n <= len(b) {
+		b = b[len(b)-n:]
+	}
+	return mpiValue{data: b, bits: 8 * len(b)}
+}
+
+//values returns multi-precision integers (or octet strings) of field items
+func (f *fields) values(items []*result.Item) []mpiValue {
+	vals := []mpiValue{}
+	for _, itm := range items {
+		if itm != nil && itm.Kind == result.KindNone {
+			vals = append(vals, f.value(itm))
+		}
+	}
+	return vals
+}
+
+//dump returns octets of dump string in field item of name (for fields without span)
+func (f *fields) dump(name string) []byte {
+	itm := f.find(name)
+	if itm == nil {
+		return nil
+	}
+	b, err := hex.DecodeString(strings.Join(strings.Fields(itm.Dump), ""))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+//mpiValue class is multi-precision integer (or octet string) in packet
+type mpiValue struct {
+	data []byte
+	bits int
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
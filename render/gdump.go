@@ -0,0 +1,739 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//GDump returns text compatible with "gpg --list-packets" output
+func GDump(info *result.Info) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	if info == nil {
+		return buf, nil
+	}
+	for _, item := range info.Packets {
+		gdumpPacket(buf, item, 0)
+	}
+	return buf, nil
+}
+
+//gdumpPacket outputs packet and packets in compressed data
+func gdumpPacket(w *bytes.Buffer, item *result.Item, base int64) {
+	if item == nil || item.Kind != result.KindPacket {
+		return
+	}
+	tag := item.Code
+	plen := int64(len(item.Raw))
+	partial := false
+	if h := item.Header; h != nil {
+		partial = h.Partial
+		bodyLen := h.BodyLen
+		if partial {
+			bodyLen = 0
+		}
+		fmt.Fprintf(w, "# off=%d ctb=%02x tag=%d hlen=%d plen=%d", base+h.Offset, h.CTB, tag, h.HeaderLen, bodyLen)
+		if partial {
+			if h.NewFormat() {
+				w.WriteString(" partial")
+			} else {
+				w.WriteString(" indeterminate")
+			}
+		}
+		if h.NewFormat() {
+			w.WriteString(" new-ctb")
+		}
+		w.WriteString("\n")
+	}
+	f := newFields(item)
+	switch tag {
+	case 1:
+		gdumpPubkeyEnc(w, f)
+	case 2:
+		gdumpSignature(w, newSignature(item))
+	case 3:
+		gdumpSymkeyEnc(w, f)
+	case 4:
+		gdumpOnepass(w, f)
+	case 5, 6, 7, 14:
+		gdumpKey(w, newKeyPacket(item), tag)
+	case 8:
+		algo, _ := f.byte("Compression Algorithm")
+		fmt.Fprintf(w, ":compressed packet: algo=%d\n", algo)
+		if h := item.Header; h != nil {
+			base += h.Offset + int64(h.HeaderLen) + 1
+		}
+		for _, itm := range item.Items {
+			if itm != nil && itm.Header != nil {
+				gdumpPacket(w, itm, base)
+			}
+		}
+	case 9:
+		w.WriteString(":encrypted data packet:\n")
+		gdumpLength(w, plen, partial)
+		gdumpDecrypted(w, item)
+	case 10:
+		if bytes.Equal(item.Raw, []byte("PGP")) {
+			w.WriteString(":marker packet: PGP\n")
+		} else {
+			w.WriteString(":marker packet: [invalid]\n")
+		}
+	case 11:
+		gdumpLiteral(w, f, partial)
+	case 12:
+		gdumpTrust(w, f.octets(f.find("Trust")))
+	case 13:
+		fmt.Fprintf(w, ":user ID packet: \"%s\"\n", escapeName(item.Raw))
+	case 17:
+		fmt.Fprintf(w, ":attribute packet: %s\n", attributeName(item))
+	case 18:
+		w.WriteString(":encrypted data packet:\n")
+		gdumpLength(w, plen, partial)
+		if v, ok := seipdVersion(f); ok && v == 1 {
+			w.WriteString("\tmdc_method: 2\n")
+		}
+		gdumpDecrypted(w, item)
+	case 19:
+		fmt.Fprintf(w, ":mdc packet: length=%d\n", plen)
+	case 20:
+		v, _ := f.byte("Version")
+		cipher, _ := f.byte("Symmetric Algorithm")
+		aead, _ := f.byte("AEAD Algorithm")
+		cb, ok := f.byte("Chunk size")
+		if v != 1 || !ok {
+			fmt.Fprintf(w, ":aead encrypted packet: [unknown version %d]\n", v)
+			break
+		}
+		fmt.Fprintf(w, ":aead encrypted packet: cipher=%d aead=%d cb=%d\n", cipher, aead, cb)
+		gdumpLength(w, plen, partial)
+		gdumpDecrypted(w, item)
+	default:
+		fmt.Fprintf(w, ":unknown packet: type %2d, length %d\n", tag, plen)
+	}
+}
+
+//seipdVersion returns version of Sym. Encrypted Integrity Protected Data Packet (tag 18)
+func seipdVersion(f *fields) (byte, bool) {
+	if v, ok := f.byte("Version"); ok {
+		return v, true
+	}
+	//version 1 packet has version octet in head of encrypted data
+	if b := f.octets(f.find("Encrypted data")); len(b) > 0 {
+		return b[0], true
+	}
+	return 0, false
+}
+
+//gdumpDecrypted outputs packets in decrypted data (offsets are in decrypted data)
+func gdumpDecrypted(w *bytes.Buffer, item *result.Item) {
+	for _, itm := range item.Items {
+		if itm != nil && itm.Header != nil {
+			gdumpPacket(w, itm, 0)
+		}
+	}
+}
+
+func gdumpLength(w *bytes.Buffer, plen int64, partial bool) {
+	if partial || plen == 0 {
+		w.WriteString("\tlength: unknown\n")
+		return
+	}
+	fmt.Fprintf(w, "\tlength: %d\n", plen)
+}
+
+//gdumpPubkeyEnc outputs Public-Key Encrypted Session Key Packet (tag 1)
+func gdumpPubkeyEnc(w *bytes.Buffer, f *fields) {
+	v, _ := f.byte("Version")
+	if v != 2 && v != 3 {
+		return
+	}
+	keyid, _ := f.tail("Key ID", 8)
+	algo, ok := f.byte("Public-key Algorithm")
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, ":pubkey enc packet: version %d, algo %d, keyid %s\n", v, algo, keyIDString(keyid))
+	n := numEnc(int(algo))
+	if n == 0 {
+		fmt.Fprintf(w, "\tunsupported algorithm %d\n", algo)
+		return
+	}
+	for i, val := range f.values(f.after("Public-key Algorithm")) {
+		if i >= n {
+			break
+		}
+		bits := val.bits
+		if algo == 18 && i == 1 {
+			bits = (len(val.data) + 1) * 8
+		}
+		fmt.Fprintf(w, "\tdata: [%d bits]\n", bits)
+	}
+}
+
+//gdumpSignature outputs Signature Packet (tag 2)
+func gdumpSignature(w *bytes.Buffer, sig *signature) {
+	if sig == nil || sig.version < 2 || 5 < sig.version || len(sig.left) < 2 {
+		return
+	}
+	fmt.Fprintf(w, ":signature packet: algo %d, keyid %s\n", sig.pubAlg, keyIDString(sig.keyID))
+	fmt.Fprintf(w, "\tversion %d, created %d, md5len %d, sigclass 0x%02x\n", sig.version, sig.created, sig.md5len, sig.sigType)
+	fmt.Fprintf(w, "\tdigest algo %d, begin of digest %02x %02x\n", sig.hashAlg, sig.left[0], sig.left[1])
+	for _, sub := range sig.hashed {
+		gdumpSubpacket(w, sub, true)
+	}
+	for _, sub := range sig.unhashed {
+		gdumpSubpacket(w, sub, false)
+	}
+	n := numSig(sig.pubAlg)
+	if n == 0 {
+		fmt.Fprintf(w, "\tunknown algorithm %d\n", sig.pubAlg)
+		return
+	}
+	for i, val := range sig.values {
+		if i >= n {
+			break
+		}
+		fmt.Fprintf(w, "\tdata: [%d bits]\n", val.bits)
+	}
+}
+
+//subpackets returns sub-packets in area of packet
+func subpackets(item *result.Item, kind result.Kind) []*result.Item {
+	subs := []*result.Item{}
+	for _, area := range item.Items {
+		if area == nil || area.Kind != kind {
+			continue
+		}
+		for _, sub := range area.Items {
+			if sub != nil && sub.Kind == result.KindSubpacket {
+				subs = append(subs, sub)
+			}
+		}
+	}
+	return subs
+}
+
+//gdumpSubpacket outputs sub-packet in signature packet
+func gdumpSubpacket(w *bytes.Buffer, sub subpacket, hashed bool) {
+	b := sub.body
+	typ := sub.typ
+	w.WriteString("\t")
+	if sub.critical {
+		w.WriteString("critical ")
+	}
+	if hashed {
+		w.WriteString("hashed ")
+	}
+	fmt.Fprintf(w, "subpkt %d len %d (", typ, len(b))
+	p := ""
+	switch typ {
+	case 2:
+		if len(b) >= 4 {
+			fmt.Fprintf(w, "sig created %s", timestamp(binary.BigEndian.Uint32(b)))
+		}
+	case 3:
+		if len(b) >= 4 {
+			if t := binary.BigEndian.Uint32(b); t != 0 {
+				fmt.Fprintf(w, "sig expires after %s", timeValue(t))
+			} else {
+				w.WriteString("sig does not expire")
+			}
+		}
+	case 4:
+		if len(b) > 0 {
+			fmt.Fprintf(w, "%sexportable", not(b[0]))
+		}
+	case 5:
+		if len(b) != 2 {
+			p = "[invalid trust subpacket]"
+		} else {
+			fmt.Fprintf(w, "trust signature of depth %d, value %d", b[0], b[1])
+		}
+	case 6:
+		if len(b) == 0 {
+			p = "[invalid regexp subpacket]"
+		} else {
+			fmt.Fprintf(w, "regular expression: \"%s", sanitize(b, "\""))
+			p = "\""
+		}
+	case 7:
+		if len(b) > 0 {
+			fmt.Fprintf(w, "%srevocable", not(b[0]))
+		}
+	case 9:
+		if len(b) >= 4 {
+			if t := binary.BigEndian.Uint32(b); t != 0 {
+				fmt.Fprintf(w, "key expires after %s", timeValue(t))
+			} else {
+				w.WriteString("key does not expire")
+			}
+		}
+	case 11:
+		fmt.Fprintf(w, "pref-sym-algos:%s", algoList(b))
+	case 12:
+		w.WriteString("revocation key: ")
+		if len(b) < 22 {
+			p = "[too short]"
+		} else {
+			fmt.Fprintf(w, "c=%02x a=%d f=%s", b[0], b[1], hexString(b[2:]))
+		}
+	case 16:
+		if len(b) >= 8 {
+			fmt.Fprintf(w, "issuer key ID %s", keyIDString(b[:8]))
+		}
+	case 20:
+		w.WriteString("notation: ")
+		if len(b) < 8 {
+			p = "[too short]"
+			break
+		}
+		n1 := int(binary.BigEndian.Uint16(b[4:]))
+		n2 := int(binary.BigEndian.Uint16(b[6:]))
+		if 8+n1+n2 != len(b) {
+			p = "[error]"
+			break
+		}
+		fmt.Fprintf(w, "%s=", sanitize(b[8:8+n1], ")"))
+		if b[0]&0x80 != 0 {
+			w.WriteString(sanitize(b[8+n1:], ")"))
+		} else {
+			p = "[not human readable]"
+		}
+	case 21:
+		fmt.Fprintf(w, "pref-hash-algos:%s", algoList(b))
+	case 22:
+		fmt.Fprintf(w, "pref-zip-algos:%s", algoList(b))
+	case 23:
+		fmt.Fprintf(w, "keyserver preferences:%s", flagList(b, "%02X"))
+	case 24:
+		fmt.Fprintf(w, "preferred keyserver: %s", sanitize(b, ")"))
+	case 25:
+		p = "primary user ID"
+	case 26:
+		fmt.Fprintf(w, "policy: %s", sanitize(b, ")"))
+	case 27:
+		fmt.Fprintf(w, "key flags:%s", flagList(b, "%02X"))
+	case 28:
+		p = "signer's user ID"
+	case 29:
+		if len(b) > 0 {
+			fmt.Fprintf(w, "revocation reason 0x%02x (%s", b[0], sanitize(b[1:], ")"))
+			p = ")"
+		}
+	case 30:
+		fmt.Fprintf(w, "features:%s", flagList(b, "%02x"))
+	case 32:
+		w.WriteString("signature: ")
+		if len(b) < 17 {
+			p = "[too short]"
+		} else if b[0] == 3 {
+			fmt.Fprintf(w, "v%d, class 0x%02X, algo %d, digest algo %d", b[0], b[2], b[15], b[16])
+		} else {
+			fmt.Fprintf(w, "v%d, class 0x%02X, algo %d, digest algo %d", b[0], b[1], b[2], b[3])
+		}
+	case 33:
+		if len(b) >= 21 {
+			fmt.Fprintf(w, "issuer fpr v%d %s", b[0], hexString(b[1:]))
+		}
+	case 34:
+		fmt.Fprintf(w, "pref-aead-algos:%s", algoList(b))
+	case 38:
+		w.WriteString("key-block: ")
+		switch {
+		case len(b) > 0 && b[0] != 0:
+			p = "[unknown reserved octet]"
+		case len(b) < 50:
+			p = "[invalid subpacket]"
+		default:
+			p = "[present]"
+		}
+	default:
+		if 100 <= typ && typ <= 110 {
+			p = "experimental / private subpacket"
+		} else {
+			p = "?"
+		}
+	}
+	fmt.Fprintf(w, "%s)\n", p)
+}
+
+//gdumpSymkeyEnc outputs Symmetric-Key Encrypted Session Key Packet (tag 3)
+func gdumpSymkeyEnc(w *bytes.Buffer, f *fields) {
+	v, _ := f.byte("Version")
+	if v != 4 && v != 5 {
+		return
+	}
+	cipher, _ := f.byte("Symmetric Algorithm")
+	aead, _ := f.byte("AEAD Algorithm")
+	s := newS2KSpec(f)
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(w, ":symkey enc packet: version %d, cipher %d, aead %d,s2k %d, hash %d", v, cipher, aead, s.mode, s.hash)
+	l := 0
+	for _, name := range []string{"IV", "Encrypted session key", "Encrypted session key and authentication tag"} {
+		l += len(f.octets(f.find(name)))
+	}
+	if l > 0 {
+		if aead != 0 {
+			fmt.Fprintf(w, ", encrypted seskey %d bytes", l)
+		} else {
+			fmt.Fprintf(w, ", seskey %d bits", (l-1)*8)
+		}
+	}
+	w.WriteString("\n")
+	if s.mode == 1 || s.mode == 3 {
+		fmt.Fprintf(w, "\tsalt %s", hexString(s.salt))
+		if s.mode == 3 {
+			fmt.Fprintf(w, ", count %d (%d)", values.Stretch(s.count).Count(), s.count)
+		}
+		w.WriteString("\n")
+	}
+}
+
+//gdumpOnepass outputs One-Pass Signature Packet (tag 4)
+func gdumpOnepass(w *bytes.Buffer, f *fields) {
+	v, _ := f.byte("Version")
+	sigclass, _ := f.byte("Signiture Type")
+	hash, _ := f.byte("Hash Algorithm")
+	algo, _ := f.byte("Public-key Algorithm")
+	keyid, _ := f.tail("Key ID", 8)
+	last, ok := onepassLast(f)
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, ":onepass_sig packet: keyid %s\n", keyIDString(keyid))
+	fmt.Fprintf(w, "\tversion %d, sigclass 0x%02x, digest %d, pubkey %d, last=%d\n", v, sigclass, hash, algo, last)
+}
+
+//onepassLast returns flag of last one-pass signature in One-Pass Signature Packet (tag 4)
+func onepassLast(f *fields) (byte, bool) {
+	return f.byte("Encrypted session key") //name of flag item in parser
+}
+
+var keyNames = map[int]string{
+	5:  "secret",
+	6:  "public",
+	7:  "secret sub",
+	14: "public sub",
+}
+
+//gdumpKey outputs Public-Key, Public-Subkey, Secret-Key and Secret-Subkey Packet (tag 5, 6, 7 and 14)
+func gdumpKey(w *bytes.Buffer, k *keyPacket, tag int) {
+	if k == nil || k.version < 2 || 5 < k.version {
+		w.WriteString(":key packet: [unknown version]\n")
+		return
+	}
+	expires := uint32(0)
+	if k.version < 4 && k.days > 0 {
+		expires = k.created + uint32(k.days)*86400
+	}
+	fmt.Fprintf(w, ":%s key packet:\n", keyNames[tag])
+	fmt.Fprintf(w, "\tversion %d, algo %d, created %d, expires %d\n", k.version, k.pubAlg, k.created, expires)
+	npkey := numPubKey(k.pubAlg)
+	if npkey == 0 {
+		fmt.Fprintf(w, "\tunknown algorithm %d\n", k.pubAlg)
+	}
+	for i, val := range k.pkeys {
+		if i >= npkey {
+			break
+		}
+		if !val.sized {
+			fmt.Fprintf(w, "\tpkey[%d]: [%d bits]\n", i, val.bits)
+			continue
+		}
+		fmt.Fprintf(w, "\tpkey[%d]: [%d bits]", i, (len(val.data)+1)*8)
+		if i == 0 {
+			fmt.Fprintf(w, " %s (%s)", curveName(val.data), oidString(val.data))
+		}
+		w.WriteString("\n")
+	}
+	if tag == 5 || tag == 7 {
+		if k.secret == nil || !gdumpSecretKey(w, k.secret, k.version, k.pubAlg, npkey) {
+			return
+		}
+	}
+	fmt.Fprintf(w, "\tkeyid: %s\n", keyIDString(k.keyID()))
+}
+
+//gdumpSecretKey outputs secret part of Secret-Key Packet
+func gdumpSecretKey(w *bytes.Buffer, s *secretKey, v, algo, npkey int) bool {
+	protected := s.usage != 0
+	switch s.usage {
+	case 0:
+	case 253, 254, 255:
+		if s.s2k == nil {
+			return false
+		}
+		mode := s.s2k.mode
+		if mode == 101 {
+			if s.s2k.gnu == 0 {
+				fmt.Fprintf(w, "\tunknown S2K %d\n", mode)
+				return true
+			}
+			mode = s.s2k.gnu
+		}
+		check := "simple checksum"
+		switch s.usage {
+		case 254:
+			check = "SHA1 protection"
+		case 253:
+			check = "AEAD protection"
+		}
+		fmt.Fprintf(w, "\t%sS2K, algo: %d, %s, hash: %d", s2kModeName(mode), s.cipher, check, s.s2k.hash)
+		if mode == 1 || mode == 3 {
+			fmt.Fprintf(w, ", salt: %s", hexString(s.s2k.salt))
+		}
+		w.WriteString("\n")
+		if mode == 3 {
+			fmt.Fprintf(w, "\tprotect count: %d (%d)\n", values.Stretch(s.s2k.count).Count(), s.s2k.count)
+		}
+		iv := s.iv
+		switch mode {
+		case 1001:
+			iv = nil
+		case 1002:
+			iv = s.s2k.serial
+			if len(iv) > 16 {
+				iv = iv[:16]
+			}
+		}
+		if mode == 1002 {
+			w.WriteString("\tserial-number: ")
+		} else {
+			w.WriteString("\tprotect IV: ")
+		}
+		for _, c := range iv {
+			fmt.Fprintf(w, " %02x", c)
+		}
+		w.WriteString("\n")
+		if mode == 1001 || mode == 1002 {
+			//GnuPG stubs have no secret key values
+			return true
+		}
+	default:
+		fmt.Fprintf(w, "\tprotect algo: %d  (hash algo: %d)\n", s.usage, 1)
+		w.WriteString("\tprotect IV: ")
+		for _, c := range s.iv {
+			fmt.Fprintf(w, " %02x", c)
+		}
+		w.WriteString("\n")
+	}
+	if protected && v >= 4 {
+		fmt.Fprintf(w, "\tskey[%d]: [v4 protected]\n", npkey)
+		return true
+	}
+	if protected {
+		for i := npkey; i < numSecKey(algo); i++ {
+			fmt.Fprintf(w, "\tskey[%d]: [v3 protected]\n", i)
+		}
+	} else {
+		for i, val := range s.values {
+			fmt.Fprintf(w, "\tskey[%d]: [%d bits]\n", npkey+i, val.bits)
+		}
+	}
+	if len(s.checksum) < 2 {
+		return false
+	}
+	fmt.Fprintf(w, "\tchecksum: %04x\n", binary.BigEndian.Uint16(s.checksum))
+	return true
+}
+
+func s2kModeName(mode int) string {
+	switch mode {
+	case 0:
+		return "simple "
+	case 1:
+		return "salted "
+	case 3:
+		return "iter+salt "
+	case 1001:
+		return "gnu-dummy "
+	case 1002:
+		return "gnu-divert-to-card "
+	case 1003:
+		return "gnu-mode1003 "
+	default:
+		return "unknown "
+	}
+}
+
+//gdumpLiteral outputs Literal Data Packet (tag 11)
+func gdumpLiteral(w *bytes.Buffer, f *fields, partial bool) {
+	mode, _ := f.byte("Literal data format")
+	name, _ := f.sized("File name")
+	created, ok := f.uint32("Creation time")
+	if !ok {
+		return
+	}
+	m := byte('?')
+	if ' ' <= mode && mode < 'z' {
+		m = mode
+	}
+	fmt.Fprintf(w, ":literal data packet:\n\tmode %c (%X), created %d, name=\"%s\",\n", m, mode, created, escapeName(name))
+	if partial {
+		w.WriteString("\traw data: unknown length\n")
+		return
+	}
+	fmt.Fprintf(w, "\traw data: %d bytes\n", len(f.octets(f.find("Literal data"))))
+}
+
+//gdumpTrust outputs Trust Packet (tag 12)
+func gdumpTrust(w *bytes.Buffer, b []byte) {
+	if len(b) == 0 {
+		w.WriteString(":trust packet: empty\n")
+		return
+	}
+	flag, sigcache := b[0], byte(0)
+	if flag == 0 && len(b) == 2 && b[1]&0x80 == 0 {
+		sigcache = b[1]
+	}
+	fmt.Fprintf(w, ":trust packet: flag=%02x sigcache=%02x\n", flag, sigcache)
+}
+
+//attributeName returns description of User Attribute Packet (tag 17)
+func attributeName(item *result.Item) string {
+	subs := subpackets(item, result.KindSubpacketArea)
+	switch len(subs) {
+	case 0:
+		return fmt.Sprintf("[bad attribute packet of size %d]", len(item.Raw))
+	case 1:
+	default:
+		return fmt.Sprintf("[%d attributes of size %d]", len(subs), len(item.Raw))
+	}
+	sub := subs[0]
+	if sub.Code&0x7f != 1 {
+		return fmt.Sprintf("[unknown attribute of size %d]", len(sub.Raw))
+	}
+	if len(sub.Raw) < 4 {
+		return "[invalid image]"
+	}
+	hlen := int(binary.LittleEndian.Uint16(sub.Raw))
+	if sub.Raw[2] != 1 || hlen > len(sub.Raw) {
+		return "[invalid image]"
+	}
+	name := "unknown"
+	if sub.Raw[3] == 1 {
+		name = "jpeg"
+	}
+	return fmt.Sprintf("[%s image of size %d]", name, len(sub.Raw)-hlen)
+}
+
+//keyIDString returns string of key ID
+func keyIDString(keyid []byte) string {
+	if len(keyid) != 8 {
+		return "0000000000000000"
+	}
+	return hexString(keyid)
+}
+
+//timestamp returns date string of UNIX time
+func timestamp(t uint32) string {
+	return time.Unix(int64(t), 0).UTC().Format("2006-01-02")
+}
+
+//timeValue returns string of time period
+func timeValue(t uint32) string {
+	t /= 60
+	minutes := t % 60
+	t /= 60
+	hours := t % 24
+	t /= 24
+	days := t % 365
+	years := t / 365
+	switch {
+	case years > 0:
+		return fmt.Sprintf("%dy%dd%dh%dm", years, days, hours, minutes)
+	case days > 0:
+		return fmt.Sprintf("%dd%dh%dm", days, hours, minutes)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+func not(flag byte) string {
+	if flag == 0 {
+		return "not "
+	}
+	return ""
+}
+
+func algoList(b []byte) string {
+	bldr := &strings.Builder{}
+	for _, c := range b {
+		fmt.Fprintf(bldr, " %d", c)
+	}
+	return bldr.String()
+}
+
+func flagList(b []byte, format string) string {
+	bldr := &strings.Builder{}
+	for _, c := range b {
+		bldr.WriteString(" ")
+		fmt.Fprintf(bldr, format, c)
+	}
+	return bldr.String()
+}
+
+//escapeName returns escaped string of user ID or file name
+func escapeName(b []byte) string {
+	bldr := &strings.Builder{}
+	for _, c := range b {
+		if ' ' <= c && c <= 'z' {
+			bldr.WriteByte(c)
+		} else {
+			fmt.Fprintf(bldr, "\\x%02x", c)
+		}
+	}
+	return bldr.String()
+}
+
+//sanitize returns string with escaping control characters and delimiters
+func sanitize(b []byte, delimiters string) string {
+	bldr := &strings.Builder{}
+	for _, c := range b {
+		if c < 0x20 || c == 0x7f || strings.IndexByte(delimiters, c) >= 0 || c == '\\' {
+			bldr.WriteByte('\\')
+			switch c {
+			case '\n':
+				bldr.WriteByte('n')
+			case '\r':
+				bldr.WriteByte('r')
+			case '\f':
+				bldr.WriteByte('f')
+			case '\v':
+				bldr.WriteByte('v')
+			case '\b':
+				bldr.WriteByte('b')
+			case 0:
+				bldr.WriteByte('0')
+			default:
+				fmt.Fprintf(bldr, "x%02x", c)
+			}
+			continue
+		}
+		bldr.WriteByte(c)
+	}
+	return bldr.String()
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
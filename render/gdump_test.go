@@ -0,0 +1,201 @@
+package render
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func TestGDump(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		content string
+	}{
+		{name: "../testdata/eccsig.asc", armored: true, content: `# off=0 ctb=88 tag=2 hlen=2 plen=94
+:signature packet: algo 19, keyid 31FBFDA95FBBFA18
+	version 4, created 1422067935, md5len 0, sigclass 0x01
+	digest algo 8, begin of digest 36 1f
+	hashed subpkt 2 len 4 (sig created 2015-01-24)
+	subpkt 16 len 8 (issuer key ID 31FBFDA95FBBFA18)
+	data: [256 bits]
+	data: [252 bits]
+`},
+		{name: "../testdata/comp-sig.asc", armored: true, content: `# off=0 ctb=a3 tag=8 hlen=1 plen=0 indeterminate
+:compressed packet: algo=1
+# off=2 ctb=90 tag=4 hlen=2 plen=13
+:onepass_sig packet: keyid B4DA3BAE7E20B81C
+	version 3, sigclass 0x00, digest 8, pubkey 17, last=1
+# off=17 ctb=cb tag=11 hlen=2 plen=19 new-ctb
+:literal data packet:
+	mode b (62), created 1511591396, name="",
+	raw data: 13 bytes
+# off=38 ctb=88 tag=2 hlen=2 plen=117
+:signature packet: algo 17, keyid B4DA3BAE7E20B81C
+	version 4, created 1511591396, md5len 0, sigclass 0x00
+	digest algo 8, begin of digest 73 3c
+	hashed subpkt 33 len 21 (issuer fpr v4 1B5202DB4A3EC776F1E0AD18B4DA3BAE7E20B81C)
+	hashed subpkt 2 len 4 (sig created 2017-11-25)
+	subpkt 16 len 8 (issuer key ID B4DA3BAE7E20B81C)
+	data: [256 bits]
+	data: [255 bits]
+`},
+		{name: "../testdata/from-pgpdump/enc1", armored: false, content: `# off=0 ctb=a8 tag=10 hlen=2 plen=3
+:marker packet: PGP
+# off=5 ctb=c3 tag=3 hlen=2 plen=4 new-ctb
+:symkey enc packet: version 4, cipher 3, aead 0,s2k 0, hash 1
+# off=11 ctb=c9 tag=9 hlen=2 plen=56 new-ctb
+:encrypted data packet:
+	length: 56
+`},
+		{name: "../testdata/decrypt/alice-card.asc", armored: true, content: `# off=0 ctb=95 tag=5 hlen=3 plen=920
+:secret key packet:
+	version 4, algo 1, created 1792421529, expires 0
+	pkey[0]: [2048 bits]
+	pkey[1]: [17 bits]
+	skey[2]: [2046 bits]
+	skey[3]: [1024 bits]
+	skey[4]: [1024 bits]
+	skey[5]: [1022 bits]
+	checksum: 427c
+	keyid: BE831B3CC1AAEE9B
+# off=923 ctb=b4 tag=13 hlen=2 plen=25
+:user ID packet: "Alice <alice@example.com>"
+# off=950 ctb=89 tag=2 hlen=3 plen=334
+:signature packet: algo 1, keyid BE831B3CC1AAEE9B
+	version 4, created 1792421529, md5len 0, sigclass 0x13
+	digest algo 10, begin of digest 06 98
+	hashed subpkt 33 len 21 (issuer fpr v4 39AC4955B238AAB10223932CBE831B3CC1AAEE9B)
+	hashed subpkt 2 len 4 (sig created 2026-10-19)
+	hashed subpkt 27 len 1 (key flags: 03)
+	hashed subpkt 11 len 4 (pref-sym-algos: 9 8 7 2)
+	hashed subpkt 21 len 5 (pref-hash-algos: 10 9 8 11 2)
+	hashed subpkt 22 len 3 (pref-zip-algos: 2 3 1)
+	hashed subpkt 30 len 1 (features: 01)
+	hashed subpkt 23 len 1 (keyserver preferences: 80)
+	subpkt 16 len 8 (issuer key ID BE831B3CC1AAEE9B)
+	data: [2048 bits]
+# off=1287 ctb=c7 tag=7 hlen=3 plen=294 new-ctb
+:secret sub key packet:
+	version 4, algo 1, created 1792421530, expires 0
+	pkey[0]: [2048 bits]
+	pkey[1]: [17 bits]
+	gnu-divert-to-card S2K, algo: 0, simple checksum, hash: 0
+	serial-number:  d2 d3 d4 d5 d6 d7 d8 d9 da db dc dd de df e0 e1
+	keyid: 567F9CCC90616400
+# off=1584 ctb=89 tag=2 hlen=3 plen=310
+:signature packet: algo 1, keyid BE831B3CC1AAEE9B
+	version 4, created 1792421530, md5len 0, sigclass 0x18
+	digest algo 10, begin of digest 88 45
+	hashed subpkt 33 len 21 (issuer fpr v4 39AC4955B238AAB10223932CBE831B3CC1AAEE9B)
+	hashed subpkt 2 len 4 (sig created 2026-10-19)
+	hashed subpkt 27 len 1 (key flags: 0C)
+	subpkt 16 len 8 (issuer key ID BE831B3CC1AAEE9B)
+	data: [2048 bits]
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := GDump(parseFile(t, tc.name, tc.armored))
+		if err != nil {
+			t.Fatalf("GDump() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("GDump(%v) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestGDumpSecretKey(t *testing.T) {
+	r, err := GDump(parseFile(t, "../testdata/cv25519/cv25519-seckey.asc", true))
+	if err != nil {
+		t.Fatalf("GDump() = \"%+v\", want nil error.", err)
+	}
+	want := `# off=0 ctb=94 tag=5 hlen=2 plen=134
+:secret key packet:
+	version 4, algo 22, created 1512126368, expires 0
+	pkey[0]: [80 bits] ed25519 (1.3.6.1.4.1.11591.15.1)
+	pkey[1]: [263 bits]
+	iter+salt S2K, algo: 7, SHA1 protection, hash: 2, salt: 18A86C787F74FC94
+	protect count: 4980736 (195)
+	protect IV:  bd 05 7a 71 60 bf c2 50 ad 26 4b 08 33 20 21 f2
+	skey[2]: [v4 protected]
+	keyid: B791C1AE32D3F3FC
+# off=136 ctb=b4 tag=13 hlen=2 plen=25
+:user ID packet: "Alice <alice@example.com>"
+`
+	if str := readAll(t, r); !strings.HasPrefix(str, want) {
+		t.Errorf("GDump() = \"%v\", want prefix \"%v\".", str, want)
+	}
+}
+
+func TestGDumpUnprotectedSecretKey(t *testing.T) {
+	r, err := GDump(parseFile(t, "../testdata/decrypt/alice-sec.asc", true))
+	if err != nil {
+		t.Fatalf("GDump() = \"%+v\", want nil error.", err)
+	}
+	want := `# off=0 ctb=95 tag=5 hlen=3 plen=920
+:secret key packet:
+	version 4, algo 1, created 1792421529, expires 0
+	pkey[0]: [2048 bits]
+	pkey[1]: [17 bits]
+	skey[2]: [2046 bits]
+	skey[3]: [1024 bits]
+	skey[4]: [1024 bits]
+	skey[5]: [1022 bits]
+	checksum: 427c
+	keyid: BE831B3CC1AAEE9B
+`
+	if str := readAll(t, r); !strings.HasPrefix(str, want) {
+		t.Errorf("GDump() = \"%v\", want prefix \"%v\".", str, want)
+	}
+}
+
+//parseFile returns parsing result of OpenPGP file
+func parseFile(t *testing.T, name string, armored bool) *result.Info {
+	t.Helper()
+	file, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("os.Open(%v) = \"%+v\", want nil error.", name, err)
+	}
+	defer file.Close()
+	p, err := parse.New(context.New(context.Set(context.ARMOR, armored)), file)
+	if err != nil {
+		t.Fatalf("parse.New() = \"%+v\", want nil error.", err)
+	}
+	info, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() = \"%+v\", want nil error.", err)
+	}
+	return info
+}
+
+//readAll returns all text from io.Reader
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("io.ReadAll() = \"%+v\", want nil error.", err)
+	}
+	return string(b)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
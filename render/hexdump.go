@@ -0,0 +1,251 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//hexdumpWidth is number of octets per line in annotated hexdump
+const hexdumpWidth = 16
+
+//hexdumper class is renderer for annotated hexdump
+type hexdumper struct {
+	w     *bytes.Buffer
+	width int //width of offset column
+}
+
+//HexDump returns annotated hexdump of packets (each run of octets is labelled by item parsed from it)
+func HexDump(info *result.Info) (io.Reader, error) {
+	d := &hexdumper{w: &bytes.Buffer{}, width: 4}
+	if info == nil {
+		return d.w, nil
+	}
+	size := int64(0)
+	for _, item := range info.Packets {
+		if item != nil && item.Header != nil {
+			if end := item.Header.Offset + int64(item.Header.HeaderLen) + int64(len(item.Raw)); end > size {
+				size = end
+			}
+		}
+	}
+	if w := len(fmt.Sprintf("%x", size)); w > d.width {
+		d.width = w
+	}
+	for _, item := range info.Packets {
+		d.packet(item, 0)
+	}
+	return d.w, nil
+}
+
+//packet outputs packet header and body
+func (d *hexdumper) packet(item *result.Item, depth int) {
+	if item == nil {
+		return
+	}
+	base := int64(0)
+	if h := item.Header; h != nil {
+		base = h.Offset + int64(h.HeaderLen)
+		label := hexdumpLabel(item)
+		if h.Partial {
+			label += " [partial body length: offsets are in concatenated body]"
+		}
+		d.run(h.Offset, h.Octets, depth, label, false)
+	} else {
+		d.annotation(depth, hexdumpLabel(item))
+	}
+	nested := d.body(item, base, depth+1)
+	if len(nested) > 0 {
+		d.annotation(depth+1, fmt.Sprintf("[packets in %s: offsets are in inner data]", item.Name))
+		for _, itm := range nested {
+			d.packet(itm, depth+1)
+		}
+	}
+}
+
+//body outputs items in data of packet or sub-packet (base is offset of data), and returns nested packets
+func (d *hexdumper) body(item *result.Item, base int64, depth int) []*result.Item {
+	nested := []*result.Item{}
+	raw := item.Raw
+	cursor := 0
+	for _, itm := range item.Items {
+		if itm == nil {
+			continue
+		}
+		if itm.Kind == result.KindPacket && itm.Header != nil {
+			nested = append(nested, itm)
+			continue
+		}
+		cursor = d.field(itm, raw, base, cursor, depth)
+	}
+	d.skipped(raw, base, cursor, len(raw), depth)
+	return nested
+}
+
+//field outputs item in data (cursor is current position in raw data), and returns next position
+func (d *hexdumper) field(item *result.Item, raw []byte, base int64, cursor, depth int) int {
+	if item.Span == nil {
+		d.annotation(depth, hexdumpLabel(item))
+		for _, itm := range item.Items {
+			if itm != nil {
+				cursor = d.field(itm, raw, base, cursor, depth+1)
+			}
+		}
+		return cursor
+	}
+	s, e := clamp(item.Span.Start, len(raw)), clamp(item.Span.End, len(raw))
+	if s > cursor {
+		d.skipped(raw, base, cursor, s, depth)
+	}
+	if s < cursor { //overlapped
+		s = cursor
+		if e < s {
+			e = s
+		}
+	}
+	switch item.Kind {
+	case result.KindPacket: //embedded packet (data of sub-packet)
+		d.annotation(depth, hexdumpLabel(item))
+		nested := d.body(item, base+int64(s), depth+1)
+		for _, itm := range nested {
+			d.packet(itm, depth+1)
+		}
+		if end := s + len(item.Raw); end > e {
+			e = clamp(int64(end), len(raw))
+		}
+		return e
+	case result.KindHashedArea, result.KindUnhashedArea, result.KindSubpacketArea:
+		return d.area(item, raw, base, s, e, depth)
+	}
+	if e > s || len(item.Items) == 0 {
+		d.run(base+int64(s), raw[s:e], depth, hexdumpLabel(item), false)
+	} else {
+		d.annotation(depth, hexdumpLabel(item))
+	}
+	cursor = e
+	for _, itm := range item.Items {
+		if itm != nil {
+			cursor = d.field(itm, raw, base, cursor, depth+1)
+		}
+	}
+	return cursor
+}
+
+//area outputs sub-packet area (s and e are range of area including length octets)
+func (d *hexdumper) area(item *result.Item, raw []byte, base int64, s, e, depth int) int {
+	bs := e - len(item.Raw)
+	if bs < s {
+		bs = s
+	}
+	if bs > s {
+		d.run(base+int64(s), raw[s:bs], depth, hexdumpLabel(item), false)
+	} else {
+		d.annotation(depth, hexdumpLabel(item))
+	}
+	subs := []*result.Item{}
+	for _, itm := range item.Items {
+		if itm != nil {
+			subs = append(subs, itm)
+		}
+	}
+	cursor := bs
+	for n, r := range subpacketRanges(item.Raw) {
+		if n >= len(subs) {
+			break
+		}
+		sub := subs[n]
+		start, body, end := bs+r.start, bs+r.body+1, bs+r.end
+		if end > len(raw) {
+			break
+		}
+		if len(sub.Items) == 0 {
+			d.run(base+int64(start), raw[start:end], depth+1, hexdumpLabel(sub), false)
+		} else {
+			d.run(base+int64(start), raw[start:body], depth+1, hexdumpLabel(sub), false)
+			for _, itm := range d.body(sub, base+int64(body), depth+2) {
+				d.packet(itm, depth+2)
+			}
+		}
+		cursor = end
+	}
+	d.skipped(raw, base, cursor, e, depth+1)
+	return e
+}
+
+//skipped outputs octets skipped by parser
+func (d *hexdumper) skipped(raw []byte, base int64, s, e, depth int) {
+	if s >= e || e > len(raw) {
+		return
+	}
+	d.run(base+int64(s), raw[s:e], depth, fmt.Sprintf("<skipped %d bytes>", e-s), true)
+}
+
+//run outputs run of octets with label
+func (d *hexdumper) run(offset int64, b []byte, depth int, label string, skipped bool) {
+	mark := " "
+	if skipped {
+		mark = "!"
+	}
+	if len(b) == 0 {
+		fmt.Fprintf(d.w, "%0*x %s %-*s  %s%s\n", d.width, offset, mark, hexdumpWidth*3-1, "", strings.Repeat("  ", depth), label)
+		return
+	}
+	for i := 0; i < len(b); i += hexdumpWidth {
+		end := i + hexdumpWidth
+		if end > len(b) {
+			end = len(b)
+		}
+		hex := strings.TrimSpace(dumpString(b[i:end]))
+		if i == 0 {
+			fmt.Fprintf(d.w, "%0*x %s %-*s  %s%s\n", d.width, offset+int64(i), mark, hexdumpWidth*3-1, hex, strings.Repeat("  ", depth), label)
+			continue
+		}
+		fmt.Fprintf(d.w, "%0*x %s %s\n", d.width, offset+int64(i), mark, hex)
+	}
+}
+
+//annotation outputs label without octets
+func (d *hexdumper) annotation(depth int, label string) {
+	fmt.Fprintf(d.w, "%s   %-*s  %s%s\n", strings.Repeat(" ", d.width), hexdumpWidth*3-1, "", strings.Repeat("  ", depth), label)
+}
+
+//hexdumpLabel returns label of item
+func hexdumpLabel(item *result.Item) string {
+	label := item.Name
+	if len(item.Value) > 0 {
+		label += ": " + item.Value
+	}
+	if len(item.Note) > 0 {
+		label += " (" + item.Note + ")"
+	}
+	return label
+}
+
+//clamp returns offset in range of [0, size]
+func clamp(off int64, size int) int {
+	switch {
+	case off < 0:
+		return 0
+	case off > int64(size):
+		return size
+	}
+	return int(off)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
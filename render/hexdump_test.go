@@ -0,0 +1,94 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func TestHexDump(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		content string
+	}{
+		{name: "../testdata/eccsig.asc", armored: true, content: `0000   88 5e                                            Signature Packet (tag 2) (94 bytes)
+0002   04                                                 Version: 4 (current)
+0003   01                                                 Signiture Type: Signature of a canonical text document (0x01)
+0004   13                                                 Public-key Algorithm: ECDSA public key algorithm (pub 19)
+0005   08                                                 Hash Algorithm: SHA2-256 (hash 8)
+0006   00 06                                              Hashed Subpacket (6 bytes)
+0008   05 02 54 c3 08 df                                    Signature Creation Time (sub 2): 2015-01-24T02:52:15Z
+000e   00 0a                                              Unhashed Subpacket (10 bytes)
+0010   09 10 31 fb fd a9 5f bb fa 18                        Issuer (sub 16): 0x31fbfda95fbbfa18
+001a   36 1f                                              Hash left 2 bytes
+001c   01 00 ea 1d a2 14 5b 82 06 fd d5 ae c4 9f d8 14    ECDSA value r (256 bits)
+002c   44 41 a4 f5 4f 56 69 ad 9a b0 44 f3 a3 88 b2 60
+003c   f4 0c
+003e   00 fc 0a d3 c0 23 f3 ed cd af 9b 19 6f ee c4 65    ECDSA value s (252 bits)
+004e   44 b5 08 e8 27 6c 3a a8 6e 3b 52 9f 61 7a ea ee
+005e   27 48
+`},
+		{name: "../testdata/from-pgpdump/enc1", armored: false, content: `0000   a8 03                                            Marker Packet (Obsolete Literal Packet) (tag 10) (3 bytes)
+0002   50 47 50                                           Literal data (3 bytes)
+0005   c3 04                                            Symmetric-Key Encrypted Session Key Packet (tag 3) (4 bytes)
+0007   04                                                 Version: 4 (current)
+0008   03                                                 Symmetric Algorithm: CAST5 (128 bit key, as per) (sym 3)
+0009   00                                                 String-to-Key (S2K) Algorithm: Simple S2K (s2k 0)
+000a   01                                                   Hash Algorithm: MD5 (hash 1)
+000b   c9 38                                            Symmetrically Encrypted Data Packet (tag 9) (56 bytes)
+000d   e7 2d 2f b1 f1 0f c3 ce 55 5d b2 8a 4b e8 4f 43    Encrypted data: sym alg is specified in sym-key encrypted session key (56 bytes)
+001d   15 6e 7d 90 90 53 6a 9a e3 aa 1c 68 d6 d3 fc 6a
+002d   4e 79 a8 e7 b1 a5 87 ea cc cc 99 66 31 ad ff e1
+003d   a3 03 b6 47 85 76 bd 0b
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := HexDump(parseFile(t, tc.name, tc.armored))
+		if err != nil {
+			t.Fatalf("HexDump() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("HexDump(%s) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestHexDumpSkipped(t *testing.T) {
+	item := result.NewItem(
+		result.Name("Unknown Packet (tag 99)"),
+		result.Meta(result.KindPacket, 99),
+		result.Raw([]byte{0x04, 0x01, 0x02, 0x03, 0x05}),
+		result.PacketHeader(&result.Header{Offset: 0, CTB: 0xc4, HeaderLen: 2, BodyLen: 5, Octets: []byte{0xc4, 0x05}}),
+	)
+	item.Add(result.NewItem(result.Name("Version"), result.Value("4"), result.Range(0, 1)))
+	item.Add(result.NewItem(result.Name("Flag"), result.Value("5"), result.Range(4, 5)))
+	r, err := HexDump(&result.Info{Packets: []*result.Item{item}})
+	if err != nil {
+		t.Fatalf("HexDump() = \"%+v\", want nil error.", err)
+	}
+	res := `0000   c4 05                                            Unknown Packet (tag 99)
+0002   04                                                 Version: 4
+0003 ! 01 02 03                                           <skipped 3 bytes>
+0006   05                                                 Flag: 5
+`
+	if str := readAll(t, r); str != res {
+		t.Errorf("HexDump() = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
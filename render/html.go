@@ -0,0 +1,367 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//maxHexBytes is maximum size of hex view per packet
+const maxHexBytes = 64 * 1024
+
+//htmlNode class is node of item tree in HTML report
+type htmlNode struct {
+	Label    string
+	Note     string
+	Dump     string
+	Title    string
+	Packet   int
+	Start    int
+	End      int
+	Children []*htmlNode
+
+	Description string //by explain mode
+	Reference   string //by explain mode
+}
+
+//htmlByte class is one octet in hex view
+type htmlByte struct {
+	ID  string
+	Hex string
+}
+
+//htmlRow class is row (16 octets) in hex view
+type htmlRow struct {
+	Offset string
+	Bytes  []htmlByte
+	Pad    string
+	ASCII  string
+}
+
+//htmlPacket class is hex view of packet
+type htmlPacket struct {
+	Index   int
+	Title   string
+	Rows    []htmlRow
+	Omitted int
+}
+
+//htmlReport class is data for HTML template
+type htmlReport struct {
+	Lang    string
+	Nodes   []*htmlNode
+	Packets []*htmlPacket
+	HexView bool
+}
+
+//htmlBuilder class is builder of htmlReport
+type htmlBuilder struct {
+	report    *htmlReport
+	localizer Localizer
+}
+
+//Localizer is interface of translator for labels in HTML report (e.g. *locale.Catalog)
+type Localizer interface {
+	Lang() string
+	LocalizeItem(*result.Item) *result.Item
+}
+
+//HTMLOpt is self-referential function for functional options pattern
+type HTMLOpt func(*htmlBuilder)
+
+//WithLocalizer returns function for setting translator of labels in HTML report (explanations are looked up by original names)
+func WithLocalizer(l Localizer) HTMLOpt {
+	return func(b *htmlBuilder) {
+		b.localizer = l
+	}
+}
+
+//WithHexView returns function for setting output of hex view pane (e.g. false for redacted items without raw octets)
+func WithHexView(flag bool) HTMLOpt {
+	return func(b *htmlBuilder) {
+		b.report.HexView = flag
+	}
+}
+
+//HTML returns self-contained HTML report
+func HTML(info *result.Info, opts ...HTMLOpt) (io.Reader, error) {
+	b := &htmlBuilder{report: &htmlReport{Lang: "en", HexView: true}}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.localizer != nil {
+		b.report.Lang = b.localizer.Lang()
+	}
+	if info != nil {
+		for _, item := range info.Packets {
+			if node := b.packet(item, nil); node != nil {
+				b.report.Nodes = append(b.report.Nodes, node)
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := htmlTemplate.Execute(buf, b.report); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return buf, nil
+}
+
+//packet returns node of packet and registers its hex view
+func (b *htmlBuilder) packet(item, parent *result.Item) *htmlNode {
+	if item == nil {
+		return nil
+	}
+	p := len(b.report.Packets)
+	b.report.Packets = append(b.report.Packets, newHTMLPacket(p, b.localized(item)))
+	node := b.node(item, parent, p, 0, len(item.Raw))
+	node.Children = b.body(item, p, 0, len(item.Raw))
+	return node
+}
+
+//body returns nodes of items in data of packet or sub-packet (base is offset of data in raw data of packet, and size is length of raw data of packet)
+func (b *htmlBuilder) body(item *result.Item, p, base, size int) []*htmlNode {
+	nodes := []*htmlNode{}
+	for _, itm := range item.Items {
+		if itm == nil {
+			continue
+		}
+		if itm.Kind == result.KindPacket && itm.Header != nil {
+			nodes = append(nodes, b.packet(itm, item))
+			continue
+		}
+		nodes = append(nodes, b.field(itm, item, p, base, size))
+	}
+	return nodes
+}
+
+//field returns node of field in packet (range of octets is given by span of item)
+func (b *htmlBuilder) field(item, parent *result.Item, p, base, size int) *htmlNode {
+	if item.Span == nil {
+		//range of container is union of children
+		node := b.node(item, parent, p, 0, 0)
+		for _, itm := range item.Items {
+			if itm == nil {
+				continue
+			}
+			child := b.field(itm, item, p, base, size)
+			node.Children = append(node.Children, child)
+			if child.Start >= child.End {
+				continue
+			}
+			if node.Start >= node.End {
+				node.Start, node.End = child.Start, child.End
+				continue
+			}
+			if child.Start < node.Start {
+				node.Start = child.Start
+			}
+			if child.End > node.End {
+				node.End = child.End
+			}
+		}
+		return node
+	}
+	s, e := clamp(int64(base)+item.Span.Start, size), clamp(int64(base)+item.Span.End, size)
+	node := b.node(item, parent, p, s, e)
+	switch item.Kind {
+	case result.KindPacket: //embedded packet (data of sub-packet)
+		if end := clamp(int64(s+len(item.Raw)), size); end > e {
+			node.End = end
+		}
+		node.Children = b.body(item, p, s, size)
+		return node
+	case result.KindHashedArea, result.KindUnhashedArea, result.KindSubpacketArea:
+		node.Children = b.area(item, p, s, e, size)
+		return node
+	}
+	for _, itm := range item.Items {
+		if itm != nil {
+			node.Children = append(node.Children, b.field(itm, item, p, base, size))
+		}
+	}
+	return node
+}
+
+//area returns nodes of sub-packets in sub-packet area (s and e are range of area including length octets)
+func (b *htmlBuilder) area(item *result.Item, p, s, e, size int) []*htmlNode {
+	bs := e - len(item.Raw)
+	if bs < s {
+		bs = s
+	}
+	nodes := []*htmlNode{}
+	rngs := subpacketRanges(item.Raw)
+	for _, sub := range item.Items {
+		if sub == nil {
+			continue
+		}
+		n := len(nodes)
+		if n >= len(rngs) || bs+rngs[n].end > size {
+			nodes = append(nodes, b.node(sub, item, p, 0, 0))
+			continue
+		}
+		r := rngs[n]
+		node := b.node(sub, item, p, bs+r.start, bs+r.end)
+		node.Children = b.body(sub, p, bs+r.body+1, size)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+//localized returns copy of item translated for labels
+func (b *htmlBuilder) localized(item *result.Item) *result.Item {
+	if b.localizer == nil {
+		return item
+	}
+	return b.localizer.LocalizeItem(item)
+}
+
+//node returns node of item (parent is item including it)
+func (b *htmlBuilder) node(item, parent *result.Item, p, start, end int) *htmlNode {
+	title := explanationTitle(item, parent)
+	item = b.localized(item)
+	label := item.Name
+	if item.Value != "" {
+		label += ": " + item.Value
+	}
+	return &htmlNode{
+		Label:  label,
+		Note:   item.Note,
+		Dump:   item.Dump,
+		Title:  title,
+		Packet: p,
+		Start:  start,
+		End:    end,
+
+		Description: item.Description,
+		Reference:   item.Reference,
+	}
+}
+
+func newHTMLPacket(p int, item *result.Item) *htmlPacket {
+	pckt := &htmlPacket{Index: p, Title: item.Name}
+	if h := item.Header; h != nil {
+		pckt.Title = fmt.Sprintf("%s: off=%d ctb=%02x hlen=%d plen=%d", item.Name, h.Offset, h.CTB, h.HeaderLen, len(item.Raw))
+	}
+	raw := item.Raw
+	if len(raw) > maxHexBytes {
+		pckt.Omitted = len(raw) - maxHexBytes
+		raw = raw[:maxHexBytes]
+	}
+	for off := 0; off < len(raw); off += 16 {
+		row := htmlRow{Offset: fmt.Sprintf("%08x", off)}
+		ascii := &strings.Builder{}
+		for i := off; i < off+16 && i < len(raw); i++ {
+			row.Bytes = append(row.Bytes, htmlByte{ID: fmt.Sprintf("p%db%d", p, i), Hex: fmt.Sprintf("%02x", raw[i])})
+			if 0x20 <= raw[i] && raw[i] < 0x7f {
+				ascii.WriteByte(raw[i])
+			} else {
+				ascii.WriteByte('.')
+			}
+		}
+		row.Pad = strings.Repeat("   ", 16-len(row.Bytes))
+		row.ASCII = ascii.String()
+		pckt.Rows = append(pckt.Rows, row)
+	}
+	return pckt
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>gpgpdump report</title>
+<style>
+body { font-family: sans-serif; margin: 0; }
+header { padding: 0.5em 1em; background: #333; color: #fff; }
+main { display: flex; height: calc(100vh - 3em); }
+#tree, #hex { overflow: auto; padding: 0.5em 1em; }
+#tree { flex: 1; border-right: 1px solid #ccc; }
+#hex { flex: 1; font-family: monospace; }
+ul { list-style: none; margin: 0; padding-left: 1.2em; }
+summary, .leaf { cursor: pointer; padding: 1px 2px; }
+.leaf { padding-left: 1.1em; }
+.note { color: #666; }
+.dump { color: #036; font-family: monospace; }
+.explain { display: block; color: #360; font-size: 0.9em; padding-left: 1em; }
+.sel { background: #ffe08a; }
+.hl { background: #ffb347; }
+h2 { font-size: 1em; margin: 1em 0 0.2em; }
+pre { margin: 0; }
+</style>
+</head>
+<body>
+<header>OpenPGP packets (gpgpdump)</header>
+<main>
+<div id="tree">
+{{- range .Nodes}}{{template "node" .}}{{end}}
+</div>
+{{- if .HexView}}
+<div id="hex">
+{{- range .Packets}}
+<section id="hex{{.Index}}">
+<h2>{{.Title}}</h2>
+<pre>
+{{- range .Rows}}
+{{.Offset}}  {{range .Bytes}}<span id="{{.ID}}">{{.Hex}}</span> {{end}}{{.Pad}} {{.ASCII}}
+{{- end}}
+{{- if .Omitted}}
+... ({{.Omitted}} bytes omitted)
+{{- end}}
+</pre>
+</section>
+{{- end}}
+</div>
+{{- end}}
+</main>
+<script>
+(function () {
+	function select(el) {
+		document.querySelectorAll(".hl, .sel").forEach(function (e) { e.classList.remove("hl", "sel"); });
+		el.classList.add("sel");
+		var p = el.dataset.p, s = Number(el.dataset.s), e = Number(el.dataset.e), first = null;
+		for (var i = s; i < e; i++) {
+			var b = document.getElementById("p" + p + "b" + i);
+			if (b) {
+				b.classList.add("hl");
+				if (!first) { first = b; }
+			}
+		}
+		var view = first || document.getElementById("hex" + p);
+		if (view) { view.scrollIntoView({block: "nearest"}); }
+	}
+	document.querySelectorAll("[data-p]").forEach(function (el) {
+		el.addEventListener("click", function (ev) { ev.stopPropagation(); select(el); });
+	});
+})();
+</script>
+</body>
+</html>
+{{define "node"}}
+{{- if .Children}}<details open><summary data-p="{{.Packet}}" data-s="{{.Start}}" data-e="{{.End}}"{{if .Title}} title="{{.Title}}"{{end}}>{{template "label" .}}</summary>
+<ul>{{range .Children}}<li>{{template "node" .}}</li>{{end}}</ul>
+</details>
+{{- else}}<div class="leaf" data-p="{{.Packet}}" data-s="{{.Start}}" data-e="{{.End}}"{{if .Title}} title="{{.Title}}"{{end}}>{{template "label" .}}</div>
+{{- end}}
+{{- end}}
+{{define "label"}}{{.Label}}{{if .Note}} <span class="note">({{.Note}})</span>{{end}}{{if .Dump}} <span class="dump">{{.Dump}}</span>{{end}}{{if .Description}} <span class="explain">{{.Description}}{{if .Reference}} ({{.Reference}}){{end}}</span>{{end}}{{end}}
+`))
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
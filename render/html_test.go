@@ -0,0 +1,123 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/locale"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+func TestHTML(t *testing.T) {
+	r, err := HTML(parseFile(t, "../testdata/eccsig.asc", true))
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	testCases := []string{
+		"<!DOCTYPE html>\n",
+		`<summary data-p="0" data-s="0" data-e="94" title="RFC 9580 Section 5.2: Signature over data or a key component, made with the issuer&#39;s key.">Signature Packet (tag 2) <span class="note">(94 bytes)</span></summary>`,
+		`<div class="leaf" data-p="0" data-s="14" data-e="24" title="RFC 9580 Section 5.2.3.12: Key ID of the key that made the signature.">Issuer (sub 16): 0x31fbfda95fbbfa18</div>`,
+		`<div class="leaf" data-p="0" data-s="24" data-e="26" title="RFC 9580 Section 5.2.4: Left 16 bits of the signed hash, for quick rejection of wrong signatures.">Hash left 2 bytes <span class="dump">36 1f</span></div>`,
+		`<span id="p0b0">04</span>`,
+		`<span id="p0b93">48</span>`,
+	}
+	for _, tc := range testCases {
+		if !strings.Contains(str, tc) {
+			t.Errorf("HTML() does not contain \"%v\".", tc)
+		}
+	}
+	for _, tc := range []string{"src=", "href=", "http://", "https://"} {
+		if strings.Contains(str, tc) {
+			t.Errorf("HTML() contains \"%v\", want no external assets.", tc)
+		}
+	}
+}
+
+func TestHTMLLocalized(t *testing.T) {
+	catalog, _ := locale.Get("ja")
+	r, err := HTML(parseFile(t, "../testdata/eccsig.asc", true), WithLocalizer(catalog))
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	testCases := []string{
+		`<html lang="ja">`,
+		`<div class="leaf" data-p="0" data-s="24" data-e="26" title="RFC 9580 Section 5.2.4: Left 16 bits of the signed hash, for quick rejection of wrong signatures.">ハッシュ値の左 2 バイト <span class="dump">36 1f</span></div>`,
+	}
+	for _, tc := range testCases {
+		if !strings.Contains(str, tc) {
+			t.Errorf("HTML() does not contain \"%v\".", tc)
+		}
+	}
+}
+
+func TestHTMLRedacted(t *testing.T) {
+	info, err := Redact(parseFile(t, "../testdata/eccsig.asc", true), RedactAll)
+	if err != nil {
+		t.Fatalf("Redact() = \"%+v\", want nil error.", err)
+	}
+	r, err := HTML(info, WithHexView(false))
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	if !strings.Contains(str, "Issuer (sub 16): 0x0101010101010101") {
+		t.Errorf("HTML() = \"%v\", want redacted key ID.", str)
+	}
+	for _, tc := range []string{`<div id="hex">`, `<span id="p0b`, "31fbfda95fbbfa18", "31 fb fd a9"} {
+		if strings.Contains(str, tc) {
+			t.Errorf("HTML() contains \"%v\", want no raw octets.", tc)
+		}
+	}
+}
+
+func TestHTMLSpan(t *testing.T) {
+	info := result.New()
+	pckt := result.NewItem(
+		result.Name("Test Packet"),
+		result.Meta(result.KindPacket, 60),
+		result.Raw([]byte{0x01, 0x02, 0x01, 0x02}),
+	)
+	pckt.Add(result.NewItem(result.Name("First"), result.Value("1, 2"), result.Range(0, 2)))
+	pckt.Add(result.NewItem(result.Name("Second"), result.DumpStr("01 02"), result.Range(2, 4)))
+	info.Add(pckt)
+	r, err := HTML(info)
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	for _, tc := range []string{
+		`<div class="leaf" data-p="0" data-s="0" data-e="2">First: 1, 2</div>`,
+		`<div class="leaf" data-p="0" data-s="2" data-e="4">Second <span class="dump">01 02</span></div>`,
+	} {
+		if !strings.Contains(str, tc) {
+			t.Errorf("HTML() does not contain \"%v\".", tc)
+		}
+	}
+}
+
+func TestHTMLEmpty(t *testing.T) {
+	r, err := HTML(nil)
+	if err != nil {
+		t.Fatalf("HTML() = \"%+v\", want nil error.", err)
+	}
+	if str := readAll(t, r); !strings.HasPrefix(str, "<!DOCTYPE html>\n") {
+		t.Errorf("HTML() = \"%v\", want HTML document.", str)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
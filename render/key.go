@@ -0,0 +1,188 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//keyPacket class is Public-Key, Public-Subkey, Secret-Key or Secret-Subkey Packet (tag 5, 6, 7 and 14) taken from parsed items
+type keyPacket struct {
+	version int
+	created uint32
+	days    int
+	pubAlg  int
+	pkeys   []keyValue //public key values
+	public  []byte     //public key part of packet
+	secret  *secretKey //nil if public key packet
+}
+
+//keyValue class is public key value
+type keyValue struct {
+	mpiValue
+	sized bool //one-octet length-prefixed data (ECC curve OID and KDF parameters)
+}
+
+//secretKey class is secret part of Secret-Key and Secret-Subkey Packet
+type secretKey struct {
+	usage    int
+	cipher   int
+	aead     int
+	s2k      *s2kSpec
+	iv       []byte
+	values   []mpiValue //plain or encrypted secret key values
+	checksum []byte
+}
+
+//s2kSpec class is String-to-Key specifier
+type s2kSpec struct {
+	mode   int
+	hash   int
+	salt   []byte
+	count  byte
+	gnu    int //GnuPG extension number (1001: gnu-dummy, 1002: gnu-divert-to-card)
+	serial []byte
+}
+
+//newKeyPacket returns keyPacket instance of parsed key packet (nil if not key packet)
+func newKeyPacket(item *result.Item) *keyPacket {
+	if item == nil || item.Kind != result.KindPacket {
+		return nil
+	}
+	switch item.Code {
+	case 5, 6, 7, 14:
+	default:
+		return nil
+	}
+	f := newFields(item)
+	v, _ := f.byte("Version")
+	k := &keyPacket{version: int(v)}
+	pub, ok := f.in("Public-Key")
+	if !ok {
+		pub = f
+	}
+	k.created, _ = pub.uint32("Public key creation time")
+	if itm := pub.find("Valid days"); itm != nil {
+		k.days, _ = strconv.Atoi(itm.Value)
+	}
+	algo, _ := pub.byte("Public-key Algorithm")
+	k.pubAlg = int(algo)
+	for _, itm := range pub.after("Public-key Algorithm") {
+		if itm == nil || itm.Kind != result.KindNone {
+			continue
+		}
+		switch itm.Name {
+		case "ECC Curve OID", "KDF parameters":
+			b, _ := sizedData(pub.octets(itm))
+			k.pkeys = append(k.pkeys, keyValue{mpiValue: mpiValue{data: b, bits: 8 * len(b)}, sized: true})
+		default:
+			k.pkeys = append(k.pkeys, keyValue{mpiValue: pub.value(itm)})
+		}
+	}
+	k.public = f.raw[:pub.end()]
+	if item.Code == 5 || item.Code == 7 {
+		k.secret = newSecretKey(f)
+	}
+	return k
+}
+
+//keyID returns key ID of key packet
+func (k *keyPacket) keyID() []byte {
+	switch k.version {
+	case 2, 3:
+		if k.pubAlg <= 3 && len(k.pkeys) > 0 && len(k.pkeys[0].data) >= 8 {
+			return k.pkeys[0].data[len(k.pkeys[0].data)-8:]
+		}
+		return nil
+	case 4:
+		return fingerprint(k.public, 4)[12:20]
+	case 5:
+		return fingerprint(k.public, 5)[:8]
+	default:
+		return nil
+	}
+}
+
+//fingerprint returns fingerprint of key packet (nil if version 3 or earlier)
+func (k *keyPacket) fingerprint() []byte {
+	return fingerprint(k.public, byte(k.version))
+}
+
+//curve returns ECC curve OID of key packet
+func (k *keyPacket) curve() []byte {
+	if len(k.pkeys) > 0 && k.pkeys[0].sized {
+		return k.pkeys[0].data
+	}
+	return nil
+}
+
+//newSecretKey returns secretKey instance taken from "Secret-Key" item
+func newSecretKey(f *fields) *secretKey {
+	sec, ok := f.in("Secret-Key")
+	if !ok {
+		return nil
+	}
+	s := &secretKey{}
+	_, _ = fmt.Sscanf(f.find("Secret-Key").Note, "s2k usage %d", &s.usage)
+	switch s.usage {
+	case 0:
+	case 253, 254, 255:
+		c, _ := sec.byte("Symmetric Algorithm")
+		s.cipher = int(c)
+		c, _ = sec.byte("AEAD Algorithm")
+		s.aead = int(c)
+		s.s2k = newS2KSpec(sec)
+	default:
+		s.cipher = s.usage
+	}
+	for _, itm := range sec.items {
+		if itm == nil || itm.Kind != result.KindNone {
+			continue
+		}
+		switch itm.Name {
+		case "Symmetric Algorithm", "AEAD Algorithm", "String-to-Key (S2K) Algorithm", "Unknown data", "Decrypted secret-key material":
+		case "IV", "nonce for the AEAD":
+			s.iv = sec.octets(itm)
+		case "2-octet checksum":
+			s.checksum, _ = sec.tail(itm.Name, 2)
+		default:
+			s.values = append(s.values, sec.value(itm))
+		}
+	}
+	return s
+}
+
+//newS2KSpec returns s2kSpec instance taken from "String-to-Key (S2K) Algorithm" item (nil if not exist)
+func newS2KSpec(f *fields) *s2kSpec {
+	mode, ok := f.byte("String-to-Key (S2K) Algorithm")
+	if !ok {
+		return nil
+	}
+	f, _ = f.in("String-to-Key (S2K) Algorithm")
+	s := &s2kSpec{mode: int(mode)}
+	hash, _ := f.byte("Hash Algorithm")
+	s.hash = int(hash)
+	s.salt, _ = f.tail("Salt", 8)
+	s.count, _ = f.byte("Count")
+	if itm := f.find("GNU-divert-to-card"); itm != nil {
+		_, _ = fmt.Sscanf(itm.Value, "Extension Number %d", &s.gnu)
+		s.serial = f.dump("Serial Number")
+	}
+	return s
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
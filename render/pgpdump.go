@@ -0,0 +1,911 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+var pgpTagNames = map[int]string{
+	0:  "Reserved",
+	1:  "Public-Key Encrypted Session Key Packet",
+	2:  "Signature Packet",
+	3:  "Symmetric-Key Encrypted Session Key Packet",
+	4:  "One-Pass Signature Packet",
+	5:  "Secret Key Packet",
+	6:  "Public Key Packet",
+	7:  "Secret Subkey Packet",
+	8:  "Compressed Data Packet",
+	9:  "Symmetrically Encrypted Data Packet",
+	10: "Marker Packet",
+	11: "Literal Data Packet",
+	12: "Trust Packet",
+	13: "User ID Packet",
+	14: "Public Subkey Packet",
+	17: "User Attribute Packet",
+	18: "Symmetrically Encrypted and MDC Packet",
+	19: "Modification Detection Code Packet",
+	20: "AEAD Encrypted Data Packet",
+}
+
+var pgpPubAlgNames = map[int]string{
+	1:  "RSA Encrypt or Sign",
+	2:  "RSA Encrypt-Only",
+	3:  "RSA Sign-Only",
+	16: "ElGamal Encrypt-Only",
+	17: "DSA Digital Signature Algorithm",
+	18: "ECDH Elliptic Curve Diffie-Hellman Algorithm",
+	19: "ECDSA Elliptic Curve Digital Signature Algorithm",
+	20: "Reserved formerly ElGamal Encrypt or Sign",
+	21: "Reserved for Diffie-Hellman (X9.42)",
+	22: "EdDSA Edwards-curve Digital Signature Algorithm",
+	25: "X25519",
+	26: "X448",
+	27: "Ed25519",
+	28: "Ed448",
+}
+
+var pgpSymAlgNames = map[int]string{
+	0:  "Plaintext or unencrypted data",
+	1:  "IDEA",
+	2:  "Triple-DES",
+	3:  "CAST5",
+	4:  "Blowfish",
+	5:  "Reserved",
+	6:  "Reserved",
+	7:  "AES with 128-bit key",
+	8:  "AES with 192-bit key",
+	9:  "AES with 256-bit key",
+	10: "Twofish with 256-bit key",
+	11: "Camellia with 128-bit key",
+	12: "Camellia with 192-bit key",
+	13: "Camellia with 256-bit key",
+}
+
+var pgpHashAlgNames = map[int]string{
+	1:  "MD5",
+	2:  "SHA1",
+	3:  "RIPEMD160",
+	4:  "Reserved",
+	5:  "Reserved",
+	6:  "Reserved",
+	7:  "Reserved",
+	8:  "SHA256",
+	9:  "SHA384",
+	10: "SHA512",
+	11: "SHA224",
+}
+
+var pgpCompAlgNames = map[int]string{
+	0: "Uncompressed",
+	1: "ZIP <RFC1951>",
+	2: "ZLIB <RFC1950>",
+	3: "BZip2",
+}
+
+var pgpAEADAlgNames = map[int]string{
+	1: "EAX",
+	2: "OCB",
+}
+
+var pgpSigTypeNames = map[int]string{
+	0x00: "Signature of a binary document",
+	0x01: "Signature of a canonical text document",
+	0x02: "Standalone signature",
+	0x10: "Generic certification of a User ID and Public Key packet",
+	0x11: "Persona certification of a User ID and Public Key packet",
+	0x12: "Casual certification of a User ID and Public Key packet",
+	0x13: "Positive certification of a User ID and Public Key packet",
+	0x16: "Attested key signature",
+	0x18: "Subkey Binding Signature",
+	0x19: "Primary Key Binding Signature",
+	0x1f: "Signature directly on a key",
+	0x20: "Key revocation signature",
+	0x28: "Subkey revocation signature",
+	0x30: "Certification revocation signature",
+	0x40: "Timestamp signature",
+	0x50: "Third-Party Confirmation signature",
+}
+
+var pgpSubNames = map[int]string{
+	2:  "signature creation time",
+	3:  "signature expiration time",
+	4:  "exportable certification",
+	5:  "trust signature",
+	6:  "regular expression",
+	7:  "revocable",
+	9:  "key expiration time",
+	10: "additional decryption key",
+	11: "preferred symmetric algorithms",
+	12: "revocation key",
+	16: "issuer key ID",
+	20: "notation data",
+	21: "preferred hash algorithms",
+	22: "preferred compression algorithms",
+	23: "key server preferences",
+	24: "preferred key server",
+	25: "primary User ID",
+	26: "policy URL",
+	27: "key flags",
+	28: "signer's User ID",
+	29: "reason for revocation",
+	30: "features",
+	31: "signature target",
+	32: "embedded signature",
+	33: "issuer fingerprint",
+	34: "preferred AEAD algorithms",
+	35: "intended recipient fingerprint",
+	37: "attested certifications",
+}
+
+var pgpCurveNames = map[string]string{
+	"nistp256":        "NIST curve P-256",
+	"nistp384":        "NIST curve P-384",
+	"nistp521":        "NIST curve P-521",
+	"secp256k1":       "secp256k1",
+	"brainpoolP256r1": "brainpoolP256r1",
+	"brainpoolP384r1": "brainpoolP384r1",
+	"brainpoolP512r1": "brainpoolP512r1",
+	"ed25519":         "Ed25519",
+	"cv25519":         "Curve25519",
+}
+
+var pgpKeyFlags = []string{
+	"This key may be used to certify other keys",
+	"This key may be used to sign data",
+	"This key may be used to encrypt communications",
+	"This key may be used to encrypt storage",
+	"The private component of this key may have been split by a secret-sharing mechanism",
+	"This key may be used for authentication",
+	"",
+	"The private component of this key may be in the possession of more than one person",
+}
+
+var pgpFeatures = []string{
+	"Modification detection (packets 18 and 19)",
+	"AEAD Encrypted Data Packet (packet 20) and version 5 Symmetric-Key Encrypted Session Key Packets (packet 3)",
+	"Version 5 Public-Key Packet format and corresponding new fingerprint format",
+}
+
+var pgpRevReasons = map[int]string{
+	0:  "No reason specified",
+	1:  "Key is superseded",
+	2:  "Key material has been compromised",
+	3:  "Key is retired and no longer used",
+	32: "User ID information is no longer valid",
+}
+
+//pgpdumper class is renderer for pgpdump compatible output
+type pgpdumper struct {
+	w          *bytes.Buffer
+	utc        bool
+	integer    bool
+	literal    bool
+	symAlgMode string
+	keyCreated uint32
+}
+
+//PGPDump returns text compatible with pgpdump output
+func PGPDump(cxt *context.Context, info *result.Info) (io.Reader, error) {
+	if cxt == nil {
+		cxt = context.New()
+	}
+	d := &pgpdumper{w: &bytes.Buffer{}, utc: cxt.UTC(), integer: cxt.Integer(), literal: cxt.Literal()}
+	if info == nil {
+		return d.w, nil
+	}
+	for _, item := range info.Packets {
+		d.packet(item)
+	}
+	return d.w, nil
+}
+
+//packet outputs packet and packets in compressed data
+func (d *pgpdumper) packet(item *result.Item) {
+	if item == nil || item.Kind != result.KindPacket {
+		return
+	}
+	tag := item.Code
+	format := "Old"
+	length := fmt.Sprintf("(%d bytes)", len(item.Raw))
+	if h := item.Header; h != nil {
+		if h.NewFormat() {
+			format = "New"
+		}
+		if h.Partial {
+			if h.NewFormat() {
+				length += " partial"
+			} else {
+				length = "(until eof)"
+			}
+		}
+	}
+	fmt.Fprintf(d.w, "%s: %s(tag %d)%s\n", format, pgpName(pgpTagNames, tag, "unknown"), tag, length)
+	f := newFields(item)
+	switch tag {
+	case 1:
+		d.pubkeyEnc(f)
+	case 2:
+		if sig := newSignature(item); sig != nil {
+			d.signature(sig)
+		}
+	case 3:
+		d.symkeyEnc(f)
+	case 4:
+		d.onepass(f)
+	case 5, 6, 7, 14:
+		d.key(newKeyPacket(item), tag)
+	case 8:
+		algo, _ := f.byte("Compression Algorithm")
+		fmt.Fprintf(d.w, "\tComp alg - %s\n", pgpAlg(pgpCompAlgNames, int(algo), "comp"))
+	case 9:
+		fmt.Fprintf(d.w, "\tEncrypted data [sym alg is specified in %s encrypted session key]\n", d.specified())
+	case 10:
+		fmt.Fprintf(d.w, "\tString - %s\n", item.Raw)
+	case 11:
+		d.literalData(f)
+	case 12:
+		fmt.Fprintf(d.w, "\tTrust - %s\n", dumpString(item.Raw))
+	case 13:
+		fmt.Fprintf(d.w, "\tUser ID - %s\n", item.Raw)
+	case 17:
+		d.userAttribute(item)
+	case 18:
+		v, _ := seipdVersion(f)
+		fmt.Fprintf(d.w, "\tVer %d\n", v)
+		fmt.Fprintf(d.w, "\tEncrypted data [sym alg is specified in %s encrypted session key]\n", d.specified())
+		d.w.WriteString("\t\t(plain text + MDC SHA1(20 bytes))\n")
+	case 19:
+		fmt.Fprintf(d.w, "\tMDC - SHA1(%d bytes)\n", len(item.Raw))
+	case 20:
+		v, _ := f.byte("Version")
+		cipher, _ := f.byte("Symmetric Algorithm")
+		aead, _ := f.byte("AEAD Algorithm")
+		cb, _ := f.byte("Chunk size")
+		fmt.Fprintf(d.w, "\tVer %d\n", v)
+		fmt.Fprintf(d.w, "\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, int(cipher), "sym"))
+		fmt.Fprintf(d.w, "\tAEAD alg - %s\n", pgpAlg(pgpAEADAlgNames, int(aead), "aead"))
+		fmt.Fprintf(d.w, "\tChunk size - %d\n", 1<<(uint(cb)+6))
+		d.w.WriteString("\tEncrypted data\n")
+	}
+	//packets in compressed or decrypted data
+	for _, itm := range item.Items {
+		if itm != nil && itm.Header != nil {
+			d.packet(itm)
+		}
+	}
+}
+
+//specified returns packet name in which symmetric algorithm is specified
+func (d *pgpdumper) specified() string {
+	if d.symAlgMode == "" {
+		return "sym-key"
+	}
+	return d.symAlgMode
+}
+
+//time outputs date-time string like ctime(3)
+func (d *pgpdumper) time(t uint32) string {
+	tm := time.Unix(int64(t), 0)
+	if d.utc {
+		tm = tm.UTC()
+	}
+	return tm.Format("Mon Jan _2 15:04:05 MST 2006")
+}
+
+//mpi outputs multi-precision integer
+func (d *pgpdumper) mpi(prefix, name string, bits int, data []byte) {
+	fmt.Fprintf(d.w, "%s%s(%d bits) - ", prefix, name, bits)
+	if d.integer {
+		fmt.Fprintf(d.w, "%x\n", data)
+	} else {
+		d.w.WriteString("...\n")
+	}
+}
+
+//version outputs version of packet
+func (d *pgpdumper) version(prefix string, v byte) {
+	switch v {
+	case 2, 3:
+		fmt.Fprintf(d.w, "%sVer %d - old\n", prefix, v)
+	case 4, 5:
+		fmt.Fprintf(d.w, "%sVer %d - new\n", prefix, v)
+	default:
+		fmt.Fprintf(d.w, "%sVer %d - unknown\n", prefix, v)
+	}
+}
+
+//pubkeyEnc outputs Public-Key Encrypted Session Key Packet (tag 1)
+func (d *pgpdumper) pubkeyEnc(f *fields) {
+	d.symAlgMode = "pub-key"
+	v, _ := f.byte("Version")
+	switch v {
+	case 3:
+		d.w.WriteString("\tNew version(3)\n")
+	default:
+		fmt.Fprintf(d.w, "\tOld version(%d)\n", v)
+	}
+	keyid, _ := f.tail("Key ID", 8)
+	algo, ok := f.byte("Public-key Algorithm")
+	if !ok {
+		return
+	}
+	fmt.Fprintf(d.w, "\tKey ID - 0x%s\n", keyIDString(keyid))
+	fmt.Fprintf(d.w, "\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, int(algo), "pub"))
+	vals := f.values(f.after("Public-key Algorithm"))
+	switch algo {
+	case 1, 2, 3:
+		if len(vals) > 0 {
+			d.mpi("\t", "RSA m^e mod n", vals[0].bits, vals[0].data)
+			d.w.WriteString("\t\t-> m = sym alg(1 byte) + checksum(2 bytes) + PKCS-1 block type 02\n")
+		}
+	case 16, 20:
+		if len(vals) > 0 {
+			d.mpi("\t", "ElGamal g^k mod p", vals[0].bits, vals[0].data)
+		}
+		if len(vals) > 1 {
+			d.mpi("\t", "ElGamal m * y^k mod p", vals[1].bits, vals[1].data)
+			d.w.WriteString("\t\t-> m = sym alg(1 byte) + checksum(2 bytes) + PKCS-1 block type 02\n")
+		}
+	case 18:
+		if len(vals) > 0 {
+			d.mpi("\t", "ECDH ephemeral public key", vals[0].bits, vals[0].data)
+		}
+		if len(vals) > 1 {
+			fmt.Fprintf(d.w, "\tECDH symmetric key(%d bytes) - ", len(vals[1].data))
+			if d.integer {
+				fmt.Fprintf(d.w, "%x\n", vals[1].data)
+			} else {
+				d.w.WriteString("...\n")
+			}
+		}
+	default:
+		d.w.WriteString("\tunknown(pub)\n")
+	}
+}
+
+//signature outputs Signature Packet (tag 2)
+func (d *pgpdumper) signature(sig *signature) {
+	d.version("\t", byte(sig.version))
+	switch sig.version {
+	case 2, 3:
+		d.w.WriteString("\tHash material(5 bytes):\n")
+		fmt.Fprintf(d.w, "\t\tSig type - %s\n", pgpSigType(sig.sigType))
+		fmt.Fprintf(d.w, "\t\tCreation time - %s\n", d.time(sig.created))
+		fmt.Fprintf(d.w, "\tKey ID - 0x%s\n", keyIDString(sig.keyID))
+		fmt.Fprintf(d.w, "\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, sig.pubAlg, "pub"))
+		fmt.Fprintf(d.w, "\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, sig.hashAlg, "hash"))
+	case 4, 5:
+		fmt.Fprintf(d.w, "\tSig type - %s\n", pgpSigType(sig.sigType))
+		fmt.Fprintf(d.w, "\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, sig.pubAlg, "pub"))
+		fmt.Fprintf(d.w, "\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, sig.hashAlg, "hash"))
+		for _, sub := range sig.hashed {
+			d.subpacket(sub, "Hashed Sub", sig.created)
+		}
+		for _, sub := range sig.unhashed {
+			d.subpacket(sub, "Sub", sig.created)
+		}
+	default:
+		return
+	}
+	if len(sig.left) < 2 {
+		return
+	}
+	fmt.Fprintf(d.w, "\tHash left 2 bytes - %02x %02x \n", sig.left[0], sig.left[1])
+	var names []string
+	switch sig.pubAlg {
+	case 1, 3:
+		names = []string{"RSA m^d mod n"}
+	case 17:
+		names = []string{"DSA r", "DSA s"}
+	case 19:
+		names = []string{"ECDSA r", "ECDSA s"}
+	case 20:
+		names = []string{"ElGamal a = g^k mod p", "ElGamal b = (h - a*x)/k mod p - 1"}
+	case 22:
+		names = []string{"EdDSA R", "EdDSA s"}
+	default:
+		d.w.WriteString("\tunknown(pub)\n")
+		return
+	}
+	for i, v := range sig.values {
+		if i < len(names) {
+			d.mpi("\t", names[i], v.bits, v.data)
+		}
+	}
+	if sig.pubAlg == 1 || sig.pubAlg == 3 {
+		d.w.WriteString("\t\t-> PKCS-1\n")
+	}
+}
+
+//subpacket outputs sub-packet in signature packet
+func (d *pgpdumper) subpacket(sub subpacket, area string, created uint32) {
+	b := sub.body
+	name := pgpSubNames[sub.typ]
+	switch {
+	case name != "":
+	case 100 <= sub.typ && sub.typ <= 110:
+		name = "private/experimental"
+	case sub.typ < 100:
+		name = "reserved"
+	default:
+		name = "unknown"
+	}
+	fmt.Fprintf(d.w, "\t%s: %s(sub %d)", area, name, sub.typ)
+	if sub.critical {
+		d.w.WriteString("(critical)")
+	}
+	fmt.Fprintf(d.w, "(%d bytes)\n", len(b))
+	switch sub.typ {
+	case 2:
+		if len(b) >= 4 {
+			fmt.Fprintf(d.w, "\t\tTime - %s\n", d.time(binary.BigEndian.Uint32(b)))
+		}
+	case 3, 9:
+		if len(b) >= 4 {
+			base := created
+			if sub.typ == 9 {
+				base = d.keyCreated
+			}
+			if t := binary.BigEndian.Uint32(b); t == 0 {
+				d.w.WriteString("\t\tTime - Never\n")
+			} else {
+				fmt.Fprintf(d.w, "\t\tTime - %s\n", d.time(base+t))
+			}
+		}
+	case 4:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "\t\tExportable - %s\n", yesNo(b[0]))
+		}
+	case 5:
+		if len(b) >= 2 {
+			fmt.Fprintf(d.w, "\t\tLevel - %d\n\t\tAmount - %d\n", b[0], b[1])
+		}
+	case 6:
+		fmt.Fprintf(d.w, "\t\tRegex - %s\n", bytes.TrimRight(b, "\x00"))
+	case 7:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "\t\tRevocable - %s\n", yesNo(b[0]))
+		}
+	case 11:
+		for _, c := range b {
+			fmt.Fprintf(d.w, "\t\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, int(c), "sym"))
+		}
+	case 12:
+		if len(b) >= 2 {
+			class := "Normal"
+			if b[0]&0x40 != 0 {
+				class = "Sensitive"
+			}
+			fmt.Fprintf(d.w, "\t\tClass - %s\n", class)
+			fmt.Fprintf(d.w, "\t\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, int(b[1]), "pub"))
+			fmt.Fprintf(d.w, "\t\tFingerprint - %s\n", dumpString(b[2:]))
+		}
+	case 16:
+		if len(b) >= 8 {
+			fmt.Fprintf(d.w, "\t\tKey ID - 0x%s\n", keyIDString(b[:8]))
+		}
+	case 20:
+		if len(b) < 8 {
+			break
+		}
+		if b[0]&0x80 != 0 {
+			d.w.WriteString("\t\tFlag - Human-readable\n")
+		}
+		n1 := int(binary.BigEndian.Uint16(b[4:]))
+		n2 := int(binary.BigEndian.Uint16(b[6:]))
+		if 8+n1+n2 > len(b) {
+			break
+		}
+		fmt.Fprintf(d.w, "\t\tName - %s\n", b[8:8+n1])
+		if b[0]&0x80 != 0 {
+			fmt.Fprintf(d.w, "\t\tValue - %s\n", b[8+n1:8+n1+n2])
+		} else {
+			fmt.Fprintf(d.w, "\t\tValue - %s\n", dumpString(b[8+n1:8+n1+n2]))
+		}
+	case 21:
+		for _, c := range b {
+			fmt.Fprintf(d.w, "\t\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, int(c), "hash"))
+		}
+	case 22:
+		for _, c := range b {
+			fmt.Fprintf(d.w, "\t\tComp alg - %s\n", pgpAlg(pgpCompAlgNames, int(c), "comp"))
+		}
+	case 23:
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			d.w.WriteString("\t\tFlag - No-modify\n")
+		}
+	case 24, 26:
+		fmt.Fprintf(d.w, "\t\tURL - %s\n", b)
+	case 25:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "\t\tPrimary - %s\n", yesNo(b[0]))
+		}
+	case 27:
+		d.flags(b, pgpKeyFlags)
+	case 28:
+		fmt.Fprintf(d.w, "\t\tUser ID - %s\n", b)
+	case 29:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "\t\tReason - %s\n", pgpName(pgpRevReasons, int(b[0]), "unknown"))
+			fmt.Fprintf(d.w, "\t\tComment - %s\n", b[1:])
+		}
+	case 30:
+		d.flags(b, pgpFeatures)
+	case 31:
+		if len(b) >= 2 {
+			fmt.Fprintf(d.w, "\t\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, int(b[0]), "pub"))
+			fmt.Fprintf(d.w, "\t\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, int(b[1]), "hash"))
+			fmt.Fprintf(d.w, "\t\tHash - %s\n", dumpString(b[2:]))
+		}
+	case 32:
+		if sig := sub.embedded(); sig != nil {
+			d.signature(sig)
+		}
+	case 33, 35:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "\t\tv%d -\tFingerprint - %s\n", b[0], dumpString(b[1:]))
+		}
+	case 34:
+		for _, c := range b {
+			fmt.Fprintf(d.w, "\t\tAEAD alg - %s\n", pgpAlg(pgpAEADAlgNames, int(c), "aead"))
+		}
+	}
+}
+
+//flags outputs descriptions of flag bits
+func (d *pgpdumper) flags(b []byte, names []string) {
+	for i, c := range b {
+		for j := 0; j < 8; j++ {
+			if c&(1<<uint(j)) == 0 {
+				continue
+			}
+			if n := i*8 + j; n < len(names) && names[n] != "" {
+				fmt.Fprintf(d.w, "\t\tFlag - %s\n", names[n])
+			} else {
+				fmt.Fprintf(d.w, "\t\tFlag - unknown(flag %d)\n", n)
+			}
+		}
+	}
+}
+
+//symkeyEnc outputs Symmetric-Key Encrypted Session Key Packet (tag 3)
+func (d *pgpdumper) symkeyEnc(f *fields) {
+	d.symAlgMode = "sym-key"
+	v, _ := f.byte("Version")
+	d.version("\t", v)
+	cipher, ok := f.byte("Symmetric Algorithm")
+	if !ok {
+		return
+	}
+	fmt.Fprintf(d.w, "\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, int(cipher), "sym"))
+	if aead, ok := f.byte("AEAD Algorithm"); ok {
+		fmt.Fprintf(d.w, "\tAEAD alg - %s\n", pgpAlg(pgpAEADAlgNames, int(aead), "aead"))
+	}
+	if !d.s2k(newS2KSpec(f), "\t") {
+		return
+	}
+	if iv := f.octets(f.find("IV")); len(iv) > 0 {
+		fmt.Fprintf(d.w, "\tIV - %s\n", dumpString(iv))
+	}
+	if f.find("Encrypted session key") != nil || f.find("Encrypted session key and authentication tag") != nil {
+		d.w.WriteString("\tEncrypted session key\n")
+		d.w.WriteString("\t\t-> sym alg(1 bytes) + session key\n")
+	}
+}
+
+//s2k outputs String-to-Key specifier
+func (d *pgpdumper) s2k(s *s2kSpec, prefix string) bool {
+	if s == nil {
+		return false
+	}
+	switch s.mode {
+	case 0:
+		fmt.Fprintf(d.w, "%sSimple string-to-key(s2k 0):\n", prefix)
+	case 1:
+		fmt.Fprintf(d.w, "%sSalted string-to-key(s2k 1):\n", prefix)
+	case 3:
+		fmt.Fprintf(d.w, "%sIterated and salted string-to-key(s2k 3):\n", prefix)
+	case 101:
+		fmt.Fprintf(d.w, "%sGnuPG string-to-key(s2k 101):\n", prefix)
+	default:
+		fmt.Fprintf(d.w, "%sunknown(s2k %d):\n", prefix, s.mode)
+	}
+	fmt.Fprintf(d.w, "%s\tHash alg - %s\n", prefix, pgpAlg(pgpHashAlgNames, s.hash, "hash"))
+	switch s.mode {
+	case 1, 3:
+		if len(s.salt) == 0 {
+			return false
+		}
+		fmt.Fprintf(d.w, "%s\tSalt - %s\n", prefix, dumpString(s.salt))
+		if s.mode == 3 {
+			fmt.Fprintf(d.w, "%s\tCount - %d(coded count %d)\n", prefix, values.Stretch(s.count).Count(), s.count)
+		}
+	case 101:
+		switch s.gnu {
+		case 1001:
+			fmt.Fprintf(d.w, "%s\tGNU extension - dummy\n", prefix)
+		case 1002:
+			fmt.Fprintf(d.w, "%s\tGNU extension - divert to card\n", prefix)
+			if len(s.serial) > 0 {
+				fmt.Fprintf(d.w, "%s\tSerial number - %s\n", prefix, dumpString(s.serial))
+			}
+		default:
+			fmt.Fprintf(d.w, "%s\tGNU extension - unknown(mode %d)\n", prefix, s.gnu%1000)
+		}
+	}
+	return true
+}
+
+//onepass outputs One-Pass Signature Packet (tag 4)
+func (d *pgpdumper) onepass(f *fields) {
+	v, _ := f.byte("Version")
+	sigclass, _ := f.byte("Signiture Type")
+	hash, _ := f.byte("Hash Algorithm")
+	algo, _ := f.byte("Public-key Algorithm")
+	keyid, _ := f.tail("Key ID", 8)
+	last, ok := onepassLast(f)
+	if !ok {
+		return
+	}
+	fmt.Fprintf(d.w, "\tNew version(%d)\n", v)
+	fmt.Fprintf(d.w, "\tSig type - %s\n", pgpSigType(int(sigclass)))
+	fmt.Fprintf(d.w, "\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, int(hash), "hash"))
+	fmt.Fprintf(d.w, "\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, int(algo), "pub"))
+	fmt.Fprintf(d.w, "\tKey ID - 0x%s\n", keyIDString(keyid))
+	if last == 0 {
+		d.w.WriteString("\tNext packet - another one pass signature\n")
+	} else {
+		d.w.WriteString("\tNext packet - other than one pass signature\n")
+	}
+}
+
+//key outputs Public-Key, Public-Subkey, Secret-Key and Secret-Subkey Packet (tag 5, 6, 7 and 14)
+func (d *pgpdumper) key(k *keyPacket, tag int) {
+	if k == nil {
+		return
+	}
+	d.version("\t", byte(k.version))
+	if k.version < 2 || 5 < k.version {
+		return
+	}
+	if tag == 5 || tag == 6 {
+		d.keyCreated = k.created
+	}
+	fmt.Fprintf(d.w, "\tPublic key creation time - %s\n", d.time(k.created))
+	if k.version < 4 {
+		fmt.Fprintf(d.w, "\tValid days - %d[0 is forever]\n", k.days)
+	}
+	fmt.Fprintf(d.w, "\tPub alg - %s\n", pgpAlg(pgpPubAlgNames, k.pubAlg, "pub"))
+	names, secrets := pgpKeyNames(k.pubAlg)
+	if names == nil {
+		d.w.WriteString("\tunknown(pub)\n")
+		return
+	}
+	for i, name := range names {
+		if i >= len(k.pkeys) {
+			return
+		}
+		val := k.pkeys[i]
+		if !val.sized {
+			d.mpi("\t", name, val.bits, val.data)
+			continue
+		}
+		if i == 0 {
+			curve, ok := pgpCurveNames[curveName(val.data)]
+			if !ok {
+				curve = "unknown"
+			}
+			fmt.Fprintf(d.w, "\tElliptic Curve - %s (%s)\n", curve, oidDump(val.data))
+			continue
+		}
+		if len(val.data) >= 3 {
+			fmt.Fprintf(d.w, "\tHash alg - %s\n", pgpAlg(pgpHashAlgNames, int(val.data[1]), "hash"))
+			fmt.Fprintf(d.w, "\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, int(val.data[2]), "sym"))
+		}
+	}
+	if (tag == 5 || tag == 7) && k.secret != nil {
+		d.secretKey(k.secret, k.version, secrets)
+	}
+}
+
+//secretKey outputs secret part of Secret-Key Packet
+func (d *pgpdumper) secretKey(s *secretKey, v int, names []string) {
+	switch s.usage {
+	case 0:
+	case 253, 254, 255:
+		fmt.Fprintf(d.w, "\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, s.cipher, "sym"))
+		if s.usage == 253 {
+			fmt.Fprintf(d.w, "\tAEAD alg - %s\n", pgpAlg(pgpAEADAlgNames, s.aead, "aead"))
+		}
+		if !d.s2k(s.s2k, "\t") {
+			return
+		}
+		if s.s2k.mode == 101 {
+			//GnuPG extensions have no IV and no secret key
+			return
+		}
+	default:
+		fmt.Fprintf(d.w, "\tSym alg - %s\n", pgpAlg(pgpSymAlgNames, s.cipher, "sym"))
+		d.w.WriteString("\tSimple string-to-key for IDEA\n")
+	}
+	if s.usage != 0 {
+		if len(s.iv) == 0 {
+			return
+		}
+		fmt.Fprintf(d.w, "\tIV - %s\n", dumpString(s.iv))
+	}
+	if s.usage != 0 && v >= 4 {
+		for _, name := range names {
+			fmt.Fprintf(d.w, "\tEncrypted %s\n", name)
+		}
+		switch s.usage {
+		case 254:
+			d.w.WriteString("\tEncrypted SHA1 hash\n")
+		case 253:
+			d.w.WriteString("\tEncrypted AEAD tag\n")
+		default:
+			d.w.WriteString("\tEncrypted checksum\n")
+		}
+		return
+	}
+	for i, name := range names {
+		if i >= len(s.values) {
+			return
+		}
+		val := s.values[i]
+		if s.usage != 0 {
+			fmt.Fprintf(d.w, "\tEncrypted %s(%d bits)\n", name, val.bits)
+			continue
+		}
+		d.mpi("\t", name, val.bits, val.data)
+	}
+	if len(s.checksum) == 2 {
+		fmt.Fprintf(d.w, "\tChecksum - %s\n", dumpString(s.checksum))
+	}
+}
+
+//pgpKeyNames returns names of public and secret key values
+func pgpKeyNames(algo int) ([]string, []string) {
+	switch algo {
+	case 1, 2, 3:
+		return []string{"RSA n", "RSA e"}, []string{"RSA d", "RSA p", "RSA q", "RSA u"}
+	case 16, 20:
+		return []string{"ElGamal p", "ElGamal g", "ElGamal y"}, []string{"ElGamal x"}
+	case 17:
+		return []string{"DSA p", "DSA q", "DSA g", "DSA y"}, []string{"DSA x"}
+	case 18:
+		return []string{"", "ECDH Q", ""}, []string{"ECDH d"}
+	case 19:
+		return []string{"", "ECDSA Q"}, []string{"ECDSA d"}
+	case 22:
+		return []string{"", "EdDSA Q"}, []string{"EdDSA d"}
+	default:
+		return nil, nil
+	}
+}
+
+//literalData outputs Literal Data Packet (tag 11)
+func (d *pgpdumper) literalData(f *fields) {
+	mode, _ := f.byte("Literal data format")
+	name, _ := f.sized("File name")
+	modified, ok := f.uint32("Creation time")
+	if !ok {
+		return
+	}
+	switch mode {
+	case 'b':
+		d.w.WriteString("\tFormat - binary\n")
+	case 't':
+		d.w.WriteString("\tFormat - text\n")
+	case 'u':
+		d.w.WriteString("\tFormat - UTF-8 text\n")
+	case 'l', '1':
+		d.w.WriteString("\tFormat - local\n")
+	default:
+		fmt.Fprintf(d.w, "\tFormat - unknown(%#02x)\n", mode)
+	}
+	fmt.Fprintf(d.w, "\tFilename - %s\n", name)
+	fmt.Fprintf(d.w, "\tFile modified time - %s\n", d.time(modified))
+	if d.literal {
+		fmt.Fprintf(d.w, "\tLiteral - %s\n", strings.TrimRight(string(f.octets(f.find("Literal data"))), "\r\n"))
+	} else {
+		d.w.WriteString("\tLiteral - ...\n")
+	}
+}
+
+//userAttribute outputs User Attribute Packet (tag 17)
+func (d *pgpdumper) userAttribute(item *result.Item) {
+	for _, sub := range subpacketItems(item, result.KindSubpacketArea) {
+		switch sub.typ {
+		case 1:
+			fmt.Fprintf(d.w, "\tSub: image attribute(sub 1)(%d bytes)\n", len(sub.body))
+			if len(sub.body) < 4 {
+				break
+			}
+			hlen := int(binary.LittleEndian.Uint16(sub.body))
+			if sub.body[3] == 1 {
+				d.w.WriteString("\t\tImage encoding - JPEG(image 1)\n")
+			} else {
+				fmt.Fprintf(d.w, "\t\tImage encoding - unknown(image %d)\n", sub.body[3])
+			}
+			if hlen <= len(sub.body) {
+				fmt.Fprintf(d.w, "\t\tImage data(%d bytes)\n", len(sub.body)-hlen)
+			}
+		default:
+			fmt.Fprintf(d.w, "\tSub: unknown(sub %d)(%d bytes)\n", sub.typ, len(sub.body))
+		}
+	}
+}
+
+//pgpName returns name in table or default string
+func pgpName(names map[int]string, id int, def string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return def
+}
+
+//pgpAlg returns algorithm name with its ID
+func pgpAlg(names map[int]string, id int, typ string) string {
+	name, ok := names[id]
+	switch {
+	case ok:
+	case 100 <= id && id <= 110:
+		name = "Private/Experimental algorithm"
+	default:
+		name = "unknown"
+	}
+	return fmt.Sprintf("%s(%s %d)", name, typ, id)
+}
+
+//pgpSigType returns name of signature type
+func pgpSigType(t int) string {
+	return fmt.Sprintf("%s(%#02x).", pgpName(pgpSigTypeNames, t, "unknown"), t)
+}
+
+//oidDump returns hex dump of OID like pgpdump
+func oidDump(oid []byte) string {
+	if len(oid) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("0x%s", strings.TrimSpace(strings.ToUpper(dumpString(oid))))
+}
+
+//dumpString returns hex dump string separated by space
+func dumpString(b []byte) string {
+	bldr := &strings.Builder{}
+	for _, c := range b {
+		fmt.Fprintf(bldr, "%02x ", c)
+	}
+	return bldr.String()
+}
+
+func yesNo(flag byte) string {
+	if flag == 0 {
+		return "No"
+	}
+	return "Yes"
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+)
+
+func TestPGPDump(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		content string
+	}{
+		{name: "../testdata/comp-sig.asc", armored: true, content: `Old: Compressed Data Packet(tag 8)(until eof)
+	Comp alg - ZIP <RFC1951>(comp 1)
+Old: One-Pass Signature Packet(tag 4)(13 bytes)
+	New version(3)
+	Sig type - Signature of a binary document(0x00).
+	Hash alg - SHA256(hash 8)
+	Pub alg - DSA Digital Signature Algorithm(pub 17)
+	Key ID - 0xB4DA3BAE7E20B81C
+	Next packet - other than one pass signature
+New: Literal Data Packet(tag 11)(19 bytes)
+	Format - binary
+	Filename - 
+	File modified time - Sat Nov 25 06:29:56 UTC 2017
+	Literal - ...
+Old: Signature Packet(tag 2)(117 bytes)
+	Ver 4 - new
+	Sig type - Signature of a binary document(0x00).
+	Pub alg - DSA Digital Signature Algorithm(pub 17)
+	Hash alg - SHA256(hash 8)
+	Hashed Sub: issuer fingerprint(sub 33)(21 bytes)
+		v4 -	Fingerprint - 1b 52 02 db 4a 3e c7 76 f1 e0 ad 18 b4 da 3b ae 7e 20 b8 1c 
+	Hashed Sub: signature creation time(sub 2)(4 bytes)
+		Time - Sat Nov 25 06:29:56 UTC 2017
+	Sub: issuer key ID(sub 16)(8 bytes)
+		Key ID - 0xB4DA3BAE7E20B81C
+	Hash left 2 bytes - 73 3c 
+	DSA r(256 bits) - ...
+	DSA s(255 bits) - ...
+`},
+		{name: "../testdata/from-pgpdump/enc1", armored: false, content: `Old: Marker Packet(tag 10)(3 bytes)
+	String - PGP
+New: Symmetric-Key Encrypted Session Key Packet(tag 3)(4 bytes)
+	Ver 4 - new
+	Sym alg - CAST5(sym 3)
+	Simple string-to-key(s2k 0):
+		Hash alg - MD5(hash 1)
+New: Symmetrically Encrypted Data Packet(tag 9)(56 bytes)
+	Encrypted data [sym alg is specified in sym-key encrypted session key]
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := PGPDump(context.New(context.Set(context.UTC, true)), parseFile(t, tc.name, tc.armored))
+		if err != nil {
+			t.Fatalf("PGPDump() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("PGPDump(%v) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestPGPDumpUnprotectedSecretKey(t *testing.T) {
+	r, err := PGPDump(context.New(context.Set(context.UTC, true)), parseFile(t, "../testdata/decrypt/alice-sec.asc", true))
+	if err != nil {
+		t.Fatalf("PGPDump() = \"%+v\", want nil error.", err)
+	}
+	want := `Old: Secret Key Packet(tag 5)(920 bytes)
+	Ver 4 - new
+	Public key creation time - Mon Oct 19 14:52:09 UTC 2026
+	Pub alg - RSA Encrypt or Sign(pub 1)
+	RSA n(2048 bits) - ...
+	RSA e(17 bits) - ...
+	RSA d(2046 bits) - ...
+	RSA p(1024 bits) - ...
+	RSA q(1024 bits) - ...
+	RSA u(1022 bits) - ...
+	Checksum - 42 7c 
+`
+	if str := readAll(t, r); !strings.HasPrefix(str, want) {
+		t.Errorf("PGPDump() = \"%v\", want prefix \"%v\".", str, want)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,292 @@
+package render
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+//categories of redaction
+const (
+	RedactUserID      = "uid"         //User IDs and Signer's User IDs
+	RedactEmail       = "email"       //e-mail addresses in text values
+	RedactNotation    = "notation"    //values of notation data
+	RedactFileName    = "filename"    //file names of literal data
+	RedactKeyID       = "keyid"       //key IDs
+	RedactFingerprint = "fingerprint" //fingerprints and dumps of public-key material
+	RedactAll         = "all"         //all categories
+)
+
+var redactCategories = []string{RedactUserID, RedactEmail, RedactNotation, RedactFileName, RedactKeyID, RedactFingerprint}
+
+//RedactCategories returns names of redaction categories
+func RedactCategories() []string {
+	return append([]string{}, redactCategories...)
+}
+
+//emailPattern is pattern of e-mail address
+var emailPattern = regexp.MustCompile(`[^\s<>@()"]+@[^\s<>@()"]+`)
+
+//redactor class is state of redaction (same value maps to same pseudonym)
+type redactor struct {
+	categories map[string]bool
+	keys       [][]byte                  //fingerprints or key IDs (index+1 is number of key)
+	texts      map[string]map[string]int //numbers of text values by category
+}
+
+//Redact returns copy of result.Info with values replaced by stable pseudonyms (raw octets and dumps of them are dropped; lengths, algorithms and structure are kept)
+func Redact(info *result.Info, categories ...string) (*result.Info, error) {
+	r, err := newRedactor(categories...)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, nil
+	}
+	if r.categories[RedactKeyID] || r.categories[RedactFingerprint] {
+		//key IDs are mapped to same keys as fingerprints including them
+		for _, itm := range info.Packets {
+			r.collectFingerprints(itm)
+		}
+	}
+	res := result.New()
+	for _, itm := range info.Packets {
+		if itm != nil {
+			res.Add(r.item(itm, nil))
+		}
+	}
+	return res, nil
+}
+
+//newRedactor returns redactor instance of categories
+func newRedactor(categories ...string) (*redactor, error) {
+	r := &redactor{categories: map[string]bool{}, texts: map[string]map[string]int{}}
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == RedactAll {
+			for _, cat := range redactCategories {
+				r.categories[cat] = true
+			}
+			continue
+		}
+		ok := false
+		for _, cat := range redactCategories {
+			if c == cat {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, errs.Wrap(ecode.ErrInvalidOption, errs.WithContext("category", c))
+		}
+		r.categories[c] = true
+	}
+	return r, nil
+}
+
+//collectFingerprints registers fingerprints in item and its sub-items
+func (r *redactor) collectFingerprints(item *result.Item) {
+	if item == nil {
+		return
+	}
+	if fpr := dumpedFingerprint(item); len(fpr) > 0 {
+		r.keyNumber(fpr)
+	}
+	for _, itm := range item.Items {
+		r.collectFingerprints(itm)
+	}
+}
+
+//item returns redacted copy of item (parent is item including it)
+func (r *redactor) item(item, parent *result.Item) *result.Item {
+	itm := *item
+	itm.Raw = nil
+	if len(item.Raw) > 0 {
+		//dump of whole packet, sub-packet area or sub-packet (by --debug option) includes original octets of its items
+		itm.Dump = ""
+	}
+	switch {
+	case isUserIDItem(item) && len(item.Value) > 0:
+		if r.categories[RedactUserID] {
+			itm.Value = r.userID(item.Value)
+			itm.Dump = ""
+		} else if r.categories[RedactEmail] {
+			itm.Value = emailPattern.ReplaceAllStringFunc(item.Value, r.email)
+			itm.Dump = ""
+		}
+	case item.Name == "Value" && parent != nil && strings.HasPrefix(parent.Name, "Notation Data"):
+		if r.categories[RedactNotation] {
+			tok := fmt.Sprintf("notation-%d", r.textNumber(RedactNotation, item.Value+"\x00"+item.Dump))
+			if len(item.Value) > 0 {
+				itm.Value = tok
+				itm.Dump = ""
+			} else {
+				itm.Dump = tok
+			}
+		} else if r.categories[RedactEmail] && len(item.Value) > 0 {
+			itm.Value = emailPattern.ReplaceAllStringFunc(item.Value, r.email)
+			itm.Dump = ""
+		}
+	case item.Name == "File name" && len(item.Value) > 0:
+		if r.categories[RedactFileName] {
+			itm.Value = fmt.Sprintf("file-%d", r.textNumber(RedactFileName, item.Value))
+			itm.Dump = ""
+		}
+	case isKeyIDItem(item):
+		if r.categories[RedactKeyID] {
+			if keyid, err := hex.DecodeString(strings.TrimPrefix(item.Value, "0x")); err == nil && len(keyid) == 8 && !isWildcard(keyid) {
+				pseudo := r.pseudoKey(keyid)
+				itm.Value = fmt.Sprintf("0x%x", pseudo)
+				if len(itm.Dump) > 0 {
+					itm.Dump = values.DumpBytes(pseudo, true).String()
+				}
+			}
+		}
+	case item.Name == "Fingerprint":
+		if r.categories[RedactFingerprint] {
+			if fpr := dumpedFingerprint(item); len(fpr) > 0 {
+				itm.Dump = values.DumpBytes(r.pseudoKey(fpr), true).String()
+			}
+		}
+	case parent != nil && isKeyPacket(parent) && strings.HasSuffix(item.Note, " bits"):
+		//public-key material identifies the key
+		if r.categories[RedactFingerprint] {
+			itm.Dump = ""
+		}
+	}
+	itm.Items = nil
+	for _, child := range item.Items {
+		if child != nil {
+			itm.Items = append(itm.Items, r.item(child, item))
+		}
+	}
+	return &itm
+}
+
+//userID returns pseudonym of User ID (e-mail address in it is replaced by pseudonym of e-mail)
+func (r *redactor) userID(uid string) string {
+	s := fmt.Sprintf("uid-%d", r.textNumber(RedactUserID, uid))
+	if email := emailPattern.FindString(uid); len(email) > 0 {
+		s += " <" + r.email(email) + ">"
+	}
+	return s
+}
+
+//keyIDText returns pseudonym of key ID string (e.g. "0x1234567890abcdef"; wildcard and illegal strings are not changed)
+func (r *redactor) keyIDText(s string) string {
+	keyid, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(keyid) != 8 || isWildcard(keyid) {
+		return s
+	}
+	return fmt.Sprintf("0x%x", r.pseudoKey(keyid))
+}
+
+//fingerprintText returns pseudonym of fingerprint string (e.g. "0x0123...cdef"; illegal strings are not changed)
+func (r *redactor) fingerprintText(s string) string {
+	fpr, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(fpr) < 16 {
+		return s
+	}
+	return fmt.Sprintf("0x%x", r.pseudoKey(fpr))
+}
+
+//email returns pseudonym of e-mail address
+func (r *redactor) email(email string) string {
+	return fmt.Sprintf("email-%d@example.invalid", r.textNumber(RedactEmail, strings.ToLower(email)))
+}
+
+//textNumber returns number of text value in category (1 origin)
+func (r *redactor) textNumber(category, s string) int {
+	m, ok := r.texts[category]
+	if !ok {
+		m = map[string]int{}
+		r.texts[category] = m
+	}
+	if n, ok := m[s]; ok {
+		return n
+	}
+	m[s] = len(m) + 1
+	return m[s]
+}
+
+//keyNumber returns number of key by fingerprint or key ID (1 origin; key ID is low 64 bits of v4 fingerprint or high 64 bits of v5 fingerprint)
+func (r *redactor) keyNumber(id []byte) int {
+	for i, k := range r.keys {
+		if bytes.Equal(k, id) {
+			return i + 1
+		}
+		if len(id) == 8 && len(k) > 8 && (bytes.HasSuffix(k, id) || bytes.HasPrefix(k, id)) {
+			return i + 1
+		}
+	}
+	r.keys = append(r.keys, id)
+	return len(r.keys)
+}
+
+//pseudoKey returns pseudonym of fingerprint or key ID with the same length (octets of key number are repeated)
+func (r *redactor) pseudoKey(id []byte) []byte {
+	n := r.keyNumber(id)
+	pattern := []byte{byte(n)}
+	if n > 0xff {
+		pattern = []byte{byte(n >> 8), byte(n)}
+	}
+	b := make([]byte, len(id))
+	for i := range b {
+		b[i] = pattern[i%len(pattern)]
+	}
+	return b
+}
+
+//isUserIDItem returns true if item is User ID or Signer's User ID
+func isUserIDItem(item *result.Item) bool {
+	return item.Name == "User ID" || strings.HasPrefix(item.Name, "Signer's User ID")
+}
+
+//isKeyIDItem returns true if value of item is key ID
+func isKeyIDItem(item *result.Item) bool {
+	return item.Name == "Key ID" || (item.Kind == result.KindSubpacket && item.Code&0x7f == 16)
+}
+
+//isWildcard returns true if key ID is wildcard (anonymous recipient)
+func isWildcard(keyid []byte) bool {
+	for _, b := range keyid {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//dumpedFingerprint returns fingerprint in dump string of "Fingerprint" item (nil if not found)
+func dumpedFingerprint(item *result.Item) []byte {
+	if item.Name != "Fingerprint" || len(item.Dump) == 0 {
+		return nil
+	}
+	fpr, err := hex.DecodeString(strings.Join(strings.Fields(item.Dump), ""))
+	if err != nil || len(fpr) < 16 {
+		return nil
+	}
+	return fpr
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,142 @@
+package render
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//redactTestData returns result.Info with notation data and literal data
+func redactTestData() *result.Info {
+	info := result.New()
+	sig := result.NewItem(result.Name("Signature Packet (tag 2)"), result.Meta(result.KindPacket, 2), result.Raw([]byte{0x04}))
+	area := result.NewItem(result.Name("Hashed Subpacket"), result.Meta(result.KindHashedArea, 0))
+	notation := result.NewItem(result.Name("Notation Data (sub 20)"), result.Meta(result.KindSubpacket, 20))
+	notation.Add(result.NewItem(result.Name("Name"), result.Value("note@example.com")))
+	notation.Add(result.NewItem(result.Name("Value"), result.Value("mail to bob@example.com")))
+	area.Add(notation)
+	signer := result.NewItem(result.Name("Signer's User ID (sub 28)"), result.Value("Bob <bob@example.com>"), result.Meta(result.KindSubpacket, 28))
+	area.Add(signer)
+	sig.Add(area)
+	info.Add(sig)
+	lit := result.NewItem(result.Name("Literal Data Packet (tag 11)"), result.Meta(result.KindPacket, 11))
+	lit.Add(result.NewItem(result.Name("File name"), result.Value("secret-plan.txt")))
+	info.Add(lit)
+	return info
+}
+
+func TestRedact(t *testing.T) {
+	testCases := []struct {
+		categories []string
+		want       string
+	}{
+		{categories: []string{"all"}, want: "Signature Packet (tag 2)\n\tHashed Subpacket\n\t\tNotation Data (sub 20)\n\t\t\tName: note@example.com\n\t\t\tValue: notation-1\n\t\tSigner's User ID (sub 28): uid-1 <email-1@example.invalid>\nLiteral Data Packet (tag 11)\n\tFile name: file-1\n"},
+		{categories: []string{"email"}, want: "Signature Packet (tag 2)\n\tHashed Subpacket\n\t\tNotation Data (sub 20)\n\t\t\tName: note@example.com\n\t\t\tValue: mail to email-1@example.invalid\n\t\tSigner's User ID (sub 28): Bob <email-1@example.invalid>\nLiteral Data Packet (tag 11)\n\tFile name: secret-plan.txt\n"},
+		{categories: []string{"FileName", "keyid"}, want: "Signature Packet (tag 2)\n\tHashed Subpacket\n\t\tNotation Data (sub 20)\n\t\t\tName: note@example.com\n\t\t\tValue: mail to bob@example.com\n\t\tSigner's User ID (sub 28): Bob <bob@example.com>\nLiteral Data Packet (tag 11)\n\tFile name: file-1\n"},
+	}
+	for _, tc := range testCases {
+		info := redactTestData()
+		res, err := Redact(info, tc.categories...)
+		if err != nil {
+			t.Errorf("Redact(%v) = \"%+v\", want nil error.", tc.categories, err)
+			continue
+		}
+		if str := res.String(); str != tc.want {
+			t.Errorf("Redact(%v) = \"%v\", want \"%v\".", tc.categories, str, tc.want)
+		}
+		if res.Packets[0].Raw != nil {
+			t.Errorf("Redact(%v) keeps raw octets.", tc.categories)
+		}
+		if info.Packets[1].Items[0].Value != "secret-plan.txt" || info.Packets[0].Raw == nil {
+			t.Errorf("Redact(%v) changes original result.", tc.categories)
+		}
+	}
+	if _, err := Redact(nil, "foo"); !errors.Is(err, ecode.ErrInvalidOption) {
+		t.Errorf("Redact() = \"%+v\", want \"%+v\".", err, ecode.ErrInvalidOption)
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	res, err := Redact(parseFile(t, "../testdata/diff/alice-2.asc", true), "keyid", "fingerprint", "uid")
+	if err != nil {
+		t.Fatalf("Redact() = \"%+v\", want nil error.", err)
+	}
+	str := res.String()
+	for _, s := range []string{"72d816a3a545c23c", "a5 45 c2 3c", "alice", "Alice"} {
+		if strings.Contains(str, s) {
+			t.Errorf("Redact() = \"%v\", want not to contain \"%v\".", str, s)
+		}
+	}
+	testCases := []struct {
+		s     string
+		count int
+	}{
+		{s: "\t01 01 01 01 01 01 01 01 01 01 01 01 01 01 01 01 01 01 01 01\n", count: 5},
+		{s: "Issuer (sub 16): 0x0101010101010101\n", count: 5},
+		{s: "Issuer (sub 16): 0x0202020202020202\n", count: 1},
+		{s: "User ID: uid-1 <email-1@example.invalid>\n", count: 1},
+		{s: "User ID: uid-2 <email-2@example.invalid>\n", count: 1},
+	}
+	for _, tc := range testCases {
+		if n := strings.Count(str, tc.s); n != tc.count {
+			t.Errorf("Redact() contains \"%v\" %d times, want %d.", tc.s, n, tc.count)
+		}
+	}
+}
+
+func TestRedactDebug(t *testing.T) {
+	file, err := os.Open("../testdata/diff/alice-1.asc")
+	if err != nil {
+		t.Fatalf("os.Open() = \"%+v\", want nil error.", err)
+	}
+	defer file.Close()
+	p, err := parse.New(context.New(context.Set(context.DEBUG, true)), file)
+	if err != nil {
+		t.Fatalf("parse.New() = \"%+v\", want nil error.", err)
+	}
+	info, err := p.Parse()
+	if err != nil {
+		t.Fatalf("Parse() = \"%+v\", want nil error.", err)
+	}
+	if str := info.String(); !strings.Contains(str, "41 6c 69 63 65") || !strings.Contains(str, "f9 05 49 bb") {
+		t.Fatalf("Parse() = \"%v\", want dumps of User ID and fingerprint.", str)
+	}
+	res, err := Redact(info, "all")
+	if err != nil {
+		t.Fatalf("Redact() = \"%+v\", want nil error.", err)
+	}
+	str := res.String()
+	for _, s := range []string{
+		"Alice", "alice", "41 6c 69 63 65", //User ID
+		"f9 05 49 bb", "72 d8 16 a3", "72d816a3", //fingerprint and key ID
+		"40 cd 56 7e", "40 f1 08 62", //public-key material
+	} {
+		if strings.Contains(str, s) {
+			t.Errorf("Redact() = \"%v\", want not to contain \"%v\".", str, s)
+		}
+	}
+	if !strings.Contains(str, "Version: 4 (current)\n\t\t04\n") {
+		t.Errorf("Redact() = \"%v\", want dumps of other items.", str)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
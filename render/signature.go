@@ -0,0 +1,189 @@
+package render
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//subpacket class is sub-packet in parsed packet
+type subpacket struct {
+	typ      int
+	critical bool
+	body     []byte
+	item     *result.Item
+}
+
+//subpacketRange class is range of sub-packet in sub-packet area
+type subpacketRange struct {
+	start int //offset of length octets
+	body  int //offset of sub-packet type octet
+	end   int
+}
+
+//subpacketRanges returns ranges of sub-packets in sub-packet area
+func subpacketRanges(b []byte) []subpacketRange {
+	rngs := []subpacketRange{}
+	for pos := 0; pos < len(b); {
+		start := pos
+		c := int(b[pos])
+		l := c
+		pos++
+		switch {
+		case c >= 255:
+			if pos+4 > len(b) {
+				return rngs
+			}
+			l = int(binary.BigEndian.Uint32(b[pos:]))
+			pos += 4
+		case c >= 192:
+			if pos+1 > len(b) {
+				return rngs
+			}
+			l = (c-192)<<8 + int(b[pos]) + 192
+			pos++
+		}
+		if l == 0 || l > len(b)-pos {
+			return rngs
+		}
+		rngs = append(rngs, subpacketRange{start: start, body: pos, end: pos + l})
+		pos += l
+	}
+	return rngs
+}
+
+//subpacketItems returns sub-packets in area of packet item
+func subpacketItems(item *result.Item, kind result.Kind) []subpacket {
+	subs := []subpacket{}
+	for _, sub := range subpackets(item, kind) {
+		subs = append(subs, subpacket{typ: sub.Code & 0x7f, critical: sub.Code&0x80 != 0, body: sub.Raw, item: sub})
+	}
+	return subs
+}
+
+//embedded returns signature packet embedded in sub-packet (sub 32)
+func (sub subpacket) embedded() *signature {
+	if sub.item == nil {
+		return nil
+	}
+	for _, itm := range sub.item.Items {
+		if itm != nil && itm.Kind == result.KindPacket {
+			return newSignature(itm)
+		}
+	}
+	return nil
+}
+
+//signature class is Signature Packet (tag 2) taken from parsed items
+type signature struct {
+	version  int
+	sigType  int
+	pubAlg   int
+	hashAlg  int
+	md5len   int
+	created  uint32
+	keyID    []byte
+	hashed   []subpacket
+	unhashed []subpacket
+	left     []byte
+	values   []mpiValue
+}
+
+//newSignature returns signature instance of parsed signature packet (nil if not signature packet)
+func newSignature(item *result.Item) *signature {
+	if item == nil || item.Kind != result.KindPacket {
+		return nil
+	}
+	f := newFields(item)
+	v, ok := f.byte("Version")
+	if !ok {
+		return nil
+	}
+	sig := &signature{version: int(v)}
+	c, _ := f.byte("Signiture Type")
+	sig.sigType = int(c)
+	c, _ = f.byte("Public-key Algorithm")
+	sig.pubAlg = int(c)
+	c, _ = f.byte("Hash Algorithm")
+	sig.hashAlg = int(c)
+	switch v {
+	case 2, 3:
+		c, _ = f.byte("Hashed material")
+		sig.md5len = int(c)
+		sig.created, _ = f.uint32("Signature creation time")
+		sig.keyID, _ = f.tail("Key ID", 8)
+	case 4, 5:
+		sig.hashed = subpacketItems(item, result.KindHashedArea)
+		sig.unhashed = subpacketItems(item, result.KindUnhashedArea)
+		for _, sub := range sig.hashed {
+			if sub.typ == 2 && len(sub.body) >= 4 {
+				sig.created = binary.BigEndian.Uint32(sub.body)
+			}
+		}
+		sig.keyID = sig.issuer()
+	default:
+		return sig
+	}
+	sig.left, _ = f.tail("Hash left 2 bytes", 2)
+	sig.values = f.values(f.after("Hash left 2 bytes"))
+	return sig
+}
+
+//issuer returns key ID of issuer
+func (sig *signature) issuer() []byte {
+	subs := append(append([]subpacket{}, sig.hashed...), sig.unhashed...)
+	for _, sub := range subs {
+		if sub.typ == 16 && len(sub.body) >= 8 {
+			return sub.body[:8]
+		}
+	}
+	for _, sub := range subs {
+		if sub.typ == 33 && len(sub.body) >= 21 {
+			switch sub.body[0] {
+			case 4:
+				return sub.body[len(sub.body)-8:]
+			case 5:
+				return sub.body[1:9]
+			}
+		}
+	}
+	return nil
+}
+
+//fingerprint returns fingerprint of public key
+func fingerprint(pub []byte, v byte) []byte {
+	switch v {
+	case 4:
+		h := sha1.New()
+		_, _ = h.Write([]byte{0x99, byte(len(pub) >> 8), byte(len(pub))})
+		_, _ = h.Write(pub)
+		return h.Sum(nil)
+	case 5:
+		h := sha256.New()
+		l := make([]byte, 4)
+		binary.BigEndian.PutUint32(l, uint32(len(pub)))
+		_, _ = h.Write([]byte{0x9a})
+		_, _ = h.Write(l)
+		_, _ = h.Write(pub)
+		return h.Sum(nil)
+	default:
+		return nil
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
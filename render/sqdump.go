@@ -0,0 +1,853 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/values"
+)
+
+var sqTagNames = map[int]string{
+	0:  "Reserved - a packet tag MUST NOT have this value",
+	1:  "Public-Key Encrypted Session Key Packet",
+	2:  "Signature Packet",
+	3:  "Symmetric-Key Encrypted Session Key Packet",
+	4:  "One-Pass Signature Packet",
+	5:  "Secret-Key Packet",
+	6:  "Public-Key Packet",
+	7:  "Secret-Subkey Packet",
+	8:  "Compressed Data Packet",
+	9:  "Symmetrically Encrypted Data Packet",
+	10: "Marker Packet",
+	11: "Literal Data Packet",
+	12: "Trust Packet",
+	13: "User ID Packet",
+	14: "Public-Subkey Packet",
+	17: "User Attribute Packet",
+	18: "Sym. Encrypted and Integrity Protected Data Packet",
+	19: "Modification Detection Code Packet",
+	20: "AEAD Encrypted Data Packet",
+}
+
+var sqPubAlgNames = map[int]string{
+	1:  "RSA",
+	2:  "RSA",
+	3:  "RSA",
+	16: "ElGamal",
+	17: "DSA",
+	18: "ECDH",
+	19: "ECDSA",
+	20: "ElGamal",
+	22: "EdDSA",
+	25: "X25519",
+	26: "X448",
+	27: "Ed25519",
+	28: "Ed448",
+}
+
+var sqSymAlgNames = map[int]string{
+	0:  "Unencrypted",
+	1:  "IDEA",
+	2:  "TripleDES",
+	3:  "CAST5",
+	4:  "Blowfish",
+	7:  "AES-128",
+	8:  "AES-192",
+	9:  "AES-256",
+	10: "Twofish",
+	11: "Camellia-128",
+	12: "Camellia-192",
+	13: "Camellia-256",
+}
+
+var sqSymAlgIDs = map[int]string{
+	0:  "Unencrypted",
+	1:  "IDEA",
+	2:  "TripleDES",
+	3:  "CAST5",
+	4:  "Blowfish",
+	7:  "AES128",
+	8:  "AES192",
+	9:  "AES256",
+	10: "Twofish",
+	11: "Camellia128",
+	12: "Camellia192",
+	13: "Camellia256",
+}
+
+var sqHashAlgNames = map[int]string{
+	1:  "MD5",
+	2:  "SHA1",
+	3:  "RipeMD160",
+	8:  "SHA256",
+	9:  "SHA384",
+	10: "SHA512",
+	11: "SHA224",
+}
+
+var sqCompAlgNames = map[int]string{
+	0: "Uncompressed",
+	1: "ZIP",
+	2: "ZLIB",
+	3: "BZip2",
+}
+
+var sqCompAlgIDs = map[int]string{
+	0: "Uncompressed",
+	1: "Zip",
+	2: "Zlib",
+	3: "BZip2",
+}
+
+var sqAEADAlgNames = map[int]string{
+	1: "EAX",
+	2: "OCB",
+}
+
+var sqSigTypeNames = map[int]string{
+	0x00: "Binary",
+	0x01: "Text",
+	0x02: "Standalone",
+	0x10: "GenericCertification",
+	0x11: "PersonaCertification",
+	0x12: "CasualCertification",
+	0x13: "PositiveCertification",
+	0x16: "AttestationKey",
+	0x18: "SubkeyBinding",
+	0x19: "PrimaryKeyBinding",
+	0x1f: "DirectKey",
+	0x20: "KeyRevocation",
+	0x28: "SubkeyRevocation",
+	0x30: "CertificationRevocation",
+	0x40: "Timestamp",
+	0x50: "Confirmation",
+}
+
+var sqCurveNames = map[string]string{
+	"nistp256":        "NIST curve P-256",
+	"nistp384":        "NIST curve P-384",
+	"nistp521":        "NIST curve P-521",
+	"secp256k1":       "secp256k1",
+	"brainpoolP256r1": "brainpoolP256r1",
+	"brainpoolP384r1": "brainpoolP384r1",
+	"brainpoolP512r1": "brainpoolP512r1",
+	"ed25519":         "Ed25519",
+	"cv25519":         "Curve25519",
+}
+
+var sqKeyFlags = []string{"C", "S", "Et", "Er", "D", "A", "", "G"}
+
+var sqRevReasons = map[int]string{
+	0:  "No reason specified",
+	1:  "Key is superseded",
+	2:  "Key material has been compromised",
+	3:  "Key is retired and no longer used",
+	32: "User ID information is no longer valid",
+}
+
+//sqdumper class is renderer for "sq packet dump" compatible output
+type sqdumper struct {
+	w    *bytes.Buffer
+	mpis bool
+}
+
+//SQDump returns text compatible with "sq packet dump" output
+func SQDump(cxt *context.Context, info *result.Info) (io.Reader, error) {
+	if cxt == nil {
+		cxt = context.New()
+	}
+	d := &sqdumper{w: &bytes.Buffer{}, mpis: cxt.Integer()}
+	if info == nil {
+		return d.w, nil
+	}
+	for _, item := range info.Packets {
+		d.tree("", item)
+	}
+	return d.w, nil
+}
+
+//children returns packets in container packet
+func children(item *result.Item) []*result.Item {
+	items := []*result.Item{}
+	switch item.Code {
+	case 8, 9, 18, 20: //compressed or decrypted data
+	default:
+		return items
+	}
+	for _, itm := range item.Items {
+		if itm != nil && itm.Kind == result.KindPacket && itm.Header != nil {
+			items = append(items, itm)
+		}
+	}
+	return items
+}
+
+//tree outputs packet and packets in container as tree
+func (d *sqdumper) tree(indent string, item *result.Item) {
+	if item == nil || item.Kind != result.KindPacket {
+		return
+	}
+	nodes := children(item)
+	prefix := indent + " " + "   "
+	if len(nodes) > 0 {
+		prefix = indent + "│" + "   "
+	}
+	d.packet(prefix, item)
+	fmt.Fprintln(d.w, strings.TrimRight(prefix, " "))
+	for i, node := range nodes {
+		if i == len(nodes)-1 {
+			fmt.Fprintf(d.w, "%s└── ", indent)
+			d.tree(indent+"    ", node)
+		} else {
+			fmt.Fprintf(d.w, "%s├── ", indent)
+			d.tree(indent+"│   ", node)
+		}
+	}
+}
+
+//packet outputs header and fields of packet
+func (d *sqdumper) packet(i string, item *result.Item) {
+	tag := item.Code
+	name, ok := sqTagNames[tag]
+	switch {
+	case ok:
+	case 60 <= tag && tag <= 63:
+		name = fmt.Sprintf("Private/Experimental Packet %d", tag)
+	default:
+		name = fmt.Sprintf("Unknown Packet %d", tag)
+	}
+	ctb := "old"
+	length := fmt.Sprintf("%d bytes", len(item.Raw))
+	if h := item.Header; h != nil {
+		if h.NewFormat() {
+			ctb = "new"
+		}
+		if h.Partial {
+			if h.NewFormat() {
+				length = "partial length"
+			} else {
+				length = "indeterminate length"
+			}
+		}
+	}
+	fmt.Fprintf(d.w, "%s, %s CTB, %s\n", name, ctb, length)
+	f := newFields(item)
+	switch tag {
+	case 1:
+		d.pubkeyEnc(i, f)
+	case 2:
+		if sig := newSignature(item); sig != nil {
+			d.signature(i, sig)
+		}
+	case 3:
+		d.symkeyEnc(i, f)
+	case 4:
+		v, _ := f.byte("Version")
+		sigclass, _ := f.byte("Signiture Type")
+		hash, _ := f.byte("Hash Algorithm")
+		algo, _ := f.byte("Public-key Algorithm")
+		keyid, _ := f.tail("Key ID", 8)
+		last, ok := onepassLast(f)
+		if !ok {
+			break
+		}
+		fmt.Fprintf(d.w, "%sVersion: %d\n", i, v)
+		fmt.Fprintf(d.w, "%sType: %s\n", i, sqSigType(int(sigclass)))
+		fmt.Fprintf(d.w, "%sPk algo: %s\n", i, sqPubAlg(int(algo)))
+		fmt.Fprintf(d.w, "%sHash algo: %s\n", i, sqAlg(sqHashAlgNames, int(hash), "hash algorithm"))
+		fmt.Fprintf(d.w, "%sIssuer: %s\n", i, keyIDString(keyid))
+		fmt.Fprintf(d.w, "%sLast: %v\n", i, last != 0)
+	case 5, 6, 7, 14:
+		d.key(i, newKeyPacket(item), tag)
+	case 8:
+		algo, _ := f.byte("Compression Algorithm")
+		fmt.Fprintf(d.w, "%sAlgorithm: %s\n", i, sqAlg(sqCompAlgNames, int(algo), "compression algorithm"))
+	case 9:
+		d.decryption(i, item)
+	case 11:
+		d.literalData(i, f)
+	case 12:
+		fmt.Fprintf(d.w, "%sValue: %s\n", i, hexString(item.Raw))
+	case 13:
+		fmt.Fprintf(d.w, "%sValue: %s\n", i, item.Raw)
+	case 17:
+		for _, sub := range subpacketItems(item, result.KindSubpacketArea) {
+			if sub.typ == 1 && len(sub.body) >= 4 && sub.body[3] == 1 {
+				hlen := int(binary.LittleEndian.Uint16(sub.body))
+				if hlen <= len(sub.body) {
+					fmt.Fprintf(d.w, "%sImage: JPEG, %d bytes\n", i, len(sub.body)-hlen)
+					continue
+				}
+			}
+			fmt.Fprintf(d.w, "%sUnknown attribute: %d, %d bytes\n", i, sub.typ, len(sub.body))
+		}
+	case 18:
+		v, _ := seipdVersion(f)
+		fmt.Fprintf(d.w, "%sVersion: %d\n", i, v)
+		d.decryption(i, item)
+	case 19:
+		fmt.Fprintf(d.w, "%sDigest: %s\n", i, hexString(item.Raw))
+	case 20:
+		v, _ := f.byte("Version")
+		cipher, _ := f.byte("Symmetric Algorithm")
+		aead, _ := f.byte("AEAD Algorithm")
+		cb, _ := f.byte("Chunk size")
+		fmt.Fprintf(d.w, "%sVersion: %d\n", i, v)
+		fmt.Fprintf(d.w, "%sSymmetric algo: %s\n", i, sqAlg(sqSymAlgNames, int(cipher), "symmetric algorithm"))
+		fmt.Fprintf(d.w, "%sAEAD: %s\n", i, sqAlg(sqAEADAlgNames, int(aead), "AEAD algorithm"))
+		fmt.Fprintf(d.w, "%sChunk size: %d\n", i, 1<<(uint(cb)+6))
+		d.decryption(i, item)
+	}
+}
+
+//decryption outputs result of decryption in encrypted data packet
+func (d *sqdumper) decryption(i string, item *result.Item) {
+	for _, itm := range item.Items {
+		if itm == nil || itm.Header != nil {
+			continue
+		}
+		for _, sub := range itm.Items {
+			if sub != nil && sub.Name == "Decrypted data" {
+				if len(children(item)) > 0 {
+					fmt.Fprintf(d.w, "%sDecryption successful\n", i)
+				} else {
+					fmt.Fprintf(d.w, "%sDecryption failed: %s\n", i, sub.Value)
+				}
+				return
+			}
+		}
+	}
+	fmt.Fprintf(d.w, "%sNo key to decrypt it\n", i)
+}
+
+//mpi outputs multi-precision integer when --int option
+func (d *sqdumper) mpi(i, name string, data []byte) {
+	if d.mpis {
+		fmt.Fprintf(d.w, "%s%s: %s\n", i, name, hexString(data))
+	}
+}
+
+//pubkeyEnc outputs Public-Key Encrypted Session Key Packet (tag 1)
+func (d *sqdumper) pubkeyEnc(i string, f *fields) {
+	v, _ := f.byte("Version")
+	keyid, _ := f.tail("Key ID", 8)
+	algo, ok := f.byte("Public-key Algorithm")
+	if !ok {
+		return
+	}
+	fmt.Fprintf(d.w, "%sVersion: %d\n", i, v)
+	fmt.Fprintf(d.w, "%sRecipient: %s\n", i, keyIDString(keyid))
+	fmt.Fprintf(d.w, "%sPk algo: %s\n", i, sqPubAlg(int(algo)))
+	if !d.mpis {
+		return
+	}
+	var names []string
+	switch algo {
+	case 1, 2, 3:
+		names = []string{"c"}
+	case 16, 20:
+		names = []string{"e", "c"}
+	case 18:
+		names = []string{"e", "key"}
+	}
+	for n, val := range f.values(f.after("Public-key Algorithm")) {
+		if n < len(names) {
+			d.mpi(i+"  ", names[n], val.data)
+		}
+	}
+}
+
+//signature outputs Signature Packet (tag 2)
+func (d *sqdumper) signature(i string, sig *signature) {
+	fmt.Fprintf(d.w, "%sVersion: %d\n", i, sig.version)
+	fmt.Fprintf(d.w, "%sType: %s\n", i, sqSigType(sig.sigType))
+	fmt.Fprintf(d.w, "%sPk algo: %s\n", i, sqPubAlg(sig.pubAlg))
+	fmt.Fprintf(d.w, "%sHash algo: %s\n", i, sqAlg(sqHashAlgNames, sig.hashAlg, "hash algorithm"))
+	if sig.version < 4 {
+		fmt.Fprintf(d.w, "%sSignature creation time: %s\n", i, sqTime(sig.created))
+		fmt.Fprintf(d.w, "%sIssuer: %s\n", i, keyIDString(sig.keyID))
+	}
+	if len(sig.hashed) > 0 {
+		fmt.Fprintf(d.w, "%sHashed area:\n", i)
+		for _, sub := range sig.hashed {
+			d.subpacket(i+"  ", sub)
+		}
+	}
+	if len(sig.unhashed) > 0 {
+		fmt.Fprintf(d.w, "%sUnhashed area:\n", i)
+		for _, sub := range sig.unhashed {
+			d.subpacket(i+"  ", sub)
+		}
+	}
+	if len(sig.left) < 2 {
+		return
+	}
+	fmt.Fprintf(d.w, "%sDigest prefix: %s\n", i, hexString(sig.left))
+	fmt.Fprintf(d.w, "%sLevel: 0 (signature over data)\n", i)
+	if !d.mpis {
+		return
+	}
+	var names []string
+	switch sig.pubAlg {
+	case 1, 3:
+		names = []string{"s"}
+	case 17, 19, 20, 22:
+		names = []string{"r", "s"}
+	}
+	fmt.Fprintf(d.w, "%s\n%sSignature:\n", strings.TrimRight(i, " "), i)
+	for n, v := range sig.values {
+		if n < len(names) {
+			d.mpi(i+"  ", names[n], v.data)
+		}
+	}
+}
+
+//subpacket outputs sub-packet in signature packet
+func (d *sqdumper) subpacket(i string, sub subpacket) {
+	b := sub.body
+	d.w.WriteString(i)
+	if sub.critical {
+		d.w.WriteString("Critical ")
+	}
+	switch sub.typ {
+	case 2:
+		if len(b) >= 4 {
+			fmt.Fprintf(d.w, "Signature creation time: %s\n", sqTime(binary.BigEndian.Uint32(b)))
+			return
+		}
+	case 3:
+		if len(b) >= 4 {
+			fmt.Fprintf(d.w, "Signature expiration time: %s\n", sqDuration(binary.BigEndian.Uint32(b)))
+			return
+		}
+	case 4:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Exportable certification: %v\n", b[0] != 0)
+			return
+		}
+	case 5:
+		if len(b) >= 2 {
+			fmt.Fprintf(d.w, "Trust signature: level %d trust %d\n", b[0], b[1])
+			return
+		}
+	case 6:
+		fmt.Fprintf(d.w, "Regular expression: %s\n", bytes.TrimRight(b, "\x00"))
+		return
+	case 7:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Revocable: %v\n", b[0] != 0)
+			return
+		}
+	case 9:
+		if len(b) >= 4 {
+			fmt.Fprintf(d.w, "Key expiration time: %s\n", sqDuration(binary.BigEndian.Uint32(b)))
+			return
+		}
+	case 11:
+		fmt.Fprintf(d.w, "Symmetric algo preferences: %s\n", sqAlgList(sqSymAlgIDs, b))
+		return
+	case 12:
+		if len(b) >= 2 {
+			fmt.Fprintf(d.w, "Revocation key: %s/%s\n", hexString(b[2:]), sqPubAlg(int(b[1])))
+			return
+		}
+	case 16:
+		if len(b) >= 8 {
+			fmt.Fprintf(d.w, "Issuer: %s\n", keyIDString(b[:8]))
+			return
+		}
+	case 20:
+		if len(b) >= 8 {
+			n1 := int(binary.BigEndian.Uint16(b[4:]))
+			n2 := int(binary.BigEndian.Uint16(b[6:]))
+			if 8+n1+n2 <= len(b) {
+				value := b[8+n1 : 8+n1+n2]
+				fmt.Fprintf(d.w, "Notation: %s\n", b[8:8+n1])
+				if b[0]&0x80 != 0 {
+					fmt.Fprintf(d.w, "%s  Flags: human readable\n", i)
+					fmt.Fprintf(d.w, "%s  Value: %q\n", i, value)
+				} else {
+					fmt.Fprintf(d.w, "%s  Value: %s\n", i, hexString(value))
+				}
+				return
+			}
+		}
+	case 21:
+		fmt.Fprintf(d.w, "Hash preferences: %s\n", sqAlgList(sqHashAlgNames, b))
+		return
+	case 22:
+		fmt.Fprintf(d.w, "Compression preferences: %s\n", sqAlgList(sqCompAlgIDs, b))
+		return
+	case 23:
+		prefs := []string{}
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			prefs = append(prefs, "no modify")
+		}
+		fmt.Fprintf(d.w, "Keyserver preferences: %s\n", strings.Join(prefs, ", "))
+		return
+	case 24:
+		fmt.Fprintf(d.w, "Preferred keyserver: %s\n", b)
+		return
+	case 25:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Primary User ID: %v\n", b[0] != 0)
+			return
+		}
+	case 26:
+		fmt.Fprintf(d.w, "Policy URI: %s\n", b)
+		return
+	case 27:
+		flags := []string{}
+		for n := 0; n < len(b)*8; n++ {
+			if b[n/8]&(1<<uint(n%8)) == 0 {
+				continue
+			}
+			if n < len(sqKeyFlags) && sqKeyFlags[n] != "" {
+				flags = append(flags, sqKeyFlags[n])
+			} else {
+				flags = append(flags, fmt.Sprintf("#%d", n))
+			}
+		}
+		fmt.Fprintf(d.w, "Key flags: %s\n", strings.Join(flags, ", "))
+		return
+	case 28:
+		fmt.Fprintf(d.w, "Signer's User ID: %s\n", b)
+		return
+	case 29:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Reason for revocation: %s", sqName(sqRevReasons, int(b[0]), fmt.Sprintf("Unknown reason (%d)", b[0])))
+			if len(b) > 1 {
+				fmt.Fprintf(d.w, ", %s", b[1:])
+			}
+			d.w.WriteString("\n")
+			return
+		}
+	case 30:
+		features := []string{}
+		for n := 0; n < len(b)*8; n++ {
+			if b[n/8]&(1<<uint(n%8)) == 0 {
+				continue
+			}
+			switch n {
+			case 0:
+				features = append(features, "MDC")
+			case 1:
+				features = append(features, "AEAD")
+			case 2:
+				features = append(features, "V5")
+			default:
+				features = append(features, fmt.Sprintf("#%d", n))
+			}
+		}
+		fmt.Fprintf(d.w, "Features: %s\n", strings.Join(features, ", "))
+		return
+	case 31:
+		if len(b) >= 2 {
+			fmt.Fprintf(d.w, "Signature target: %s, %s, %s\n", sqPubAlg(int(b[0])), sqAlg(sqHashAlgNames, int(b[1]), "hash algorithm"), hexString(b[2:]))
+			return
+		}
+	case 32:
+		if sig := sub.embedded(); sig != nil {
+			d.w.WriteString("Embedded signature: \n")
+			d.signature(i+"  ", sig)
+			return
+		}
+	case 33:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Issuer Fingerprint: %s\n", hexString(b[1:]))
+			return
+		}
+	case 34:
+		fmt.Fprintf(d.w, "AEAD preferences: %s\n", sqAlgList(sqAEADAlgNames, b))
+		return
+	case 35:
+		if len(b) > 0 {
+			fmt.Fprintf(d.w, "Intended Recipient: %s\n", hexString(b[1:]))
+			return
+		}
+	}
+	fmt.Fprintf(d.w, "Unknown subpacket %d: %d bytes\n", sub.typ, len(b))
+}
+
+//symkeyEnc outputs Symmetric-Key Encrypted Session Key Packet (tag 3)
+func (d *sqdumper) symkeyEnc(i string, f *fields) {
+	v, _ := f.byte("Version")
+	cipher, ok := f.byte("Symmetric Algorithm")
+	if !ok {
+		return
+	}
+	fmt.Fprintf(d.w, "%sVersion: %d\n", i, v)
+	fmt.Fprintf(d.w, "%sCipher: %s\n", i, sqAlg(sqSymAlgNames, int(cipher), "symmetric algorithm"))
+	if aead, ok := f.byte("AEAD Algorithm"); ok {
+		fmt.Fprintf(d.w, "%sAEAD: %s\n", i, sqAlg(sqAEADAlgNames, int(aead), "AEAD algorithm"))
+	}
+	if !d.s2k(i, newS2KSpec(f)) {
+		return
+	}
+	if iv := f.octets(f.find("IV")); len(iv) > 0 {
+		fmt.Fprintf(d.w, "%sIV: %s\n", i, hexString(iv))
+	}
+	esk := f.octets(f.find("Encrypted session key"))
+	if esk == nil {
+		esk = f.octets(f.find("Encrypted session key and authentication tag"))
+	}
+	if len(esk) > 0 {
+		fmt.Fprintf(d.w, "%sESK: %s\n", i, hexString(esk))
+	}
+}
+
+//s2k outputs String-to-Key specifier
+func (d *sqdumper) s2k(i string, s *s2kSpec) bool {
+	if s == nil {
+		return false
+	}
+	hashName := sqAlg(sqHashAlgNames, s.hash, "hash algorithm")
+	switch s.mode {
+	case 0:
+		fmt.Fprintf(d.w, "%sS2K: Simple\n%s  Hash: %s\n", i, i, hashName)
+	case 1, 3:
+		if len(s.salt) == 0 {
+			return false
+		}
+		if s.mode == 1 {
+			fmt.Fprintf(d.w, "%sS2K: Salted\n%s  Hash: %s\n%s  Salt: %s\n", i, i, hashName, i, hexString(s.salt))
+			break
+		}
+		fmt.Fprintf(d.w, "%sS2K: IteratedSalted\n%s  Hash: %s\n%s  Salt: %s\n%s  Hash bytes: %d\n", i, i, hashName, i, hexString(s.salt), i, values.Stretch(s.count).Count())
+	case 101:
+		switch s.gnu {
+		case 1001:
+			fmt.Fprintf(d.w, "%sS2K: GnuPG dummy\n", i)
+		case 1002:
+			fmt.Fprintf(d.w, "%sS2K: GnuPG divert to card\n", i)
+			if len(s.serial) > 0 {
+				fmt.Fprintf(d.w, "%s  Serial number: %s\n", i, hexString(s.serial))
+			}
+		default:
+			fmt.Fprintf(d.w, "%sS2K: Private/Experimental S2K 101\n", i)
+		}
+	default:
+		fmt.Fprintf(d.w, "%sS2K: Unknown S2K %d\n", i, s.mode)
+	}
+	return true
+}
+
+//key outputs Public-Key, Public-Subkey, Secret-Key and Secret-Subkey Packet (tag 5, 6, 7 and 14)
+func (d *sqdumper) key(i string, k *keyPacket, tag int) {
+	if k == nil {
+		return
+	}
+	fmt.Fprintf(d.w, "%sVersion: %d\n", i, k.version)
+	if k.version < 2 || 5 < k.version || len(k.pkeys) == 0 {
+		return
+	}
+	fmt.Fprintf(d.w, "%sCreation time: %s\n", i, sqTime(k.created))
+	fmt.Fprintf(d.w, "%sPk algo: %s\n", i, sqPubAlg(k.pubAlg))
+	size := 0
+	curve := ""
+	if oid := k.curve(); oid != nil {
+		name, bits := curveInfo(oid)
+		size = bits
+		curve = oidString(oid)
+		if s, ok := sqCurveNames[name]; ok {
+			curve = s
+		}
+	} else if !isECC(k.pubAlg) {
+		size = k.pkeys[0].bits
+	}
+	if size > 0 {
+		fmt.Fprintf(d.w, "%sPk size: %d bits\n", i, size)
+	}
+	if fpr := k.fingerprint(); fpr != nil {
+		fmt.Fprintf(d.w, "%sFingerprint: %s\n", i, hexString(fpr))
+	}
+	fmt.Fprintf(d.w, "%sKeyID: %s\n", i, keyIDString(k.keyID()))
+	if d.mpis {
+		fmt.Fprintf(d.w, "%s\n", strings.TrimRight(i, " "))
+		if curve != "" {
+			fmt.Fprintf(d.w, "%sCurve: %s\n", i, curve)
+		}
+		n := 0
+		for _, val := range k.pkeys {
+			if n >= numPubKey(k.pubAlg) {
+				break
+			}
+			if !val.sized {
+				d.mpi(i, sqKeyValueName(k.pubAlg, n), val.data)
+			}
+			n++
+		}
+	}
+	if tag != 5 && tag != 7 || k.secret == nil {
+		return
+	}
+	s := k.secret
+	fmt.Fprintf(d.w, "%s\n%sSecret Key:\n%s\n", strings.TrimRight(i, " "), i, strings.TrimRight(i, " "))
+	i += "  "
+	switch s.usage {
+	case 0:
+		fmt.Fprintf(d.w, "%sUnencrypted\n", i)
+	case 253, 254, 255:
+		fmt.Fprintf(d.w, "%sEncrypted\n", i)
+		if s.usage == 253 {
+			fmt.Fprintf(d.w, "%sAEAD: %s\n", i, sqAlg(sqAEADAlgNames, s.aead, "AEAD algorithm"))
+		}
+		if !d.s2k(i, s.s2k) {
+			return
+		}
+		fmt.Fprintf(d.w, "%sSym. algo: %s\n", i, sqAlg(sqSymAlgNames, s.cipher, "symmetric algorithm"))
+		switch s.usage {
+		case 254:
+			fmt.Fprintf(d.w, "%sChecksum: SHA1\n", i)
+		case 255:
+			fmt.Fprintf(d.w, "%sChecksum: Sum16\n", i)
+		}
+	default:
+		fmt.Fprintf(d.w, "%sEncrypted\n", i)
+		fmt.Fprintf(d.w, "%sS2K: Implicit\n", i)
+		fmt.Fprintf(d.w, "%sSym. algo: %s\n", i, sqAlg(sqSymAlgNames, s.usage, "symmetric algorithm"))
+	}
+}
+
+//sqKeyValueName returns name of public key value
+func sqKeyValueName(algo, n int) string {
+	switch algo {
+	case 1, 2, 3:
+		return []string{"n", "e"}[n]
+	case 16, 20:
+		return []string{"p", "g", "y"}[n]
+	case 17:
+		return []string{"p", "q", "g", "y"}[n]
+	default:
+		return "q"
+	}
+}
+
+//literalData outputs Literal Data Packet (tag 11)
+func (d *sqdumper) literalData(i string, f *fields) {
+	mode, _ := f.byte("Literal data format")
+	name, _ := f.sized("File name")
+	modified, ok := f.uint32("Creation time")
+	if !ok {
+		return
+	}
+	switch mode {
+	case 'b':
+		fmt.Fprintf(d.w, "%sFormat: Binary data\n", i)
+	case 't':
+		fmt.Fprintf(d.w, "%sFormat: Text data\n", i)
+	case 'u':
+		fmt.Fprintf(d.w, "%sFormat: Text data (UTF-8)\n", i)
+	default:
+		fmt.Fprintf(d.w, "%sFormat: Unknown format (%#02x)\n", i, mode)
+	}
+	if len(name) > 0 {
+		fmt.Fprintf(d.w, "%sFilename: %s\n", i, name)
+	}
+	if modified != 0 {
+		fmt.Fprintf(d.w, "%sTimestamp: %s\n", i, sqTime(modified))
+	}
+	if body := f.octets(f.find("Literal data")); len(body) > 0 {
+		const max = 36
+		if len(body) > max {
+			fmt.Fprintf(d.w, "%sContent: %q...\n", i, body[:max])
+		} else {
+			fmt.Fprintf(d.w, "%sContent: %q\n", i, body)
+		}
+	}
+}
+
+//sqName returns name in table or default string
+func sqName(names map[int]string, id int, def string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return def
+}
+
+//sqAlg returns name of algorithm
+func sqAlg(names map[int]string, id int, typ string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	if 100 <= id && id <= 110 {
+		return fmt.Sprintf("Private/Experimental %s %d", typ, id)
+	}
+	return fmt.Sprintf("Unknown %s %d", typ, id)
+}
+
+//sqPubAlg returns name of public key algorithm
+func sqPubAlg(id int) string {
+	return sqAlg(sqPubAlgNames, id, "public key algorithm")
+}
+
+//sqSigType returns name of signature type
+func sqSigType(t int) string {
+	return sqName(sqSigTypeNames, t, fmt.Sprintf("Unknown(%d)", t))
+}
+
+//sqAlgList returns list of algorithm names
+func sqAlgList(names map[int]string, b []byte) string {
+	list := make([]string, 0, len(b))
+	for _, c := range b {
+		list = append(list, sqName(names, int(c), fmt.Sprintf("Unknown(%d)", c)))
+	}
+	return strings.Join(list, ", ")
+}
+
+//sqTime returns date-time string in UTC
+func sqTime(t uint32) string {
+	return time.Unix(int64(t), 0).UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+//sqDuration returns string of time period (e.g. "1year 2months 3days")
+func sqDuration(t uint32) string {
+	if t == 0 {
+		return "0s"
+	}
+	units := []struct {
+		name string
+		secs uint32
+	}{
+		{"year", 31557600},
+		{"month", 2630016},
+		{"day", 86400},
+		{"h", 3600},
+		{"m", 60},
+		{"s", 1},
+	}
+	elms := []string{}
+	for _, u := range units {
+		n := t / u.secs
+		t %= u.secs
+		switch {
+		case n == 0:
+		case len(u.name) > 1 && n > 1:
+			elms = append(elms, fmt.Sprintf("%d%ss", n, u.name))
+		default:
+			elms = append(elms, fmt.Sprintf("%d%s", n, u.name))
+		}
+	}
+	return strings.Join(elms, " ")
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
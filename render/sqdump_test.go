@@ -0,0 +1,154 @@
+package render
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/context"
+)
+
+func TestSQDump(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		content string
+	}{
+		{name: "../testdata/comp-sig.asc", armored: true, content: `Compressed Data Packet, old CTB, indeterminate length
+│   Algorithm: ZIP
+│
+├── One-Pass Signature Packet, old CTB, 13 bytes
+│       Version: 3
+│       Type: Binary
+│       Pk algo: DSA
+│       Hash algo: SHA256
+│       Issuer: B4DA3BAE7E20B81C
+│       Last: true
+│
+├── Literal Data Packet, new CTB, 19 bytes
+│       Format: Binary data
+│       Timestamp: 2017-11-25 06:29:56 UTC
+│       Content: "Hello world\r\n"
+│
+└── Signature Packet, old CTB, 117 bytes
+        Version: 4
+        Type: Binary
+        Pk algo: DSA
+        Hash algo: SHA256
+        Hashed area:
+          Issuer Fingerprint: 1B5202DB4A3EC776F1E0AD18B4DA3BAE7E20B81C
+          Signature creation time: 2017-11-25 06:29:56 UTC
+        Unhashed area:
+          Issuer: B4DA3BAE7E20B81C
+        Digest prefix: 733C
+        Level: 0 (signature over data)
+
+`},
+		{name: "../testdata/from-pgpdump/enc1", armored: false, content: `Marker Packet, old CTB, 3 bytes
+
+Symmetric-Key Encrypted Session Key Packet, new CTB, 4 bytes
+    Version: 4
+    Cipher: CAST5
+    S2K: Simple
+      Hash: MD5
+
+Symmetrically Encrypted Data Packet, new CTB, 56 bytes
+    No key to decrypt it
+
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := SQDump(context.New(), parseFile(t, tc.name, tc.armored))
+		if err != nil {
+			t.Fatalf("SQDump() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("SQDump(%v) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestSQDumpDecrypted(t *testing.T) {
+	testCases := []struct {
+		key     string
+		content string
+	}{
+		{key: "3:79DEBF2265EB299851D31485F7E87DB5", content: `Symmetrically Encrypted Data Packet, old CTB, 41 bytes
+│   Decryption successful
+│
+└── Literal Data Packet, old CTB, 29 bytes
+        Format: Binary data
+        Filename: hello.txt
+`},
+		{key: "3:00DEBF2265EB299851D31485F7E87DB5", content: `Symmetrically Encrypted Data Packet, old CTB, 41 bytes
+    Decryption failed: invalid session key
+
+`},
+	}
+	for _, tc := range testCases {
+		sk, err := context.ParseSessionKey(tc.key)
+		if err != nil {
+			t.Fatalf("ParseSessionKey() = \"%+v\", want nil error.", err)
+		}
+		file, err := os.Open("../testdata/decrypt/sed.asc")
+		if err != nil {
+			t.Fatalf("os.Open() = \"%+v\", want nil error.", err)
+		}
+		p, err := parse.New(context.New(context.Set(context.ARMOR, true), context.WithSessionKey(sk)), file)
+		if err != nil {
+			t.Fatalf("parse.New() = \"%+v\", want nil error.", err)
+		}
+		info, err := p.Parse()
+		file.Close()
+		if err != nil {
+			t.Fatalf("Parse() = \"%+v\", want nil error.", err)
+		}
+		r, err := SQDump(context.New(), info)
+		if err != nil {
+			t.Fatalf("SQDump() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); !strings.Contains(str, tc.content) {
+			t.Errorf("SQDump(%v) = \"%v\", want \"%v\".", tc.key, str, tc.content)
+		}
+	}
+}
+
+func TestSQDumpUnprotectedSecretKey(t *testing.T) {
+	r, err := SQDump(context.New(), parseFile(t, "../testdata/decrypt/alice-sec.asc", true))
+	if err != nil {
+		t.Fatalf("SQDump() = \"%+v\", want nil error.", err)
+	}
+	want := `Secret-Key Packet, old CTB, 920 bytes
+    Version: 4
+    Creation time: 2026-10-19 14:52:09 UTC
+    Pk algo: RSA
+    Pk size: 2048 bits
+    Fingerprint: 39AC4955B238AAB10223932CBE831B3CC1AAEE9B
+    KeyID: BE831B3CC1AAEE9B
+
+    Secret Key:
+
+      Unencrypted
+
+`
+	if str := readAll(t, r); !strings.HasPrefix(str, want) {
+		t.Errorf("SQDump() = \"%v\", want prefix \"%v\".", str, want)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
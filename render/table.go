@@ -0,0 +1,324 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//tableColumns is header row of table
+var tableColumns = []string{"path", "offset", "tag", "name", "length", "version", "algorithm", "keyid", "fingerprint", "created"}
+
+//tabler class is renderer for tabular output
+type tabler struct {
+	w          *csv.Writer
+	subpackets bool
+	redact     []string  //categories of redaction
+	redactor   *redactor //nil if not redacted
+}
+
+//TableOpt is self-referential function for functional options pattern
+type TableOpt func(*tabler)
+
+//WithComma returns function for setting field delimiter (e.g. '\t' for TSV)
+func WithComma(r rune) TableOpt {
+	return func(t *tabler) {
+		t.w.Comma = r
+	}
+}
+
+//WithSubpackets returns function for setting output of sub-packet rows
+func WithSubpackets(flag bool) TableOpt {
+	return func(t *tabler) {
+		t.subpackets = flag
+	}
+}
+
+//WithRedaction returns function for setting categories of redaction (values in keyid, fingerprint and name columns are replaced by stable pseudonyms)
+func WithRedaction(categories ...string) TableOpt {
+	return func(t *tabler) {
+		t.redact = categories
+	}
+}
+
+//Table returns table of packets (CSV format by default) with one row per packet and optionally per sub-packet
+func Table(info *result.Info, opts ...TableOpt) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	t := &tabler{w: csv.NewWriter(buf)}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if len(t.redact) > 0 {
+		r, err := newRedactor(t.redact...)
+		if err != nil {
+			return nil, err
+		}
+		t.redactor = r
+		if info != nil {
+			//key IDs are mapped to same keys as fingerprints including them
+			for _, item := range info.Packets {
+				t.collectFingerprints(item)
+			}
+		}
+	}
+	if err := t.w.Write(tableColumns); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if info != nil {
+		for n, item := range info.Packets {
+			if err := t.packet(item, strconv.Itoa(n)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	t.w.Flush()
+	if err := t.w.Error(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return buf, nil
+}
+
+//packet outputs row of packet and rows of its sub-packets and nested packets
+func (t *tabler) packet(item *result.Item, path string) error {
+	if item == nil {
+		return nil
+	}
+	offset := ""
+	base := int64(-1)
+	if h := item.Header; h != nil {
+		offset = strconv.FormatInt(h.Offset, 10)
+		base = h.Offset + int64(h.HeaderLen)
+	}
+	keyid := itemKeyID(item)
+	if len(keyid) == 0 {
+		keyid = tableField(item, "Key ID").Value
+	}
+	if err := t.w.Write([]string{
+		path,
+		offset,
+		strconv.Itoa(item.Code),
+		t.name(tableName(item)),
+		strconv.Itoa(len(item.Raw)),
+		tableField(item, "Version").Value,
+		tableField(item, "Public-key Algorithm", "Symmetric Algorithm", "Compression Algorithm", "AEAD Algorithm").Value,
+		t.keyID(keyid),
+		t.fingerprint(tableFingerprint(item)),
+		tableTime(item),
+	}); err != nil {
+		return errs.Wrap(err)
+	}
+	n := 0
+	for _, itm := range item.Items {
+		if itm == nil {
+			continue
+		}
+		switch itm.Kind {
+		case result.KindPacket:
+			if err := t.packet(itm, fmt.Sprintf("%s/%d", path, n)); err != nil {
+				return err
+			}
+			n++
+		case result.KindHashedArea, result.KindUnhashedArea, result.KindSubpacketArea:
+			if !t.subpackets {
+				continue
+			}
+			if err := t.area(itm, path+"/"+tableAreaName(itm.Kind), base); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//area outputs rows of sub-packets in sub-packet area (base is offset of data of packet, -1 if unknown)
+func (t *tabler) area(item *result.Item, path string, base int64) error {
+	ranges := subpacketRanges(item.Raw)
+	n := 0
+	for _, sub := range item.Items {
+		if sub == nil || sub.Kind != result.KindSubpacket {
+			continue
+		}
+		offset := ""
+		if base >= 0 && item.Span != nil && n < len(ranges) {
+			offset = strconv.FormatInt(base+item.Span.End-int64(len(item.Raw))+int64(ranges[n].start), 10)
+		}
+		subpath := fmt.Sprintf("%s/%d", path, n)
+		if err := t.w.Write([]string{
+			subpath,
+			offset,
+			strconv.Itoa(sub.Code & 0x7f),
+			t.name(tableName(sub)),
+			strconv.Itoa(len(sub.Raw)),
+			"",
+			"",
+			t.keyID(tableSubpacketKeyID(sub)),
+			t.fingerprint(tableFingerprint(sub)),
+			tableTime(sub),
+		}); err != nil {
+			return errs.Wrap(err)
+		}
+		m := 0
+		for _, itm := range sub.Items {
+			if itm != nil && itm.Kind == result.KindPacket { //embedded signature
+				if err := t.packet(itm, fmt.Sprintf("%s/%d", subpath, m)); err != nil {
+					return err
+				}
+				m++
+			}
+		}
+		n++
+	}
+	return nil
+}
+
+//collectFingerprints registers fingerprints of key packets and issuers in item and its sub-items for redaction
+func (t *tabler) collectFingerprints(item *result.Item) {
+	if item == nil {
+		return
+	}
+	if fpr, err := hex.DecodeString(strings.TrimPrefix(tableFingerprint(item), "0x")); err == nil && len(fpr) >= 16 {
+		t.redactor.keyNumber(fpr)
+	}
+	for _, itm := range item.Items {
+		t.collectFingerprints(itm)
+	}
+}
+
+//name returns value of name column (e-mail addresses are replaced by pseudonyms if redacted)
+func (t *tabler) name(s string) string {
+	if t.redactor == nil || !(t.redactor.categories[RedactUserID] || t.redactor.categories[RedactEmail]) {
+		return s
+	}
+	return emailPattern.ReplaceAllStringFunc(s, t.redactor.email)
+}
+
+//keyID returns value of keyid column (pseudonym if redacted)
+func (t *tabler) keyID(s string) string {
+	if t.redactor == nil || !t.redactor.categories[RedactKeyID] || len(s) == 0 {
+		return s
+	}
+	return t.redactor.keyIDText(s)
+}
+
+//fingerprint returns value of fingerprint column (pseudonym if redacted)
+func (t *tabler) fingerprint(s string) string {
+	if t.redactor == nil || !t.redactor.categories[RedactFingerprint] || len(s) == 0 {
+		return s
+	}
+	return t.redactor.fingerprintText(s)
+}
+
+//codePattern is pattern of tag or sub-packet type in name of item
+var codePattern = regexp.MustCompile(`\s*\((tag|sub) \d+\)$`)
+
+//tableName returns name of packet or sub-packet without its tag
+func tableName(item *result.Item) string {
+	return codePattern.ReplaceAllString(item.Name, "")
+}
+
+//tableAreaName returns name of sub-packet area in path
+func tableAreaName(kind result.Kind) string {
+	switch kind {
+	case result.KindHashedArea:
+		return "hashed"
+	case result.KindUnhashedArea:
+		return "unhashed"
+	default:
+		return "attr"
+	}
+}
+
+//tableField returns first field whose name is one of names (sub-packets and nested packets are not searched)
+func tableField(item *result.Item, names ...string) *result.Item {
+	for _, itm := range item.Items {
+		if itm == nil {
+			continue
+		}
+		for _, name := range names {
+			if itm.Name == name {
+				return itm
+			}
+		}
+		switch itm.Kind {
+		case result.KindPacket, result.KindSubpacket, result.KindHashedArea, result.KindUnhashedArea, result.KindSubpacketArea:
+			continue
+		}
+		if f := tableField(itm, names...); len(f.Name) > 0 {
+			return f
+		}
+	}
+	return &result.Item{}
+}
+
+//tableFingerprint returns fingerprint of key packet, or fingerprint of issuer in signature packet or sub-packet
+func tableFingerprint(item *result.Item) string {
+	if fp := itemFingerprint(item); len(fp) > 0 {
+		return fp
+	}
+	subs := []*result.Item{item}
+	if item.Kind == result.KindPacket && item.Code == 2 {
+		subs = append(subpackets(item, result.KindHashedArea), subpackets(item, result.KindUnhashedArea)...)
+	}
+	for _, sub := range subs {
+		if sub.Kind == result.KindSubpacket && sub.Code&0x7f == 33 && len(sub.Raw) > 1 {
+			return fmt.Sprintf("0x%x", sub.Raw[1:])
+		}
+	}
+	return ""
+}
+
+//tableSubpacketKeyID returns key ID in sub-packet (issuer)
+func tableSubpacketKeyID(sub *result.Item) string {
+	if sub.Code&0x7f == 16 {
+		return sub.Value
+	}
+	return ""
+}
+
+//tableTime returns creation time of packet or sub-packet
+func tableTime(item *result.Item) string {
+	var t time.Time
+	switch {
+	case item.Kind == result.KindSubpacket:
+		if item.Code&0x7f == 2 {
+			t = parseTime(item)
+		}
+	case item.Code == 2:
+		t = parseTime(tableField(item, "Signature creation time"))
+		for _, sub := range subpackets(item, result.KindHashedArea) {
+			if t.IsZero() && sub.Code&0x7f == 2 {
+				t = parseTime(sub)
+			}
+		}
+	default:
+		t = parseTime(tableField(item, "Public key creation time", "Creation time"))
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
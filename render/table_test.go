@@ -0,0 +1,87 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		opts    []TableOpt
+		content string
+	}{
+		{name: "../testdata/eccsig.asc", armored: true, opts: []TableOpt{}, content: `path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created
+0,0,2,Signature Packet,94,4,ECDSA public key algorithm (pub 19),0x31fbfda95fbbfa18,,2015-01-24T02:52:15Z
+`},
+		{name: "../testdata/eccsig.asc", armored: true, opts: []TableOpt{WithComma('\t'), WithSubpackets(true)}, content: "path\toffset\ttag\tname\tlength\tversion\talgorithm\tkeyid\tfingerprint\tcreated\n" +
+			"0\t0\t2\tSignature Packet\t94\t4\tECDSA public key algorithm (pub 19)\t0x31fbfda95fbbfa18\t\t2015-01-24T02:52:15Z\n" +
+			"0/hashed/0\t8\t2\tSignature Creation Time\t4\t\t\t\t\t2015-01-24T02:52:15Z\n" +
+			"0/unhashed/0\t16\t16\tIssuer\t8\t\t\t0x31fbfda95fbbfa18\t\t\n"},
+		{name: "../testdata/from-pgpdump/sig3", armored: false, opts: []TableOpt{}, content: `path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created
+0,0,10,Marker Packet (Obsolete Literal Packet),3,,,,,
+1,5,4,One-Pass Signature Packet,13,3,DSA (Digital Signature Algorithm) (pub 17),0xa79778e247b63037,,
+2,20,2,Signature Packet,63,3,DSA (Digital Signature Algorithm) (pub 17),0xa79778e247b63037,,1998-11-27T15:36:59Z
+`},
+		{name: "../testdata/eccpub.asc", armored: true, opts: []TableOpt{}, content: `path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created
+0,0,6,Public-Key Packet,82,4,ECDSA public key algorithm (pub 19),0x31fbfda95fbbfa18,0x8b2019d433a7729e5bfe577931fbfda95fbbfa18,2015-01-24T02:21:51Z
+1,84,13,User ID Packet,35,,,,,
+2,121,2,Signature Packet,127,4,ECDSA public key algorithm (pub 19),0x31fbfda95fbbfa18,,2015-01-24T02:21:51Z
+3,250,14,Public-Subkey Packet,86,4,ECDH public key algorithm (pub 18),0xee066bfe252c4d79,0x8f2fd10a4d10bf691d1d8086ee066bfe252c4d79,2015-01-24T02:21:51Z
+4,338,2,Signature Packet,103,4,ECDSA public key algorithm (pub 19),0x31fbfda95fbbfa18,,2015-01-24T02:21:51Z
+`},
+		{name: "../testdata/eccpub.asc", armored: true, opts: []TableOpt{WithRedaction(RedactAll)}, content: `path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created
+0,0,6,Public-Key Packet,82,4,ECDSA public key algorithm (pub 19),0x0101010101010101,0x0101010101010101010101010101010101010101,2015-01-24T02:21:51Z
+1,84,13,User ID Packet,35,,,,,
+2,121,2,Signature Packet,127,4,ECDSA public key algorithm (pub 19),0x0101010101010101,,2015-01-24T02:21:51Z
+3,250,14,Public-Subkey Packet,86,4,ECDH public key algorithm (pub 18),0x0202020202020202,0x0202020202020202020202020202020202020202,2015-01-24T02:21:51Z
+4,338,2,Signature Packet,103,4,ECDSA public key algorithm (pub 19),0x0101010101010101,,2015-01-24T02:21:51Z
+`},
+		{name: "../testdata/eccsig.asc", armored: true, opts: []TableOpt{WithSubpackets(true), WithRedaction(RedactKeyID)}, content: `path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created
+0,0,2,Signature Packet,94,4,ECDSA public key algorithm (pub 19),0x0101010101010101,,2015-01-24T02:52:15Z
+0/hashed/0,8,2,Signature Creation Time,4,,,,,2015-01-24T02:52:15Z
+0/unhashed/0,16,16,Issuer,8,,,0x0101010101010101,,
+`},
+	}
+
+	for _, tc := range testCases {
+		r, err := Table(parseFile(t, tc.name, tc.armored), tc.opts...)
+		if err != nil {
+			t.Fatalf("Table() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("Table(%s) = \"%v\", want \"%v\".", tc.name, str, tc.content)
+		}
+	}
+}
+
+func TestTableRedactionError(t *testing.T) {
+	if _, err := Table(nil, WithRedaction("foo")); err == nil {
+		t.Error("Table() = nil error, want error.")
+	}
+}
+
+func TestTableNil(t *testing.T) {
+	r, err := Table(nil)
+	if err != nil {
+		t.Fatalf("Table() = \"%+v\", want nil error.", err)
+	}
+	if str, want := readAll(t, r), "path,offset,tag,name,length,version,algorithm,keyid,fingerprint,created\n"; str != want {
+		t.Errorf("Table(nil) = \"%v\", want \"%v\".", str, want)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,307 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//templateFuncs is table of helper functions in template
+var templateFuncs = template.FuncMap{
+	"tag":         tagPackets,
+	"items":       findItems,
+	"item":        findItem,
+	"value":       findValue,
+	"note":        findNote,
+	"values":      itemValues,
+	"sub":         findSubpackets,
+	"keys":        keyPackets,
+	"signatures":  keySignatures,
+	"keyid":       itemKeyID,
+	"fingerprint": itemFingerprint,
+	"parseTime":   parseTime,
+	"formatTime":  formatTime,
+	"unixTime":    unixTime,
+	"hasPrefix":   strings.HasPrefix,
+	"hasSuffix":   strings.HasSuffix,
+	"contains":    strings.Contains,
+	"trimSpace":   strings.TrimSpace,
+	"join":        strings.Join,
+}
+
+//NewTemplate returns template for output of packets
+func NewTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("gpgpdump").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, errs.Wrap(ecode.ErrTemplate, errs.WithCause(err))
+	}
+	return tmpl, nil
+}
+
+//Template returns output of packets by template (dot is result.Info)
+func Template(info *result.Info, tmpl *template.Template) (io.Reader, error) {
+	if info == nil {
+		info = result.New()
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, info); err != nil {
+		return nil, errs.Wrap(ecode.ErrTemplate, errs.WithCause(err))
+	}
+	return buf, nil
+}
+
+//startItems returns items to start walking (x is *result.Info, *result.Item, or []*result.Item)
+func startItems(x interface{}) []*result.Item {
+	switch v := x.(type) {
+	case *result.Info:
+		if v == nil {
+			return nil
+		}
+		return v.Packets
+	case *result.Item:
+		if v == nil {
+			return nil
+		}
+		return v.Items
+	case []*result.Item:
+		items := []*result.Item{}
+		for _, itm := range v {
+			if itm != nil {
+				items = append(items, itm.Items...)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+//walkItems calls fn for each item in depth-first order
+func walkItems(items []*result.Item, fn func(*result.Item)) {
+	for _, itm := range items {
+		if itm == nil {
+			continue
+		}
+		fn(itm)
+		walkItems(itm.Items, fn)
+	}
+}
+
+//walkPackets calls fn for each packet including packets in container (compressed data packet etc.)
+func walkPackets(items []*result.Item, fn func(*result.Item)) {
+	for _, itm := range items {
+		if itm == nil || itm.Kind != result.KindPacket {
+			continue
+		}
+		fn(itm)
+		walkPackets(itm.Items, fn)
+	}
+}
+
+//tagPackets returns packets with tag (e.g. {{range tag 2 .}})
+func tagPackets(tag int, x interface{}) []*result.Item {
+	packets := []*result.Item{}
+	walkPackets(startItems(x), func(itm *result.Item) {
+		if itm.Code == tag {
+			packets = append(packets, itm)
+		}
+	})
+	return packets
+}
+
+//keyPackets returns key packets (tag 5, 6, 7 and 14)
+func keyPackets(x interface{}) []*result.Item {
+	packets := []*result.Item{}
+	walkPackets(startItems(x), func(itm *result.Item) {
+		if isKeyPacket(itm) {
+			packets = append(packets, itm)
+		}
+	})
+	return packets
+}
+
+//keySignatures returns signature packets following key packet (until next key packet)
+func keySignatures(key *result.Item, x interface{}) []*result.Item {
+	sigs := []*result.Item{}
+	found := false
+	walkPackets(startItems(x), func(itm *result.Item) {
+		switch {
+		case itm == key:
+			found = true
+		case isKeyPacket(itm):
+			found = false
+		case found && itm.Code == 2:
+			sigs = append(sigs, itm)
+		}
+	})
+	return sigs
+}
+
+func isKeyPacket(item *result.Item) bool {
+	switch item.Code {
+	case 5, 6, 7, 14:
+		return item.Kind == result.KindPacket
+	}
+	return false
+}
+
+//findItems returns items whose name begins with name (e.g. {{range items "Hash Algorithm" .}})
+func findItems(name string, x interface{}) []*result.Item {
+	items := []*result.Item{}
+	walkItems(startItems(x), func(itm *result.Item) {
+		if strings.HasPrefix(itm.Name, name) {
+			items = append(items, itm)
+		}
+	})
+	return items
+}
+
+//findItem returns first item whose name begins with name (nil if not found)
+func findItem(name string, x interface{}) *result.Item {
+	if items := findItems(name, x); len(items) > 0 {
+		return items[0]
+	}
+	return nil
+}
+
+//findValue returns value of first item whose name begins with name
+func findValue(name string, x interface{}) string {
+	if itm := findItem(name, x); itm != nil {
+		return itm.Value
+	}
+	return ""
+}
+
+//findNote returns note of first item whose name begins with name
+func findNote(name string, x interface{}) string {
+	if itm := findItem(name, x); itm != nil {
+		return itm.Note
+	}
+	return ""
+}
+
+//itemValues returns values of items whose name begins with name
+func itemValues(name string, x interface{}) []string {
+	values := []string{}
+	for _, itm := range findItems(name, x) {
+		values = append(values, itm.Value)
+	}
+	return values
+}
+
+//findSubpackets returns sub-packets with type in signature packet (e.g. {{range sub 9 .}})
+func findSubpackets(typ int, x interface{}) []*result.Item {
+	subs := []*result.Item{}
+	walkItems(startItems(x), func(itm *result.Item) {
+		if itm.Kind == result.KindSubpacket && itm.Code&0x7f == typ {
+			subs = append(subs, itm)
+		}
+	})
+	return subs
+}
+
+//itemKeyID returns key ID of key packet, or key ID of issuer in signature packet
+func itemKeyID(item *result.Item) string {
+	if item == nil {
+		return ""
+	}
+	var keyid []byte
+	switch {
+	case isKeyPacket(item):
+		if k := newKeyPacket(item); k != nil {
+			keyid = k.keyID()
+		}
+	case item.Code == 2 && item.Kind == result.KindPacket:
+		if sig := newSignature(item); sig != nil {
+			keyid = sig.keyID
+		}
+	}
+	if len(keyid) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("0x%x", keyid)
+}
+
+//itemFingerprint returns fingerprint of key packet
+func itemFingerprint(item *result.Item) string {
+	if item == nil || !isKeyPacket(item) {
+		return ""
+	}
+	k := newKeyPacket(item)
+	if k == nil {
+		return ""
+	}
+	fp := k.fingerprint()
+	if len(fp) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("0x%x", fp)
+}
+
+//timePattern is pattern of time string (e.g. "2015-01-31T02:21:51Z")
+var timePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})`)
+
+//parseTime returns time in string or item (value and note of item are searched; zero time if not found)
+func parseTime(x interface{}) time.Time {
+	switch v := x.(type) {
+	case string:
+		s := timePattern.FindString(v)
+		if len(s) == 0 {
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}
+		}
+		return t
+	case *result.Item:
+		if v == nil {
+			return time.Time{}
+		}
+		if t := parseTime(v.Value); !t.IsZero() {
+			return t
+		}
+		return parseTime(v.Note)
+	}
+	return time.Time{}
+}
+
+//formatTime returns time in string or item formatted by layout (empty string if not found)
+func formatTime(layout string, x interface{}) string {
+	t := parseTime(x)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+//unixTime returns time in string or item as UNIX time (0 if not found)
+func unixTime(x interface{}) int64 {
+	t := parseTime(x)
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/ecode"
+)
+
+func TestTemplate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		armored bool
+		text    string
+		content string
+	}{
+		{name: "../testdata/eccpub.asc", armored: true, text: `{{range keys .}}{{keyid .}},{{value "Public-key Algorithm" .}},{{formatTime "2006-01-02" (item "Public key creation time" .)}},{{formatTime "2006-01-02" (item "Key Expiration Time" (signatures . $))}}
+{{end}}`, content: `0x31fbfda95fbbfa18,ECDSA public key algorithm (pub 19),2015-01-24,2015-01-31
+0xee066bfe252c4d79,ECDH public key algorithm (pub 18),2015-01-24,2015-01-31
+`},
+		{name: "../testdata/eccpub.asc", armored: true, text: `{{with index (keys .) 0}}{{fingerprint .}}{{end}}`, content: "0x8b2019d433a7729e5bfe577931fbfda95fbbfa18"},
+		{name: "../testdata/eccpub.asc", armored: true, text: `{{range tag 13 .}}{{value "User ID" .}}{{end}}`, content: "John Doe (forECC) <john@examle.com>"},
+		{name: "../testdata/eccpub.asc", armored: true, text: `{{join (values "Hash Algorithm" (sub 21 (index (tag 2 .) 0))) ", "}}`, content: "SHA2-256 (hash 8), SHA2-384 (hash 9), SHA2-512 (hash 10), SHA2-224 (hash 11), SHA-1 (hash 2)"},
+		{name: "../testdata/eccsig.asc", armored: true, text: `{{range tag 2 .}}{{keyid .}} {{unixTime (item "Signature Creation Time" .)}}{{end}}`, content: "0x31fbfda95fbbfa18 1422067935"},
+		{name: "../testdata/eccsig.asc", armored: true, text: `{{len (tag 6 .)}} {{value "Unknown" .}}{{with item "Unknown" .}}found{{end}}`, content: "0 "},
+	}
+
+	for _, tc := range testCases {
+		tmpl, err := NewTemplate(tc.text)
+		if err != nil {
+			t.Fatalf("NewTemplate() = \"%+v\", want nil error.", err)
+		}
+		r, err := Template(parseFile(t, tc.name, tc.armored), tmpl)
+		if err != nil {
+			t.Fatalf("Template() = \"%+v\", want nil error.", err)
+		}
+		if str := readAll(t, r); str != tc.content {
+			t.Errorf("Template(%s) = \"%v\", want \"%v\".", tc.text, str, tc.content)
+		}
+	}
+}
+
+func TestTemplateErr(t *testing.T) {
+	if _, err := NewTemplate("{{range tag 2 .}"); !errors.Is(err, ecode.ErrTemplate) {
+		t.Errorf("NewTemplate() = \"%+v\", want \"%+v\".", err, ecode.ErrTemplate)
+	}
+	tmpl, err := NewTemplate(`{{index (keys .) 0}}`)
+	if err != nil {
+		t.Fatalf("NewTemplate() = \"%+v\", want nil error.", err)
+	}
+	if _, err := Template(parseFile(t, "../testdata/eccsig.asc", true), tmpl); !errors.Is(err, ecode.ErrTemplate) {
+		t.Errorf("Template() = \"%+v\", want \"%+v\".", err, ecode.ErrTemplate)
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,188 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/gpgpdump/parse/result"
+)
+
+//tree-branch glyphs
+const (
+	treeBranch = "├── "
+	treeLast   = "└── "
+	treeLine   = "│   "
+	treeSpace  = "    "
+)
+
+//texter class is renderer for text output
+type texter struct {
+	w      *bytes.Buffer
+	theme  *Theme
+	tree   bool
+	indent string
+
+	footnotes []string       //footnotes of current packet (by explain mode)
+	numbers   map[string]int //numbers of footnotes in current packet
+	count     int            //number of last footnote
+}
+
+//TextOpt is self-referential function for functional options pattern
+type TextOpt func(*texter)
+
+//WithTheme returns function for setting color theme (nil is monochrome)
+func WithTheme(t *Theme) TextOpt {
+	return func(d *texter) {
+		d.theme = t
+	}
+}
+
+//WithTree returns function for setting tree-branch glyphs instead of indent
+func WithTree(flag bool) TextOpt {
+	return func(d *texter) {
+		d.tree = flag
+	}
+}
+
+//WithIndent returns function for setting indent string
+func WithIndent(indent string) TextOpt {
+	return func(d *texter) {
+		d.indent = indent
+	}
+}
+
+//Text returns text output of packets (colorized by theme and/or drawn as tree)
+func Text(info *result.Info, opts ...TextOpt) (io.Reader, error) {
+	d := &texter{w: &bytes.Buffer{}, indent: "\t"}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if info == nil {
+		return d.w, nil
+	}
+	for _, item := range info.Packets {
+		if d.tree {
+			d.branch(item, "", "")
+		} else {
+			d.indented(item, 0)
+		}
+		d.flushFootnotes()
+	}
+	return d.w, nil
+}
+
+//footnote returns number of footnote for description and reference of item (same text in packet has same number)
+func (d *texter) footnote(item *result.Item) int {
+	text := item.Description
+	if len(item.Reference) > 0 {
+		text += " (" + item.Reference + ")"
+	}
+	if n, ok := d.numbers[text]; ok {
+		return n
+	}
+	if d.numbers == nil {
+		d.numbers = map[string]int{}
+	}
+	d.count++
+	d.numbers[text] = d.count
+	d.footnotes = append(d.footnotes, fmt.Sprintf("[%d] %s", d.count, text))
+	return d.count
+}
+
+//flushFootnotes outputs footnotes of current packet
+func (d *texter) flushFootnotes() {
+	for _, s := range d.footnotes {
+		d.w.WriteString(d.indent)
+		d.w.WriteString(d.theme.paint(d.theme.dumpColor(), s))
+		d.w.WriteString("\n")
+	}
+	d.footnotes = nil
+	d.numbers = nil
+}
+
+//indented outputs item with indent
+func (d *texter) indented(item *result.Item, lvl int) {
+	if item == nil {
+		return
+	}
+	d.w.WriteString(strings.Repeat(d.indent, lvl))
+	d.line(item)
+	if len(item.Dump) > 0 {
+		d.w.WriteString(strings.Repeat(d.indent, lvl+1))
+		d.w.WriteString(d.theme.paint(d.theme.dumpColor(), item.Dump))
+		d.w.WriteString("\n")
+	}
+	for _, itm := range item.Items {
+		d.indented(itm, lvl+1)
+	}
+}
+
+//branch outputs item with tree-branch glyphs (head is glyphs of item, prefix is glyphs of its sub-items)
+func (d *texter) branch(item *result.Item, head, prefix string) {
+	if item == nil {
+		return
+	}
+	d.w.WriteString(d.theme.paint(d.theme.treeColor(), head))
+	d.line(item)
+	items := []*result.Item{}
+	for _, itm := range item.Items {
+		if itm != nil {
+			items = append(items, itm)
+		}
+	}
+	if len(item.Dump) > 0 {
+		glyph := treeSpace
+		if len(items) > 0 {
+			glyph = treeLine
+		}
+		d.w.WriteString(d.theme.paint(d.theme.treeColor(), prefix+glyph))
+		d.w.WriteString(d.theme.paint(d.theme.dumpColor(), item.Dump))
+		d.w.WriteString("\n")
+	}
+	for n, itm := range items {
+		if n == len(items)-1 {
+			d.branch(itm, prefix+treeLast, prefix+treeSpace)
+		} else {
+			d.branch(itm, prefix+treeBranch, prefix+treeLine)
+		}
+	}
+}
+
+//line outputs name, value, and note of item
+func (d *texter) line(item *result.Item) {
+	name := d.theme.nameColor()
+	if item.Kind == result.KindPacket {
+		name = d.theme.packetColor()
+	}
+	d.w.WriteString(d.theme.paint(name, item.Name))
+	if len(item.Value) > 0 {
+		d.w.WriteString(": ")
+		d.w.WriteString(d.theme.paint(d.theme.valueColor(item.Value), item.Value))
+	}
+	if len(item.Note) > 0 {
+		d.w.WriteString(" ")
+		d.w.WriteString(d.theme.paint(d.theme.noteColor(item.Note), "("+item.Note+")"))
+	}
+	if len(item.Description) > 0 {
+		d.w.WriteString(" ")
+		d.w.WriteString(d.theme.paint(d.theme.dumpColor(), fmt.Sprintf("[%d]", d.footnote(item))))
+	}
+	d.w.WriteString("\n")
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextPlain(t *testing.T) {
+	for _, name := range []string{"../testdata/eccsig.asc", "../testdata/eccpub.asc"} {
+		info := parseFile(t, name, true)
+		r, err := Text(info)
+		if err != nil {
+			t.Fatalf("Text() = \"%+v\", want nil error.", err)
+		}
+		if str, want := readAll(t, r), info.ToString("\t").String(); str != want {
+			t.Errorf("Text(%s) = \"%v\", want \"%v\".", name, str, want)
+		}
+	}
+}
+
+func TestTextTree(t *testing.T) {
+	want := `Signature Packet (tag 2) (94 bytes)
+├── Version: 4 (current)
+├── Signiture Type: Signature of a canonical text document (0x01)
+├── Public-key Algorithm: ECDSA public key algorithm (pub 19)
+├── Hash Algorithm: SHA2-256 (hash 8)
+├── Hashed Subpacket (6 bytes)
+│   └── Signature Creation Time (sub 2): 2015-01-24T02:52:15Z
+├── Unhashed Subpacket (10 bytes)
+│   └── Issuer (sub 16): 0x31fbfda95fbbfa18
+├── Hash left 2 bytes
+│       36 1f
+├── ECDSA value r (256 bits)
+└── ECDSA value s (252 bits)
+`
+	r, err := Text(parseFile(t, "../testdata/eccsig.asc", true), WithTree(true))
+	if err != nil {
+		t.Fatalf("Text() = \"%+v\", want nil error.", err)
+	}
+	if str := readAll(t, r); str != want {
+		t.Errorf("Text(tree) = \"%v\", want \"%v\".", str, want)
+	}
+}
+
+func TestTextColor(t *testing.T) {
+	theme, ok := GetTheme("default")
+	if !ok {
+		t.Fatal("GetTheme(default) = false, want true.")
+	}
+	r, err := Text(parseFile(t, "../testdata/from-pgpdump/enc1", false), WithTheme(theme))
+	if err != nil {
+		t.Fatalf("Text() = \"%+v\", want nil error.", err)
+	}
+	str := readAll(t, r)
+	testCases := []string{
+		"\x1b[1;36mSymmetric-Key Encrypted Session Key Packet (tag 3)\x1b[0m \x1b[2m(4 bytes)\x1b[0m\n",
+		"\tVersion: \x1b[32m4\x1b[0m \x1b[2m(current)\x1b[0m\n",
+		"\tSymmetric Algorithm: \x1b[1;31mCAST5 (128 bit key, as per) (sym 3)\x1b[0m\n", //deprecated
+		"\t\tHash Algorithm: \x1b[1;31mMD5 (hash 1)\x1b[0m\n",                           //deprecated
+	}
+	for _, want := range testCases {
+		if !strings.Contains(str, want) {
+			t.Errorf("Text(color) = \"%v\", want to contain \"%v\".", str, want)
+		}
+	}
+}
+
+func TestValueColor(t *testing.T) {
+	theme, _ := GetTheme("default")
+	testCases := []struct {
+		value string
+		want  string
+	}{
+		{value: "SHA2-256 (hash 8)", want: theme.Algorithm},
+		{value: "SHA-1 (hash 2)", want: theme.Warning},
+		{value: "Unknown (pub 99)", want: theme.Warning},
+		{value: "Reserved (formerly Elgamal Encrypt or Sign) (pub 20)", want: theme.Warning},
+		{value: "0x31fbfda95fbbfa18", want: theme.Value},
+	}
+	for _, tc := range testCases {
+		if c := theme.valueColor(tc.value); c != tc.want {
+			t.Errorf("Theme.valueColor(%v) = \"%v\", want \"%v\".", tc.value, c, tc.want)
+		}
+	}
+	var mono *Theme
+	if c := mono.valueColor("SHA-1 (hash 2)"); c != "" {
+		t.Errorf("Theme.valueColor() = \"%v\", want \"%v\".", c, "")
+	}
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
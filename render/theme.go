@@ -0,0 +1,181 @@
+package render
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Theme class is color theme for text output (values are SGR parameters of ANSI escape sequence)
+type Theme struct {
+	Packet    string //name of packet
+	Name      string //name of item
+	Value     string //value of item
+	Note      string //note of item
+	Dump      string //raw data
+	Algorithm string //name of algorithm
+	Warning   string //unknown or reserved values, deprecated algorithms
+	Tree      string //tree-branch glyphs
+}
+
+var themes = map[string]*Theme{
+	"default": {
+		Packet:    "1;36",
+		Value:     "32",
+		Note:      "2",
+		Dump:      "90",
+		Algorithm: "33",
+		Warning:   "1;31",
+		Tree:      "90",
+	},
+	"light": {
+		Packet:    "1;34",
+		Value:     "32",
+		Note:      "90",
+		Dump:      "37",
+		Algorithm: "35",
+		Warning:   "1;31",
+		Tree:      "37",
+	},
+	"mono": {
+		Packet:  "1",
+		Note:    "2",
+		Dump:    "2",
+		Warning: "7",
+	},
+}
+
+//GetTheme returns color theme by name
+func GetTheme(name string) (*Theme, bool) {
+	t, ok := themes[strings.ToLower(name)]
+	return t, ok
+}
+
+//ThemeNames returns sorted names of color themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//paint returns string decorated by SGR parameters
+func (t *Theme) paint(sgr, s string) string {
+	if t == nil || len(sgr) == 0 || len(s) == 0 {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+//packetColor returns SGR parameters for name of packet
+func (t *Theme) packetColor() string {
+	if t == nil {
+		return ""
+	}
+	return t.Packet
+}
+
+//nameColor returns SGR parameters for name of item
+func (t *Theme) nameColor() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+//valueColor returns SGR parameters for value of item (deprecated algorithms and unknown values are warned)
+func (t *Theme) valueColor(value string) string {
+	switch {
+	case t == nil:
+		return ""
+	case isDeprecated(value), isWarning(value):
+		return t.Warning
+	case isAlgorithm(value):
+		return t.Algorithm
+	}
+	return t.Value
+}
+
+//noteColor returns SGR parameters for note of item
+func (t *Theme) noteColor(note string) string {
+	switch {
+	case t == nil:
+		return ""
+	case isWarning(note):
+		return t.Warning
+	}
+	return t.Note
+}
+
+//dumpColor returns SGR parameters for raw data
+func (t *Theme) dumpColor() string {
+	if t == nil {
+		return ""
+	}
+	return t.Dump
+}
+
+//treeColor returns SGR parameters for tree-branch glyphs
+func (t *Theme) treeColor() string {
+	if t == nil {
+		return ""
+	}
+	return t.Tree
+}
+
+//algIDPattern is pattern of algorithm ID in value of item (e.g. "SHA-1 (hash 2)")
+var algIDPattern = regexp.MustCompile(`\((pub|sym|hash|comp|aead|s2k) (\d+)\)$`)
+
+//deprecatedAlgs is table of deprecated algorithms
+var deprecatedAlgs = map[string]map[int]bool{
+	"pub":  {2: true, 3: true, 16: true, 20: true}, //RSA Encrypt-Only, RSA Sign-Only, ElGamal
+	"sym":  {0: true, 1: true, 2: true, 3: true},   //Plaintext, IDEA, TripleDES, CAST5
+	"hash": {1: true, 2: true, 3: true},            //MD5, SHA-1, RIPE-MD/160
+	"s2k":  {0: true, 1: true},                     //Simple S2K, Salted S2K
+}
+
+//isAlgorithm returns true if value of item is algorithm ID
+func isAlgorithm(value string) bool {
+	return algIDPattern.MatchString(value)
+}
+
+//isDeprecated returns true if value of item is deprecated algorithm
+func isDeprecated(value string) bool {
+	m := algIDPattern.FindStringSubmatch(value)
+	if len(m) < 3 {
+		return false
+	}
+	id, err := strconv.Atoi(m[2])
+	if err != nil {
+		return false
+	}
+	return deprecatedAlgs[m[1]][id]
+}
+
+//isWarning returns true if text includes unknown or reserved value
+func isWarning(s string) bool {
+	for _, w := range []string{"Unknown", "unknown", "Reserved", "illegal", "invalid", "不明", "予約", "不正", "無効"} {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+/* Copyright 2021 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */